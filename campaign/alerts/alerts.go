@@ -0,0 +1,140 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertStatus represents the review state of an alert
+type AlertStatus string
+
+const (
+	StatusPending       AlertStatus = "pending"
+	StatusInvestigating AlertStatus = "investigating"
+	StatusResolved      AlertStatus = "resolved"
+	StatusDismissed     AlertStatus = "dismissed"
+)
+
+// AlertSeverity represents how serious an alert is
+type AlertSeverity string
+
+const (
+	SeverityLow      AlertSeverity = "low"
+	SeverityMedium   AlertSeverity = "medium"
+	SeverityHigh     AlertSeverity = "high"
+	SeverityCritical AlertSeverity = "critical"
+)
+
+// Alert types that can be reported
+const (
+	TypeFraud            = "fraud"
+	TypeMisuseOfFunds    = "misuse_of_funds"
+	TypeFalseInformation = "false_information"
+	TypeInappropriate    = "inappropriate_content"
+	TypeMissingUpdates   = "missing_updates"
+	TypeCashDiscrepancy  = "cash_discrepancy" // Raised when a cash batch does not add up
+	TypeOther            = "other"
+)
+
+// Alert represents the domain entity for campaign alerts
+type Alert struct {
+	ID              uuid.UUID     `json:"id"`
+	CampaignID      uuid.UUID     `json:"campaign_id"`
+	AlertType       string        `json:"alert_type"`
+	Description     string        `json:"description"`
+	Status          AlertStatus   `json:"status"`
+	Severity        AlertSeverity `json:"severity"`
+	ReportedBy      *uuid.UUID    `json:"reported_by,omitempty"`
+	ReporterIP      string        `json:"-"` // Set on public reports
+	ResolvedBy      *uuid.UUID    `json:"resolved_by,omitempty"`
+	ResolutionNotes *string       `json:"resolution_notes,omitempty"`
+	CreatedAt       time.Time     `json:"created_at"`
+	ResolvedAt      *time.Time    `json:"resolved_at,omitempty"`
+	Events          []AlertEvent  `json:"events,omitempty"`
+}
+
+// AlertEvent represents a status change or note on an alert
+type AlertEvent struct {
+	ID         uuid.UUID    `json:"id"`
+	AlertID    uuid.UUID    `json:"alert_id"`
+	FromStatus *AlertStatus `json:"from_status,omitempty"`
+	ToStatus   AlertStatus  `json:"to_status"`
+	Notes      *string      `json:"notes,omitempty"`
+	ChangedBy  *uuid.UUID   `json:"changed_by,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// ReportAlertRequest represents a public request to report a campaign
+type ReportAlertRequest struct {
+	AlertType   string        `json:"alert_type"`
+	Description string        `json:"description"`
+	Severity    AlertSeverity `json:"severity,omitempty"`
+	ReporterIP  string        `json:"-"` // Set on public reports, which are limited to one open alert per reporter
+}
+
+// UpdateAlertStatusRequest represents an admin status change on an alert
+type UpdateAlertStatusRequest struct {
+	Status AlertStatus `json:"status"`
+	Notes  *string     `json:"notes,omitempty"`
+}
+
+// AlertCounts holds the alert totals of a campaign
+type AlertCounts struct {
+	Total      int `json:"total"`
+	Unresolved int `json:"unresolved"`
+	Resolved   int `json:"resolved"`
+	Dismissed  int `json:"dismissed"`
+}
+
+// IsValidType checks if an alert type is valid
+func IsValidType(alertType string) bool {
+	switch alertType {
+	case TypeFraud, TypeMisuseOfFunds, TypeFalseInformation, TypeInappropriate, TypeMissingUpdates, TypeCashDiscrepancy, TypeOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidSeverity checks if a severity is valid
+func IsValidSeverity(severity AlertSeverity) bool {
+	switch severity {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidStatus checks if an alert status is valid
+func IsValidStatus(status AlertStatus) bool {
+	switch status {
+	case StatusPending, StatusInvestigating, StatusResolved, StatusDismissed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsClosed reports whether the alert no longer needs review
+func (s AlertStatus) IsClosed() bool {
+	return s == StatusResolved || s == StatusDismissed
+}
+
+// CanTransitionTo checks if an alert status transition is valid
+func CanTransitionTo(from, to AlertStatus) bool {
+	validTransitions := map[AlertStatus][]AlertStatus{
+		StatusPending:       {StatusInvestigating, StatusResolved, StatusDismissed},
+		StatusInvestigating: {StatusResolved, StatusDismissed},
+		StatusResolved:      {StatusInvestigating}, // Reopen
+		StatusDismissed:     {StatusInvestigating}, // Reopen
+	}
+
+	for _, allowed := range validTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
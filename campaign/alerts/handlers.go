@@ -0,0 +1,190 @@
+package alerts
+
+import (
+	"net/http"
+
+	"dona_tutti_api/middleware"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Rate limit of the public report endpoint, per client
+const (
+	reportRateLimit = 5 // requests per minute
+	reportBurst     = 3
+)
+
+// Handler handles HTTP requests for campaign alerts
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new alerts handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the alert routes
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	// Public route: anyone can report a campaign, logged in users are recorded as reporters
+	g.POST("/campaigns/:campaignId/alerts", h.ReportAlert,
+		middleware.OptionalAuth(), middleware.RateLimit(reportRateLimit, reportBurst))
+
+	// Admin routes
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.GET("/campaigns/:campaignId/alerts", h.GetAlertsByCampaign)
+	adminGroup.GET("/alerts", h.ListAlerts)
+	adminGroup.GET("/alerts/:id", h.GetAlert)
+	adminGroup.PATCH("/alerts/:id/status", h.UpdateAlertStatus)
+}
+
+// @Summary Report a campaign
+// @Description Report a problem with a campaign. Can be used anonymously or with a user token. Each reporter, identified by user or IP address, can have one open alert per campaign. Requests are rate limited per client.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "Campaign ID"
+// @Param alert body ReportAlertRequest true "Alert details"
+// @Success 201 {object} Alert
+// @Failure 400 {object} errors.APIError
+// @Failure 429 {object} errors.APIError
+// @Router /campaigns/{campaignId}/alerts [post]
+func (h *Handler) ReportAlert(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	var req ReportAlertRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	req.ReporterIP = c.RealIP()
+
+	alert, err := h.service.ReportAlert(c.Request().Context(), campaignID, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, alert)
+}
+
+// @Summary Get alerts for a campaign
+// @Description Get all alerts reported against a campaign
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {array} Alert
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/{campaignId}/alerts [get]
+func (h *Handler) GetAlertsByCampaign(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	alerts, err := h.service.GetAlertsByCampaign(c.Request().Context(), campaignID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, alerts)
+}
+
+// @Summary List alerts
+// @Description Get all alerts, optionally filtered by status
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Alert status (pending, investigating, resolved, dismissed)"
+// @Success 200 {array} Alert
+// @Failure 400 {object} errors.APIError
+// @Router /alerts [get]
+func (h *Handler) ListAlerts(c echo.Context) error {
+	var status *AlertStatus
+	if value := c.QueryParam("status"); value != "" {
+		s := AlertStatus(value)
+		status = &s
+	}
+
+	alerts, err := h.service.ListAlerts(c.Request().Context(), status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, alerts)
+}
+
+// @Summary Get alert by ID
+// @Description Get alert details including its status history
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alert ID"
+// @Success 200 {object} Alert
+// @Failure 400 {object} errors.APIError
+// @Router /alerts/{id} [get]
+func (h *Handler) GetAlert(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid alert ID")
+	}
+
+	alert, err := h.service.GetAlert(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, alert)
+}
+
+// @Summary Update alert status
+// @Description Move an alert through the review workflow (pending, investigating, resolved, dismissed). Notes are required to resolve or dismiss.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alert ID"
+// @Param request body UpdateAlertStatusRequest true "Status update"
+// @Success 200 {object} Alert
+// @Failure 400 {object} errors.APIError
+// @Router /alerts/{id}/status [patch]
+func (h *Handler) UpdateAlertStatus(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid alert ID")
+	}
+
+	var req UpdateAlertStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	alert, err := h.service.UpdateAlertStatus(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, alert)
+}
+
+// getUserID returns the authenticated user ID from the context, if any
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,116 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertModel represents the database table structure with GORM tags
+type AlertModel struct {
+	ID              uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	CampaignID      uuid.UUID  `gorm:"column:campaign_id;type:uuid;not null;index"`
+	AlertType       string     `gorm:"column:alert_type;not null"`
+	Description     string     `gorm:"column:description;not null"`
+	Status          string     `gorm:"column:status;not null;default:pending"`
+	Severity        string     `gorm:"column:severity;not null;default:medium"`
+	ReportedBy      *uuid.UUID `gorm:"column:reported_by;type:uuid"`
+	ReporterIP      *string    `gorm:"column:reporter_ip"`
+	ResolvedBy      *uuid.UUID `gorm:"column:resolved_by;type:uuid"`
+	ResolutionNotes *string    `gorm:"column:resolution_notes"`
+	CreatedAt       time.Time  `gorm:"column:created_at;autoCreateTime"`
+	ResolvedAt      *time.Time `gorm:"column:resolved_at"`
+}
+
+// TableName specifies the table name for GORM
+func (AlertModel) TableName() string {
+	return "campaign_alerts"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m AlertModel) ToEntity() Alert {
+	reporterIP := ""
+	if m.ReporterIP != nil {
+		reporterIP = *m.ReporterIP
+	}
+	return Alert{
+		ID:              m.ID,
+		CampaignID:      m.CampaignID,
+		AlertType:       m.AlertType,
+		Description:     m.Description,
+		Status:          AlertStatus(m.Status),
+		Severity:        AlertSeverity(m.Severity),
+		ReportedBy:      m.ReportedBy,
+		ReporterIP:      reporterIP,
+		ResolvedBy:      m.ResolvedBy,
+		ResolutionNotes: m.ResolutionNotes,
+		CreatedAt:       m.CreatedAt,
+		ResolvedAt:      m.ResolvedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *AlertModel) FromEntity(entity Alert) {
+	m.ID = entity.ID
+	m.CampaignID = entity.CampaignID
+	m.AlertType = entity.AlertType
+	m.Description = entity.Description
+	m.Status = string(entity.Status)
+	m.Severity = string(entity.Severity)
+	m.ReportedBy = entity.ReportedBy
+	m.ReporterIP = nil
+	if entity.ReporterIP != "" {
+		m.ReporterIP = &entity.ReporterIP
+	}
+	m.ResolvedBy = entity.ResolvedBy
+	m.ResolutionNotes = entity.ResolutionNotes
+	m.CreatedAt = entity.CreatedAt
+	m.ResolvedAt = entity.ResolvedAt
+}
+
+// AlertEventModel represents the alert status history table
+type AlertEventModel struct {
+	ID         uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	AlertID    uuid.UUID  `gorm:"column:alert_id;type:uuid;not null;index"`
+	FromStatus *string    `gorm:"column:from_status"`
+	ToStatus   string     `gorm:"column:to_status;not null"`
+	Notes      *string    `gorm:"column:notes"`
+	ChangedBy  *uuid.UUID `gorm:"column:changed_by;type:uuid"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (AlertEventModel) TableName() string {
+	return "campaign_alert_events"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m AlertEventModel) ToEntity() AlertEvent {
+	event := AlertEvent{
+		ID:        m.ID,
+		AlertID:   m.AlertID,
+		ToStatus:  AlertStatus(m.ToStatus),
+		Notes:     m.Notes,
+		ChangedBy: m.ChangedBy,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.FromStatus != nil {
+		from := AlertStatus(*m.FromStatus)
+		event.FromStatus = &from
+	}
+	return event
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *AlertEventModel) FromEntity(entity AlertEvent) {
+	m.ID = entity.ID
+	m.AlertID = entity.AlertID
+	m.ToStatus = string(entity.ToStatus)
+	m.Notes = entity.Notes
+	m.ChangedBy = entity.ChangedBy
+	m.CreatedAt = entity.CreatedAt
+	if entity.FromStatus != nil {
+		from := string(*entity.FromStatus)
+		m.FromStatus = &from
+	}
+}
@@ -0,0 +1,185 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrOpenReportExists is returned when a reporter already has an open report on a campaign
+var ErrOpenReportExists = errors.New("reporter already has an open report on this campaign")
+
+type Repository interface {
+	GetAlert(ctx context.Context, id uuid.UUID) (Alert, error)
+	ListAlerts(ctx context.Context, status *AlertStatus) ([]Alert, error)
+	GetAlertsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Alert, error)
+	GetAlertEvents(ctx context.Context, alertID uuid.UUID) ([]AlertEvent, error)
+	CreateAlert(ctx context.Context, alert Alert, event AlertEvent) error
+	UpdateAlertStatus(ctx context.Context, alert Alert, event AlertEvent) error
+	GetAlertCounts(ctx context.Context, campaignID uuid.UUID) (AlertCounts, error)
+	CampaignExists(ctx context.Context, campaignID uuid.UUID) (bool, error)
+	HasOpenReport(ctx context.Context, campaignID uuid.UUID, reportedBy *uuid.UUID, reporterIP string) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetAlert(ctx context.Context, id uuid.UUID) (Alert, error) {
+	var model AlertModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return Alert{}, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *repository) ListAlerts(ctx context.Context, status *AlertStatus) ([]Alert, error) {
+	query := r.db.WithContext(ctx)
+	if status != nil {
+		query = query.Where("status = ?", string(*status))
+	}
+
+	var models []AlertModel
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	alerts := make([]Alert, len(models))
+	for i, model := range models {
+		alerts[i] = model.ToEntity()
+	}
+
+	return alerts, nil
+}
+
+func (r *repository) GetAlertsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Alert, error) {
+	var models []AlertModel
+	if err := r.db.WithContext(ctx).Where("campaign_id = ?", campaignID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	alerts := make([]Alert, len(models))
+	for i, model := range models {
+		alerts[i] = model.ToEntity()
+	}
+
+	return alerts, nil
+}
+
+func (r *repository) GetAlertEvents(ctx context.Context, alertID uuid.UUID) ([]AlertEvent, error) {
+	var models []AlertEventModel
+	if err := r.db.WithContext(ctx).Where("alert_id = ?", alertID).Order("created_at").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]AlertEvent, len(models))
+	for i, model := range models {
+		events[i] = model.ToEntity()
+	}
+
+	return events, nil
+}
+
+// CreateAlert stores a new alert together with its initial event. The open reports index allows a
+// single open public report per reporter and campaign.
+func (r *repository) CreateAlert(ctx context.Context, alert Alert, event AlertEvent) error {
+	var model AlertModel
+	model.FromEntity(alert)
+
+	var eventModel AlertEventModel
+	eventModel.FromEntity(event)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			if translator, ok := tx.Dialector.(gorm.ErrorTranslator); ok && errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey) {
+				return ErrOpenReportExists
+			}
+			return err
+		}
+		return tx.Create(&eventModel).Error
+	})
+}
+
+// UpdateAlertStatus saves the alert review fields and records the status change event
+func (r *repository) UpdateAlertStatus(ctx context.Context, alert Alert, event AlertEvent) error {
+	var eventModel AlertEventModel
+	eventModel.FromEntity(event)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&AlertModel{}).
+			Where("id = ?", alert.ID).
+			Updates(map[string]interface{}{
+				"status":           string(alert.Status),
+				"resolved_by":      alert.ResolvedBy,
+				"resolution_notes": alert.ResolutionNotes,
+				"resolved_at":      alert.ResolvedAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&eventModel).Error
+	})
+}
+
+func (r *repository) GetAlertCounts(ctx context.Context, campaignID uuid.UUID) (AlertCounts, error) {
+	var result struct {
+		Total      int64
+		Unresolved int64
+		Resolved   int64
+		Dismissed  int64
+	}
+
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			COUNT(*) as total,
+			COUNT(CASE WHEN status IN ('pending', 'investigating') THEN 1 END) as unresolved,
+			COUNT(CASE WHEN status = 'resolved' THEN 1 END) as resolved,
+			COUNT(CASE WHEN status = 'dismissed' THEN 1 END) as dismissed
+		FROM campaign_alerts
+		WHERE campaign_id = ?
+	`, campaignID).Scan(&result).Error
+
+	if err != nil {
+		return AlertCounts{}, err
+	}
+
+	return AlertCounts{
+		Total:      int(result.Total),
+		Unresolved: int(result.Unresolved),
+		Resolved:   int(result.Resolved),
+		Dismissed:  int(result.Dismissed),
+	}, nil
+}
+
+func (r *repository) CampaignExists(ctx context.Context, campaignID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("campaigns").
+		Where("id = ? AND archived_at IS NULL", campaignID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// HasOpenReport reports whether a reporter already has a pending or investigating alert on a campaign.
+// Logged in reporters are identified by their user, anonymous ones by their IP address.
+func (r *repository) HasOpenReport(ctx context.Context, campaignID uuid.UUID, reportedBy *uuid.UUID, reporterIP string) (bool, error) {
+	query := r.db.WithContext(ctx).
+		Model(&AlertModel{}).
+		Where("campaign_id = ? AND status IN ?", campaignID, []string{string(StatusPending), string(StatusInvestigating)})
+	if reportedBy != nil {
+		query = query.Where("reported_by = ? AND reporter_ip IS NOT NULL", *reportedBy)
+	} else {
+		query = query.Where("reported_by IS NULL AND reporter_ip = ?", reporterIP)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,192 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+)
+
+// MinDescriptionLength is the minimum length of a reported alert description
+const MinDescriptionLength = 20
+
+type Service interface {
+	ReportAlert(ctx context.Context, campaignID uuid.UUID, req ReportAlertRequest, reportedBy *uuid.UUID) (Alert, error)
+	GetAlert(ctx context.Context, id uuid.UUID) (Alert, error)
+	ListAlerts(ctx context.Context, status *AlertStatus) ([]Alert, error)
+	GetAlertsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Alert, error)
+	UpdateAlertStatus(ctx context.Context, id uuid.UUID, req UpdateAlertStatusRequest, changedBy *uuid.UUID) (Alert, error)
+	GetAlertCounts(ctx context.Context, campaignID uuid.UUID) (AlertCounts, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// ReportAlert registers a new alert against a campaign. Reports can be anonymous.
+func (s *service) ReportAlert(ctx context.Context, campaignID uuid.UUID, req ReportAlertRequest, reportedBy *uuid.UUID) (Alert, error) {
+	// Validate request
+	if !IsValidType(req.AlertType) {
+		return Alert{}, apierrors.NewFieldValidationError("alert_type", fmt.Sprintf("invalid alert type: %s", req.AlertType))
+	}
+	description := strings.TrimSpace(req.Description)
+	if len(description) < MinDescriptionLength {
+		return Alert{}, apierrors.NewFieldValidationError("description",
+			fmt.Sprintf("description must be at least %d characters", MinDescriptionLength))
+	}
+	severity := req.Severity
+	if severity == "" {
+		severity = SeverityMedium
+	}
+	if !IsValidSeverity(severity) {
+		return Alert{}, apierrors.NewFieldValidationError("severity", fmt.Sprintf("invalid severity: %s", severity))
+	}
+
+	exists, err := s.repo.CampaignExists(ctx, campaignID)
+	if err != nil {
+		return Alert{}, fmt.Errorf("failed to check campaign: %w", err)
+	}
+	if !exists {
+		return Alert{}, apierrors.NewNotFoundError("campaign not found")
+	}
+
+	// Open alerts lower the transparency score, so a public reporter gets one at a time per campaign.
+	// This check gives the common case a clear error; concurrent reports are stopped by the database.
+	if req.ReporterIP != "" {
+		reported, err := s.repo.HasOpenReport(ctx, campaignID, reportedBy, req.ReporterIP)
+		if err != nil {
+			return Alert{}, fmt.Errorf("failed to check previous reports: %w", err)
+		}
+		if reported {
+			return Alert{}, apierrors.NewValidationError("you already reported this campaign and the alert is under review")
+		}
+	}
+
+	now := time.Now()
+	alert := Alert{
+		ID:          uuid.New(),
+		CampaignID:  campaignID,
+		AlertType:   req.AlertType,
+		Description: description,
+		Status:      StatusPending,
+		Severity:    severity,
+		ReportedBy:  reportedBy,
+		ReporterIP:  req.ReporterIP,
+		CreatedAt:   now,
+	}
+	event := AlertEvent{
+		ID:        uuid.New(),
+		AlertID:   alert.ID,
+		ToStatus:  StatusPending,
+		ChangedBy: reportedBy,
+		CreatedAt: now,
+	}
+
+	if err := s.repo.CreateAlert(ctx, alert, event); err != nil {
+		if errors.Is(err, ErrOpenReportExists) {
+			return Alert{}, apierrors.NewValidationError("you already reported this campaign and the alert is under review")
+		}
+		return Alert{}, fmt.Errorf("failed to create alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// GetAlert returns an alert with its status history
+func (s *service) GetAlert(ctx context.Context, id uuid.UUID) (Alert, error) {
+	alert, err := s.repo.GetAlert(ctx, id)
+	if err != nil {
+		return Alert{}, fmt.Errorf("alert not found: %w", err)
+	}
+
+	events, err := s.repo.GetAlertEvents(ctx, id)
+	if err != nil {
+		return Alert{}, fmt.Errorf("failed to get alert events: %w", err)
+	}
+	alert.Events = events
+
+	return alert, nil
+}
+
+func (s *service) ListAlerts(ctx context.Context, status *AlertStatus) ([]Alert, error) {
+	if status != nil && !IsValidStatus(*status) {
+		return nil, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid alert status: %s", *status))
+	}
+	return s.repo.ListAlerts(ctx, status)
+}
+
+func (s *service) GetAlertsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Alert, error) {
+	return s.repo.GetAlertsByCampaign(ctx, campaignID)
+}
+
+// UpdateAlertStatus moves an alert through the review workflow
+func (s *service) UpdateAlertStatus(ctx context.Context, id uuid.UUID, req UpdateAlertStatusRequest, changedBy *uuid.UUID) (Alert, error) {
+	if !IsValidStatus(req.Status) {
+		return Alert{}, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid alert status: %s", req.Status))
+	}
+
+	// Get existing alert
+	alert, err := s.repo.GetAlert(ctx, id)
+	if err != nil {
+		return Alert{}, fmt.Errorf("alert not found: %w", err)
+	}
+
+	if !CanTransitionTo(alert.Status, req.Status) {
+		return Alert{}, apierrors.NewFieldValidationError("status",
+			fmt.Sprintf("cannot change alert status from %s to %s", alert.Status, req.Status))
+	}
+
+	var notes *string
+	if req.Notes != nil && strings.TrimSpace(*req.Notes) != "" {
+		trimmed := strings.TrimSpace(*req.Notes)
+		notes = &trimmed
+	}
+
+	// Closing an alert requires explaining the outcome
+	if req.Status.IsClosed() && notes == nil {
+		return Alert{}, apierrors.NewFieldValidationError("notes", "notes are required to resolve or dismiss an alert")
+	}
+
+	now := time.Now()
+	previousStatus := alert.Status
+	alert.Status = req.Status
+	if req.Status.IsClosed() {
+		alert.ResolvedBy = changedBy
+		alert.ResolvedAt = &now
+		alert.ResolutionNotes = notes
+	} else {
+		// Reopened or under investigation
+		alert.ResolvedBy = nil
+		alert.ResolvedAt = nil
+		alert.ResolutionNotes = nil
+	}
+
+	event := AlertEvent{
+		ID:         uuid.New(),
+		AlertID:    alert.ID,
+		FromStatus: &previousStatus,
+		ToStatus:   req.Status,
+		Notes:      notes,
+		ChangedBy:  changedBy,
+		CreatedAt:  now,
+	}
+
+	if err := s.repo.UpdateAlertStatus(ctx, alert, event); err != nil {
+		return Alert{}, fmt.Errorf("failed to update alert status: %w", err)
+	}
+
+	return s.GetAlert(ctx, id)
+}
+
+func (s *service) GetAlertCounts(ctx context.Context, campaignID uuid.UUID) (AlertCounts, error) {
+	return s.repo.GetAlertCounts(ctx, campaignID)
+}
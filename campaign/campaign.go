@@ -3,6 +3,7 @@ package campaign
 import (
 	"time"
 
+	"dona_tutti_api/money"
 	"dona_tutti_api/organizer"
 
 	"github.com/google/uuid"
@@ -24,7 +25,8 @@ type Campaign struct {
 	Title            string                  `json:"title"`
 	Description      string                  `json:"description"`
 	Image            string                  `json:"image"`
-	Goal             float64                 `json:"goal"`
+	Goal             money.Amount            `json:"goal"`
+	Currency         string                  `json:"currency"` // Currency of the goal and of every amount raised
 	StartDate        time.Time               `json:"start_date"`
 	EndDate          time.Time               `json:"end_date"`
 	Location         string                  `json:"location"`
@@ -39,6 +41,39 @@ type Campaign struct {
 	BeneficiaryAge   *int                    `json:"beneficiary_age,omitempty"`
 	CurrentSituation *string                 `json:"current_situation,omitempty"`
 	UrgencyReason    *string                 `json:"urgency_reason,omitempty"`
+	ArchivedAt       *time.Time              `json:"archived_at,omitempty"`
+	MatchingPledges  []MatchingPledge        `json:"matching_pledges,omitempty"` // Only loaded for a single campaign
+	DonationStats
+}
+
+// DonationStats represents the aggregated completed donations of a campaign, net of refunds
+type DonationStats struct {
+	RaisedAmount       money.Amount `json:"raised_amount"`
+	MatchedAmount      money.Amount `json:"matched_amount"` // Part of RaisedAmount given by sponsors matching donations
+	DonorCount         int          `json:"donor_count"`
+	DonationCount      int          `json:"donation_count"`
+	ProgressPercentage float64      `json:"progress_percentage"`
+}
+
+// MatchingPledge is the public state of a sponsor matching the donations of a campaign
+type MatchingPledge struct {
+	ID              uuid.UUID    `json:"id"`
+	SponsorName     string       `json:"sponsor_name"`
+	Ratio           float64      `json:"ratio"`
+	Cap             money.Amount `json:"cap"`
+	MatchedAmount   money.Amount `json:"matched_amount"`
+	RemainingAmount money.Amount `json:"remaining_amount"`
+	StartsAt        time.Time    `json:"starts_at"`
+	EndsAt          time.Time    `json:"ends_at"`
+	IsOpen          bool         `json:"is_open"` // Matching new donations now
+}
+
+// CampaignSearchResult represents a campaign matched by full-text search
+type CampaignSearchResult struct {
+	Campaign
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
 }
 
 // Valid campaign statuses
@@ -63,6 +98,26 @@ func ValidStatuses() []string {
 	}
 }
 
+// PublicListStatuses returns the statuses shown in the public listing when no status filter is given
+func PublicListStatuses() []string {
+	return []string{
+		StatusPendingApproval,
+		StatusActive,
+		StatusPaused,
+		StatusCompleted,
+	}
+}
+
+// isPublicListStatus reports whether campaigns with a status can be listed publicly
+func isPublicListStatus(status string) bool {
+	for _, public := range PublicListStatuses() {
+		if status == public {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValidStatus checks if a status is valid
 func IsValidStatus(status string) bool {
 	validStatuses := map[string]bool{
@@ -100,8 +155,70 @@ func CanTransitionTo(from, to string) bool {
 	return false
 }
 
+// CampaignEdit represents a single field change made to a campaign
+type CampaignEdit struct {
+	ID             uuid.UUID  `json:"id"`
+	CampaignID     uuid.UUID  `json:"campaign_id"`
+	EditedBy       *uuid.UUID `json:"edited_by,omitempty"`
+	CampaignStatus string     `json:"campaign_status"`
+	Field          string     `json:"field"`
+	OldValue       string     `json:"old_value"`
+	NewValue       string     `json:"new_value"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// MaxActiveGoalIncrease is the maximum fraction the goal of an active campaign can grow over the goal
+// it was activated with
+const MaxActiveGoalIncrease = 0.5
+
+// IsEditableStatus checks if a campaign in the given status can be edited at all
+func IsEditableStatus(status string) bool {
+	return status == StatusDraft || status == StatusActive || status == StatusPaused
+}
+
+// CanEditField checks if a field can be edited while the campaign is in the given status.
+// Drafts are freely editable; active and paused campaigns only allow a restricted set of fields.
+func CanEditField(status, field string) bool {
+	if status == StatusDraft {
+		return true
+	}
+
+	restrictedFields := map[string]bool{
+		"description":       true,
+		"goal":              true,
+		"end_date":          true,
+		"urgency":           true,
+		"urgency_reason":    true,
+		"current_situation": true,
+	}
+
+	if status == StatusActive || status == StatusPaused {
+		return restrictedFields[field]
+	}
+	return false
+}
+
+// NewDonorsWindowDays is the look-back window used for the new donors statistic
+const NewDonorsWindowDays = 30
+
+// Summary holds the platform statistics. Amounts are in Currency; campaigns in other currencies are not included.
 type Summary struct {
-	TotalCampaigns    int64   `json:"total_campaigns"`
-	TotalGoal         float64 `json:"total_goal"`
-	TotalContributors int64   `json:"total_contributors"`
+	Currency            string           `json:"currency"`
+	TotalCampaigns      int64            `json:"total_campaigns"`
+	TotalGoal           money.Amount     `json:"total_goal"`
+	TotalContributors   int64            `json:"total_contributors"`
+	TotalRaised         money.Amount     `json:"total_raised"`
+	TotalDonations      int64            `json:"total_donations"`
+	AverageDonation     money.Amount     `json:"average_donation"`
+	CampaignsByStatus   map[string]int64 `json:"campaigns_by_status"`
+	ActiveOrganizers    int64            `json:"active_organizers"`
+	NewDonorsLast30Days int64            `json:"new_donors_last_30_days"`
+}
+
+// SummaryFilter represents the optional filters for the platform summary
+type SummaryFilter struct {
+	Currency   string
+	DateFrom   *time.Time
+	DateTo     *time.Time
+	CategoryID *uuid.UUID
 }
@@ -3,6 +3,8 @@ package closure
 import (
 	"time"
 
+	"dona_tutti_api/money"
+
 	"github.com/google/uuid"
 )
 
@@ -45,38 +47,72 @@ type CampaignClosureReport struct {
 	ClosureType           ClosureType           `json:"closure_type"`
 	ClosureReason         *string               `json:"closure_reason,omitempty"`
 	ClosedBy              *uuid.UUID            `json:"closed_by,omitempty"`
-	TotalRaised           float64               `json:"total_raised"`
+	Currency              string                `json:"currency"` // Currency of every amount in the report
+	TotalRaised           money.Amount          `json:"total_raised"`
+	OrganicRaised         money.Amount          `json:"organic_raised"` // Given by donors
+	MatchedRaised         money.Amount          `json:"matched_raised"` // Given by sponsors matching donations
 	TotalDonors           int                   `json:"total_donors"`
 	TotalDonations        int                   `json:"total_donations"`
-	CampaignGoal          float64               `json:"campaign_goal"`
+	CampaignGoal          money.Amount          `json:"campaign_goal"`
 	GoalPercentage        float64               `json:"goal_percentage"`
-	TotalExpenses         float64               `json:"total_expenses"`
+	TotalExpenses         money.Amount          `json:"total_expenses"`
 	TotalReceipts         int                   `json:"total_receipts"`
 	ReceiptsWithDocuments int                   `json:"receipts_with_documents"`
 	TotalActivities       int                   `json:"total_activities"`
+	ActivityIntervalDays  float64               `json:"activity_interval_days"` // Average days between activities
+	CampaignStart         time.Time             `json:"campaign_start"`
+	CampaignEnd           time.Time             `json:"campaign_end"`
+	HasContract           bool                  `json:"has_contract"`
 	TransparencyScore     float64               `json:"transparency_score"`
 	TransparencyBreakdown TransparencyBreakdown `json:"transparency_breakdown"`
 	AlertsCount           int                   `json:"alerts_count"`
 	AlertsResolved        int                   `json:"alerts_resolved"`
 	ReportPdfURL          *string               `json:"report_pdf_url,omitempty"`
 	ReportHash            *string               `json:"report_hash,omitempty"`
+	RulesVersion          int                   `json:"rules_version"`
 	ClosedAt              time.Time             `json:"closed_at"`
 	CreatedAt             time.Time             `json:"created_at"`
 }
 
+// ScoringMetrics returns the transparency score inputs recorded when the campaign was closed
+func (r CampaignClosureReport) ScoringMetrics() ClosureMetrics {
+	return ClosureMetrics{
+		CampaignGoal:                 r.CampaignGoal,
+		CampaignStart:                r.CampaignStart,
+		CampaignEnd:                  r.CampaignEnd,
+		HasContract:                  r.HasContract,
+		Currency:                     r.Currency,
+		TotalRaised:                  r.TotalRaised,
+		OrganicRaised:                r.OrganicRaised,
+		MatchedRaised:                r.MatchedRaised,
+		TotalDonors:                  r.TotalDonors,
+		TotalDonations:               r.TotalDonations,
+		TotalExpenses:                r.TotalExpenses,
+		TotalReceipts:                r.TotalReceipts,
+		ReceiptsWithDocuments:        r.ReceiptsWithDocuments,
+		TotalActivities:              r.TotalActivities,
+		AverageDaysBetweenActivities: r.ActivityIntervalDays,
+		AlertsCount:                  r.AlertsCount,
+		AlertsResolved:               r.AlertsResolved,
+	}
+}
+
 // PublicAuditReport is the public version for donors
 type PublicAuditReport struct {
-	CampaignID        uuid.UUID `json:"campaign_id"`
-	CampaignTitle     string    `json:"campaign_title"`
-	OrganizerName     string    `json:"organizer_name"`
-	ClosedAt          time.Time `json:"closed_at"`
-	TotalRaised       float64   `json:"total_raised"`
-	CampaignGoal      float64   `json:"campaign_goal"`
-	GoalPercentage    float64   `json:"goal_percentage"`
-	TotalDonors       int       `json:"total_donors"`
-	TotalExpenses     float64   `json:"total_expenses"`
-	TransparencyScore float64   `json:"transparency_score"`
-	ReportPdfURL      *string   `json:"report_pdf_url,omitempty"`
+	CampaignID        uuid.UUID    `json:"campaign_id"`
+	CampaignTitle     string       `json:"campaign_title"`
+	OrganizerName     string       `json:"organizer_name"`
+	ClosedAt          time.Time    `json:"closed_at"`
+	Currency          string       `json:"currency"`
+	TotalRaised       money.Amount `json:"total_raised"`
+	OrganicRaised     money.Amount `json:"organic_raised"`
+	MatchedRaised     money.Amount `json:"matched_raised"`
+	CampaignGoal      money.Amount `json:"campaign_goal"`
+	GoalPercentage    float64      `json:"goal_percentage"`
+	TotalDonors       int          `json:"total_donors"`
+	TotalExpenses     money.Amount `json:"total_expenses"`
+	TransparencyScore float64      `json:"transparency_score"`
+	ReportPdfURL      *string      `json:"report_pdf_url,omitempty"`
 }
 
 // CloseCampaignRequest for manual closure
@@ -87,7 +123,7 @@ type CloseCampaignRequest struct {
 // ClosureMetrics holds the data needed to calculate the closure report
 type ClosureMetrics struct {
 	// Campaign data
-	CampaignGoal   float64
+	CampaignGoal   money.Amount
 	CampaignStart  time.Time
 	CampaignEnd    time.Time
 	HasContract    bool
@@ -96,29 +132,42 @@ type ClosureMetrics struct {
 	OrganizerID    uuid.UUID
 
 	// Donations
-	TotalRaised    float64
-	TotalDonors    int
-	TotalDonations int
+	Currency         string
+	TotalRaised      money.Amount
+	OrganicRaised    money.Amount
+	MatchedRaised    money.Amount
+	TotalDonors      int
+	TotalDonations   int
+	RaisedByCurrency []CurrencyTotal
 
 	// Receipts
-	TotalExpenses         float64
+	TotalExpenses         money.Amount
 	TotalReceipts         int
 	ReceiptsWithDocuments int
 
 	// Activities
 	TotalActivities              int
 	AverageDaysBetweenActivities float64
+	LastActivityDate             *time.Time
 
-	// Alerts (placeholder)
+	// Alerts
 	AlertsCount    int
 	AlertsResolved int
 }
 
+// CurrencyTotal is the money raised in one of the currencies donors paid with, net of refunds
+type CurrencyTotal struct {
+	Currency       string       `json:"currency"`
+	OriginalAmount money.Amount `json:"original_amount"` // In Currency
+	Amount         money.Amount `json:"amount"`          // Converted to the campaign currency
+	Donations      int          `json:"donations"`
+}
+
 // AuditReportData contains all data needed to generate the PDF
 type AuditReportData struct {
 	CampaignID      uuid.UUID
 	CampaignTitle   string
-	CampaignGoal    float64
+	CampaignGoal    money.Amount
 	OrganizerName   string
 	StartDate       time.Time
 	EndDate         time.Time
@@ -127,13 +176,17 @@ type AuditReportData struct {
 	ClosureReason   *string
 
 	// Financial
-	TotalRaised    float64
-	GoalPercentage float64
-	TotalDonors    int
-	TotalDonations int
+	Currency         string
+	RaisedByCurrency []CurrencyTotal
+	TotalRaised      money.Amount
+	OrganicRaised    money.Amount
+	MatchedRaised    money.Amount
+	GoalPercentage   float64
+	TotalDonors      int
+	TotalDonations   int
 
 	// Expenses
-	TotalExpenses         float64
+	TotalExpenses         money.Amount
 	TotalReceipts         int
 	ReceiptsWithDocuments int
 	Receipts              []ReceiptSummary
@@ -145,15 +198,20 @@ type AuditReportData struct {
 	// Transparency
 	TransparencyScore     float64
 	TransparencyBreakdown TransparencyBreakdown
+	RulesVersion          int
+	ScoringRules          ScoringRules
+
+	// Edits made after the contract was accepted
+	CampaignEdits []CampaignEditSummary
 }
 
 // ReceiptSummary for PDF display
 type ReceiptSummary struct {
-	Provider    string    `json:"provider"`
-	Name        string    `json:"name"`
-	Total       float64   `json:"total"`
-	Date        time.Time `json:"date"`
-	HasDocument bool      `json:"has_document"`
+	Provider    string       `json:"provider"`
+	Name        string       `json:"name"`
+	Total       money.Amount `json:"total"`
+	Date        time.Time    `json:"date"`
+	HasDocument bool         `json:"has_document"`
 }
 
 // ActivitySummary for PDF display
@@ -162,3 +220,49 @@ type ActivitySummary struct {
 	Type  string    `json:"type"`
 	Date  time.Time `json:"date"`
 }
+
+// CampaignEditSummary for PDF display
+type CampaignEditSummary struct {
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TransparencyHint is an actionable suggestion to improve the transparency score
+type TransparencyHint struct {
+	Category        string  `json:"category"`
+	Message         string  `json:"message"`
+	PotentialPoints float64 `json:"potential_points"`
+}
+
+// TransparencyPreview is the live transparency score of a campaign that is not closed yet
+type TransparencyPreview struct {
+	CampaignID            uuid.UUID             `json:"campaign_id"`
+	CampaignStatus        string                `json:"campaign_status"`
+	TransparencyScore     float64               `json:"transparency_score"`
+	TransparencyBreakdown TransparencyBreakdown `json:"transparency_breakdown"`
+	RulesVersion          int                   `json:"rules_version"`
+	Hints                 []TransparencyHint    `json:"hints"`
+	CalculatedAt          time.Time             `json:"calculated_at"`
+}
+
+// ClosureCandidate represents a campaign eligible for automatic closure
+type ClosureCandidate struct {
+	CampaignID     uuid.UUID    `json:"campaign_id"`
+	CampaignTitle  string       `json:"campaign_title"`
+	CampaignStatus string       `json:"campaign_status"`
+	CampaignGoal   money.Amount `json:"campaign_goal"`
+	TotalRaised    money.Amount `json:"total_raised"`
+	EndDate        time.Time    `json:"end_date"`
+	ClosureType    ClosureType  `json:"closure_type"`
+}
+
+// AutoClosureResult summarizes a run of the automatic closure process
+type AutoClosureResult struct {
+	DryRun     bool               `json:"dry_run"`
+	RunAt      time.Time          `json:"run_at"`
+	Candidates []ClosureCandidate `json:"candidates"`
+	Closed     []uuid.UUID        `json:"closed"`
+	Failed     map[string]string  `json:"failed,omitempty"`
+}
@@ -2,6 +2,7 @@ package closure
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -18,16 +19,26 @@ func NewHandler(service Service) *Handler {
 }
 
 // RegisterRoutes registers the closure routes
-func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc, adminOrOwnerMiddleware echo.MiddlewareFunc) {
 	// Public routes (no authentication required)
 	g.GET("/campaigns/:id/audit", h.GetPublicAuditReport)
 	g.GET("/campaigns/:id/audit/download", h.DownloadAuditPDF)
 
-	// Protected routes - require authentication first, then admin role
+	// Protected routes - require authentication first, then admin role or campaign ownership
 	authGroup := g.Group("", authMiddleware)
+	adminOrOwnerGroup := authGroup.Group("", adminOrOwnerMiddleware)
+	adminOrOwnerGroup.GET("/campaigns/:id/transparency", h.GetTransparencyPreview)
+
 	adminGroup := authGroup.Group("", adminMiddleware)
 	adminGroup.POST("/campaigns/:id/close", h.CloseCampaign)
 	adminGroup.GET("/campaigns/:id/closure-report", h.GetClosureReport)
+	adminGroup.GET("/campaigns/auto-closure/dry-run", h.AutoClosureDryRun)
+	adminGroup.POST("/campaigns/:id/rescore", h.RescoreCampaign)
+	adminGroup.GET("/campaigns/:id/rescores", h.ListRescores)
+	adminGroup.GET("/transparency/rules", h.ListScoringRules)
+	adminGroup.GET("/transparency/rules/active", h.GetActiveScoringRules)
+	adminGroup.POST("/transparency/rules", h.CreateScoringRules)
+	adminGroup.POST("/transparency/rules/:version/activate", h.ActivateScoringRules)
 }
 
 // CloseCampaignRequestDTO represents the request to close a campaign
@@ -82,11 +93,8 @@ func (h *Handler) CloseCampaign(c echo.Context) error {
 		})
 	}
 
-	// Get admin user ID from context (assuming it's set by auth middleware)
-	var closedBy *uuid.UUID
-	if userID, ok := c.Get("user_id").(uuid.UUID); ok {
-		closedBy = &userID
-	}
+	// Get admin user ID from context (set by auth middleware)
+	closedBy := getUserID(c)
 
 	// Set reason pointer
 	var reason *string
@@ -209,3 +217,233 @@ func (h *Handler) DownloadAuditPDF(c echo.Context) error {
 	// Redirect to PDF URL
 	return c.Redirect(http.StatusFound, *report.ReportPdfURL)
 }
+
+// AutoClosureDryRun handles GET /api/campaigns/auto-closure/dry-run
+// @Summary Preview automatic campaign closures (admin)
+// @Description Lists the active or paused campaigns that the auto-closure scheduler would close, without closing them
+// @Tags campaign-closure
+// @Accept json
+// @Produce json
+// @Success 200 {object} AutoClosureResult "Closure candidates"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/campaigns/auto-closure/dry-run [get]
+func (h *Handler) AutoClosureDryRun(c echo.Context) error {
+	result, err := h.service.RunAutoClosure(c.Request().Context(), true)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetTransparencyPreview handles GET /api/campaigns/:id/transparency
+// @Summary Preview the transparency score of an open campaign
+// @Description Computes the current transparency score breakdown of an active or paused campaign with hints on how to improve it. Only available to the campaign owner and admins.
+// @Tags campaign-closure
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} TransparencyPreview "Transparency preview"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 403 {object} map[string]interface{} "Not the campaign owner"
+// @Router /api/campaigns/{id}/transparency [get]
+func (h *Handler) GetTransparencyPreview(c echo.Context) error {
+	// Parse campaign ID from URL
+	campaignID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": "Invalid campaign ID format",
+		})
+	}
+
+	preview, err := h.service.GetTransparencyPreview(c.Request().Context(), campaignID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, preview)
+}
+
+// ListScoringRules handles GET /api/transparency/rules
+// @Summary List transparency scoring rules versions (admin)
+// @Description Lists all versions of the transparency scoring rules, newest first
+// @Tags campaign-closure
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} ScoringRulesVersion "Scoring rules versions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/transparency/rules [get]
+func (h *Handler) ListScoringRules(c echo.Context) error {
+	versions, err := h.service.ListScoringRules(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, versions)
+}
+
+// GetActiveScoringRules handles GET /api/transparency/rules/active
+// @Summary Get the active transparency scoring rules (admin)
+// @Description Retrieves the scoring rules version currently used for new closures
+// @Tags campaign-closure
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ScoringRulesVersion "Active scoring rules"
+// @Failure 404 {object} map[string]interface{} "No active rules"
+// @Router /api/transparency/rules/active [get]
+func (h *Handler) GetActiveScoringRules(c echo.Context) error {
+	rules, err := h.service.GetActiveScoringRules(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+// CreateScoringRules handles POST /api/transparency/rules
+// @Summary Publish a new transparency scoring rules version (admin)
+// @Description Creates a new immutable scoring rules version, optionally activating it
+// @Tags campaign-closure
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateScoringRulesRequest true "Scoring rules"
+// @Success 201 {object} ScoringRulesVersion "Created scoring rules"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /api/transparency/rules [post]
+func (h *Handler) CreateScoringRules(c echo.Context) error {
+	var req CreateScoringRulesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": "Invalid request body",
+		})
+	}
+
+	rules, err := h.service.CreateScoringRules(c.Request().Context(), req, getUserID(c))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, rules)
+}
+
+// ActivateScoringRules handles POST /api/transparency/rules/:version/activate
+// @Summary Activate a transparency scoring rules version (admin)
+// @Description Makes the given version the one used for new closures and previews
+// @Tags campaign-closure
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param version path int true "Rules version"
+// @Success 204 "Activated"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /api/transparency/rules/{version}/activate [post]
+func (h *Handler) ActivateScoringRules(c echo.Context) error {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": "Invalid rules version",
+		})
+	}
+
+	if err := h.service.ActivateScoringRules(c.Request().Context(), version); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RescoreCampaign handles POST /api/campaigns/:id/rescore
+// @Summary Re-score a closed campaign (admin)
+// @Description Scores a closed campaign under another rules version without modifying the original closure report
+// @Tags campaign-closure
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Param request body RescoreRequest false "Rules version (defaults to the active version)"
+// @Success 201 {object} TransparencyRescore "Rescore"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /api/campaigns/{id}/rescore [post]
+func (h *Handler) RescoreCampaign(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": "Invalid campaign ID format",
+		})
+	}
+
+	var req RescoreRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": "Invalid request body",
+		})
+	}
+
+	rescore, err := h.service.RescoreCampaign(c.Request().Context(), campaignID, req.RulesVersion, getUserID(c))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, rescore)
+}
+
+// ListRescores handles GET /api/campaigns/:id/rescores
+// @Summary List re-scores of a closed campaign (admin)
+// @Description Lists the transparency scores computed for a closed campaign under other rules versions
+// @Tags campaign-closure
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {array} TransparencyRescore "Rescores"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Router /api/campaigns/{id}/rescores [get]
+func (h *Handler) ListRescores(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": "Invalid campaign ID format",
+		})
+	}
+
+	rescores, err := h.service.ListRescores(c.Request().Context(), campaignID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, rescores)
+}
+
+// getUserID returns the authenticated user ID from the context, if any
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"time"
 
+	"dona_tutti_api/money"
+
 	"github.com/google/uuid"
 )
 
@@ -35,21 +37,29 @@ type CampaignClosureReportModel struct {
 	ClosureType           string                    `gorm:"column:closure_type;type:varchar(50);not null"`
 	ClosureReason         *string                   `gorm:"column:closure_reason;type:text"`
 	ClosedBy              *uuid.UUID                `gorm:"column:closed_by;type:uuid"`
-	TotalRaised           float64                   `gorm:"column:total_raised;type:decimal(12,2);not null;default:0"`
+	Currency              string                    `gorm:"column:currency;type:varchar(3);not null;default:ARS"`
+	TotalRaised           money.Amount              `gorm:"column:total_raised;type:decimal(12,2);not null;default:0"`
+	OrganicRaised         money.Amount              `gorm:"column:organic_raised;type:decimal(12,2);not null;default:0"`
+	MatchedRaised         money.Amount              `gorm:"column:matched_raised;type:decimal(12,2);not null;default:0"`
 	TotalDonors           int                       `gorm:"column:total_donors;not null;default:0"`
 	TotalDonations        int                       `gorm:"column:total_donations;not null;default:0"`
-	CampaignGoal          float64                   `gorm:"column:campaign_goal;type:decimal(12,2);not null"`
+	CampaignGoal          money.Amount              `gorm:"column:campaign_goal;type:decimal(12,2);not null"`
 	GoalPercentage        float64                   `gorm:"column:goal_percentage;type:decimal(5,2);not null;default:0"`
-	TotalExpenses         float64                   `gorm:"column:total_expenses;type:decimal(12,2);not null;default:0"`
+	TotalExpenses         money.Amount              `gorm:"column:total_expenses;type:decimal(12,2);not null;default:0"`
 	TotalReceipts         int                       `gorm:"column:total_receipts;not null;default:0"`
 	ReceiptsWithDocuments int                       `gorm:"column:receipts_with_documents;not null;default:0"`
 	TotalActivities       int                       `gorm:"column:total_activities;not null;default:0"`
+	ActivityIntervalDays  float64                   `gorm:"column:activity_interval_days;type:decimal(8,2);not null;default:30"`
+	CampaignStart         time.Time                 `gorm:"column:campaign_start;not null"`
+	CampaignEnd           time.Time                 `gorm:"column:campaign_end;not null"`
+	HasContract           bool                      `gorm:"column:has_contract;not null;default:false"`
 	TransparencyScore     float64                   `gorm:"column:transparency_score;type:decimal(5,2);not null;default:0"`
 	TransparencyBreakdown TransparencyBreakdownJSON `gorm:"column:transparency_breakdown;type:jsonb"`
 	AlertsCount           int                       `gorm:"column:alerts_count;not null;default:0"`
 	AlertsResolved        int                       `gorm:"column:alerts_resolved;not null;default:0"`
 	ReportPdfURL          *string                   `gorm:"column:report_pdf_url;type:text"`
 	ReportHash            *string                   `gorm:"column:report_hash;type:varchar(64)"`
+	RulesVersion          int                       `gorm:"column:rules_version;not null;default:1"`
 	ClosedAt              time.Time                 `gorm:"column:closed_at;not null"`
 	CreatedAt             time.Time                 `gorm:"column:created_at;autoCreateTime"`
 }
@@ -67,7 +77,10 @@ func (m CampaignClosureReportModel) ToEntity() CampaignClosureReport {
 		ClosureType:           ClosureType(m.ClosureType),
 		ClosureReason:         m.ClosureReason,
 		ClosedBy:              m.ClosedBy,
+		Currency:              m.Currency,
 		TotalRaised:           m.TotalRaised,
+		OrganicRaised:         m.OrganicRaised,
+		MatchedRaised:         m.MatchedRaised,
 		TotalDonors:           m.TotalDonors,
 		TotalDonations:        m.TotalDonations,
 		CampaignGoal:          m.CampaignGoal,
@@ -76,12 +89,17 @@ func (m CampaignClosureReportModel) ToEntity() CampaignClosureReport {
 		TotalReceipts:         m.TotalReceipts,
 		ReceiptsWithDocuments: m.ReceiptsWithDocuments,
 		TotalActivities:       m.TotalActivities,
+		ActivityIntervalDays:  m.ActivityIntervalDays,
+		CampaignStart:         m.CampaignStart,
+		CampaignEnd:           m.CampaignEnd,
+		HasContract:           m.HasContract,
 		TransparencyScore:     m.TransparencyScore,
 		TransparencyBreakdown: TransparencyBreakdown(m.TransparencyBreakdown),
 		AlertsCount:           m.AlertsCount,
 		AlertsResolved:        m.AlertsResolved,
 		ReportPdfURL:          m.ReportPdfURL,
 		ReportHash:            m.ReportHash,
+		RulesVersion:          m.RulesVersion,
 		ClosedAt:              m.ClosedAt,
 		CreatedAt:             m.CreatedAt,
 	}
@@ -94,7 +112,10 @@ func (m *CampaignClosureReportModel) FromEntity(entity CampaignClosureReport) {
 	m.ClosureType = string(entity.ClosureType)
 	m.ClosureReason = entity.ClosureReason
 	m.ClosedBy = entity.ClosedBy
+	m.Currency = entity.Currency
 	m.TotalRaised = entity.TotalRaised
+	m.OrganicRaised = entity.OrganicRaised
+	m.MatchedRaised = entity.MatchedRaised
 	m.TotalDonors = entity.TotalDonors
 	m.TotalDonations = entity.TotalDonations
 	m.CampaignGoal = entity.CampaignGoal
@@ -103,32 +124,117 @@ func (m *CampaignClosureReportModel) FromEntity(entity CampaignClosureReport) {
 	m.TotalReceipts = entity.TotalReceipts
 	m.ReceiptsWithDocuments = entity.ReceiptsWithDocuments
 	m.TotalActivities = entity.TotalActivities
+	m.ActivityIntervalDays = entity.ActivityIntervalDays
+	m.CampaignStart = entity.CampaignStart
+	m.CampaignEnd = entity.CampaignEnd
+	m.HasContract = entity.HasContract
 	m.TransparencyScore = entity.TransparencyScore
 	m.TransparencyBreakdown = TransparencyBreakdownJSON(entity.TransparencyBreakdown)
 	m.AlertsCount = entity.AlertsCount
 	m.AlertsResolved = entity.AlertsResolved
 	m.ReportPdfURL = entity.ReportPdfURL
 	m.ReportHash = entity.ReportHash
+	m.RulesVersion = entity.RulesVersion
 	m.ClosedAt = entity.ClosedAt
 	m.CreatedAt = entity.CreatedAt
 }
 
-// CampaignAlertModel represents the database table for alerts (placeholder)
-type CampaignAlertModel struct {
-	ID              uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
-	CampaignID      uuid.UUID  `gorm:"column:campaign_id;type:uuid;not null;index"`
-	AlertType       string     `gorm:"column:alert_type;type:varchar(50);not null"`
-	Description     string     `gorm:"column:description;type:text;not null"`
-	Status          string     `gorm:"column:status;type:varchar(20);not null;default:pending"`
-	Severity        string     `gorm:"column:severity;type:varchar(20);not null;default:medium"`
-	ReportedBy      *uuid.UUID `gorm:"column:reported_by;type:uuid"`
-	ResolvedBy      *uuid.UUID `gorm:"column:resolved_by;type:uuid"`
-	ResolutionNotes *string    `gorm:"column:resolution_notes;type:text"`
-	CreatedAt       time.Time  `gorm:"column:created_at;autoCreateTime"`
-	ResolvedAt      *time.Time `gorm:"column:resolved_at"`
+// ScoringRulesJSON for JSONB in PostgreSQL
+type ScoringRulesJSON ScoringRules
+
+// Value implements the driver.Valuer interface
+func (r ScoringRulesJSON) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface
+func (r *ScoringRulesJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// ScoringRulesModel represents the transparency scoring rules table
+type ScoringRulesModel struct {
+	Version   int              `gorm:"primaryKey;column:version;autoIncrement"`
+	Rules     ScoringRulesJSON `gorm:"column:rules;type:jsonb;not null"`
+	Notes     *string          `gorm:"column:notes;type:text"`
+	IsActive  bool             `gorm:"column:is_active;not null;default:false"`
+	CreatedBy *uuid.UUID       `gorm:"column:created_by;type:uuid"`
+	CreatedAt time.Time        `gorm:"column:created_at;autoCreateTime"`
 }
 
 // TableName specifies the table name for GORM
-func (CampaignAlertModel) TableName() string {
-	return "campaign_alerts"
+func (ScoringRulesModel) TableName() string {
+	return "transparency_scoring_rules"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m ScoringRulesModel) ToEntity() ScoringRulesVersion {
+	return ScoringRulesVersion{
+		Version:   m.Version,
+		Rules:     ScoringRules(m.Rules),
+		Notes:     m.Notes,
+		IsActive:  m.IsActive,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *ScoringRulesModel) FromEntity(entity ScoringRulesVersion) {
+	m.Version = entity.Version
+	m.Rules = ScoringRulesJSON(entity.Rules)
+	m.Notes = entity.Notes
+	m.IsActive = entity.IsActive
+	m.CreatedBy = entity.CreatedBy
+	m.CreatedAt = entity.CreatedAt
+}
+
+// TransparencyRescoreModel represents the transparency rescores table
+type TransparencyRescoreModel struct {
+	ID                    uuid.UUID                 `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	ClosureReportID       uuid.UUID                 `gorm:"column:closure_report_id;type:uuid;not null"`
+	CampaignID            uuid.UUID                 `gorm:"column:campaign_id;type:uuid;not null;index"`
+	RulesVersion          int                       `gorm:"column:rules_version;not null"`
+	TransparencyScore     float64                   `gorm:"column:transparency_score;type:decimal(5,2);not null"`
+	TransparencyBreakdown TransparencyBreakdownJSON `gorm:"column:transparency_breakdown;type:jsonb;not null"`
+	RescoredBy            *uuid.UUID                `gorm:"column:rescored_by;type:uuid"`
+	CreatedAt             time.Time                 `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (TransparencyRescoreModel) TableName() string {
+	return "transparency_rescores"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m TransparencyRescoreModel) ToEntity() TransparencyRescore {
+	return TransparencyRescore{
+		ID:                    m.ID,
+		ClosureReportID:       m.ClosureReportID,
+		CampaignID:            m.CampaignID,
+		RulesVersion:          m.RulesVersion,
+		TransparencyScore:     m.TransparencyScore,
+		TransparencyBreakdown: TransparencyBreakdown(m.TransparencyBreakdown),
+		RescoredBy:            m.RescoredBy,
+		CreatedAt:             m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *TransparencyRescoreModel) FromEntity(entity TransparencyRescore) {
+	m.ID = entity.ID
+	m.ClosureReportID = entity.ClosureReportID
+	m.CampaignID = entity.CampaignID
+	m.RulesVersion = entity.RulesVersion
+	m.TransparencyScore = entity.TransparencyScore
+	m.TransparencyBreakdown = TransparencyBreakdownJSON(entity.TransparencyBreakdown)
+	m.RescoredBy = entity.RescoredBy
+	m.CreatedAt = entity.CreatedAt
 }
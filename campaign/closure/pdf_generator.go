@@ -62,17 +62,33 @@ func (g *pdfGenerator) Generate(data AuditReportData) ([]byte, string, error) {
 	pdf.Ln(8)
 
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(95, 6, fmt.Sprintf("Meta de recaudacion: $%.2f", data.CampaignGoal), "", 0, "L", false, 0, "")
-	pdf.CellFormat(95, 6, fmt.Sprintf("Total recaudado: $%.2f", data.TotalRaised), "", 1, "L", false, 0, "")
+	pdf.CellFormat(95, 6, fmt.Sprintf("Meta de recaudacion: %s", data.CampaignGoal.Format(data.Currency)), "", 0, "L", false, 0, "")
+	pdf.CellFormat(95, 6, fmt.Sprintf("Total recaudado: %s", data.TotalRaised.Format(data.Currency)), "", 1, "L", false, 0, "")
 	pdf.CellFormat(95, 6, fmt.Sprintf("Total donantes: %d", data.TotalDonors), "", 0, "L", false, 0, "")
 	pdf.CellFormat(95, 6, fmt.Sprintf("Total donaciones: %d", data.TotalDonations), "", 1, "L", false, 0, "")
+
+	// Funds given by sponsors matching donations, shown apart from what donors gave
+	if data.MatchedRaised > 0 {
+		pdf.CellFormat(95, 6, fmt.Sprintf("Aportado por donantes: %s", data.OrganicRaised.Format(data.Currency)), "", 0, "L", false, 0, "")
+		pdf.CellFormat(95, 6, fmt.Sprintf("Aportado por contrapartidas: %s", data.MatchedRaised.Format(data.Currency)), "", 1, "L", false, 0, "")
+	}
+
+	// Donations made in other currencies, with the amount they were converted to
+	for _, total := range data.RaisedByCurrency {
+		if total.Currency == data.Currency {
+			continue
+		}
+		pdf.CellFormat(190, 6, fmt.Sprintf("Donado en %s: %s (%d donaciones, convertido a %s)",
+			total.Currency, total.OriginalAmount.Format(total.Currency), total.Donations,
+			total.Amount.Format(data.Currency)), "", 1, "L", false, 0, "")
+	}
 	pdf.Ln(5)
 
 	// Expenses Section
 	g.addSectionTitle(pdf, "DETALLE DE GASTOS")
 
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(95, 6, fmt.Sprintf("Total gastos documentados: $%.2f", data.TotalExpenses), "", 0, "L", false, 0, "")
+	pdf.CellFormat(95, 6, fmt.Sprintf("Total gastos documentados: %s", data.TotalExpenses.Format(data.Currency)), "", 0, "L", false, 0, "")
 	pdf.CellFormat(95, 6, fmt.Sprintf("Comprobantes: %d", data.TotalReceipts), "", 1, "L", false, 0, "")
 
 	if data.TotalReceipts > 0 {
@@ -99,6 +115,17 @@ func (g *pdfGenerator) Generate(data AuditReportData) ([]byte, string, error) {
 	}
 	pdf.Ln(5)
 
+	// Campaign Edits Section (only changes made after the contract was accepted)
+	if len(data.CampaignEdits) > 0 {
+		g.addSectionTitle(pdf, "MODIFICACIONES POSTERIORES AL CONTRATO")
+
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(190, 6, fmt.Sprintf("Total modificaciones: %d", len(data.CampaignEdits)), "", 1, "L", false, 0, "")
+		pdf.Ln(2)
+		g.addEditsTable(pdf, data.CampaignEdits)
+		pdf.Ln(5)
+	}
+
 	// Transparency Score Section
 	g.addSectionTitle(pdf, "PUNTUACION DE TRANSPARENCIA")
 
@@ -112,18 +139,22 @@ func (g *pdfGenerator) Generate(data AuditReportData) ([]byte, string, error) {
 	pdf.CellFormat(35, 5, "Puntos", "1", 0, "C", false, 0, "")
 	pdf.CellFormat(35, 5, "Maximo", "1", 1, "C", false, 0, "")
 
-	g.addScoreRow(pdf, "Documentacion de gastos", data.TransparencyBreakdown.DocumentationScore, 30)
-	g.addScoreRow(pdf, "Registro de actividades", data.TransparencyBreakdown.ActivityScore, 25)
-	g.addScoreRow(pdf, "Progreso hacia la meta", data.TransparencyBreakdown.GoalProgressScore, 20)
-	g.addScoreRow(pdf, "Frecuencia de actualizaciones", data.TransparencyBreakdown.TimelinessScore, 15)
+	rules := data.ScoringRules
+	g.addScoreRow(pdf, "Documentacion de gastos", data.TransparencyBreakdown.DocumentationScore, rules.DocumentationMax)
+	g.addScoreRow(pdf, "Registro de actividades", data.TransparencyBreakdown.ActivityScore, rules.ActivityMax)
+	g.addScoreRow(pdf, "Progreso hacia la meta", data.TransparencyBreakdown.GoalProgressScore, rules.GoalProgressMax)
+	g.addScoreRow(pdf, "Frecuencia de actualizaciones", data.TransparencyBreakdown.TimelinessScore, rules.TimelinessMax())
 	g.addScoreRow(pdf, "Deduccion por alertas", data.TransparencyBreakdown.AlertsDeductionScore, 0)
-	g.addScoreRow(pdf, "Bonificaciones", data.TransparencyBreakdown.BonusScore, 10)
+	g.addScoreRow(pdf, "Bonificaciones", data.TransparencyBreakdown.BonusScore, rules.BonusMax())
 
 	pdf.SetFont("Arial", "B", 9)
 	pdf.CellFormat(120, 5, "TOTAL", "1", 0, "L", false, 0, "")
 	pdf.CellFormat(35, 5, fmt.Sprintf("%.1f", data.TransparencyScore), "1", 0, "C", false, 0, "")
 	pdf.CellFormat(35, 5, "100", "1", 1, "C", false, 0, "")
 
+	pdf.SetFont("Arial", "I", 8)
+	pdf.CellFormat(190, 5, fmt.Sprintf("Reglas de puntuacion: version %d", data.RulesVersion), "", 1, "L", false, 0, "")
+
 	pdf.Ln(10)
 
 	// Footer
@@ -231,7 +262,7 @@ func (g *pdfGenerator) addReceiptsTable(pdf *gofpdf.Fpdf, receipts []ReceiptSumm
 
 		pdf.CellFormat(60, 5, provider, "1", 0, "L", false, 0, "")
 		pdf.CellFormat(60, 5, name, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(35, 5, fmt.Sprintf("$%.2f", receipt.Total), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(35, 5, "$"+receipt.Total.String(), "1", 0, "R", false, 0, "")
 		pdf.CellFormat(35, 5, documented, "1", 1, "C", false, 0, "")
 	}
 }
@@ -259,6 +290,38 @@ func (g *pdfGenerator) addActivitiesTable(pdf *gofpdf.Fpdf, activities []Activit
 	}
 }
 
+func (g *pdfGenerator) addEditsTable(pdf *gofpdf.Fpdf, edits []CampaignEditSummary) {
+	pdf.SetFont("Arial", "B", 8)
+	pdf.CellFormat(30, 5, "Fecha", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 5, "Campo", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(60, 5, "Valor anterior", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(60, 5, "Valor nuevo", "1", 1, "L", false, 0, "")
+
+	pdf.SetFont("Arial", "", 8)
+	maxEdits := 15
+	for i, edit := range edits {
+		if i >= maxEdits {
+			pdf.CellFormat(190, 5, fmt.Sprintf("... y %d modificaciones mas", len(edits)-maxEdits), "", 1, "L", false, 0, "")
+			break
+		}
+
+		oldValue := edit.OldValue
+		if len(oldValue) > 30 {
+			oldValue = oldValue[:27] + "..."
+		}
+
+		newValue := edit.NewValue
+		if len(newValue) > 30 {
+			newValue = newValue[:27] + "..."
+		}
+
+		pdf.CellFormat(30, 5, edit.CreatedAt.Format("02/01/2006"), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 5, edit.Field, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(60, 5, oldValue, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(60, 5, newValue, "1", 1, "L", false, 0, "")
+	}
+}
+
 func (g *pdfGenerator) drawTransparencyBadge(pdf *gofpdf.Fpdf, score float64) {
 	x, y := pdf.GetXY()
 
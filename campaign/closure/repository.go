@@ -2,6 +2,9 @@ package closure
 
 import (
 	"context"
+	"time"
+
+	"dona_tutti_api/money"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -23,18 +26,34 @@ type Repository interface {
 	// Data for PDF
 	GetReceiptSummaries(ctx context.Context, campaignID uuid.UUID) ([]ReceiptSummary, error)
 	GetActivitySummaries(ctx context.Context, campaignID uuid.UUID) ([]ActivitySummary, error)
+	GetPostContractEdits(ctx context.Context, campaignID uuid.UUID) ([]CampaignEditSummary, error)
+
+	// Scoring rules
+	GetActiveScoringRules(ctx context.Context) (ScoringRulesVersion, error)
+	GetScoringRules(ctx context.Context, version int) (ScoringRulesVersion, error)
+	ListScoringRules(ctx context.Context) ([]ScoringRulesVersion, error)
+	CreateScoringRules(ctx context.Context, rules ScoringRulesVersion) (ScoringRulesVersion, error)
+	ActivateScoringRules(ctx context.Context, version int) error
+	CreateRescore(ctx context.Context, rescore TransparencyRescore) error
+	ListRescores(ctx context.Context, campaignID uuid.UUID) ([]TransparencyRescore, error)
+
+	// Automatic closure
+	FindClosureCandidates(ctx context.Context, now time.Time) ([]ClosureCandidate, error)
 }
 
 // DonationMetrics holds donation statistics
 type DonationMetrics struct {
-	TotalRaised    float64
-	TotalDonors    int
-	TotalDonations int
+	TotalRaised      money.Amount // In the campaign currency
+	OrganicRaised    money.Amount // Given by donors
+	MatchedRaised    money.Amount // Given by sponsors matching donations
+	TotalDonors      int
+	TotalDonations   int
+	RaisedByCurrency []CurrencyTotal
 }
 
 // ReceiptsMetrics holds receipts statistics
 type ReceiptsMetrics struct {
-	TotalExpenses         float64
+	TotalExpenses         money.Amount
 	TotalReceipts         int
 	ReceiptsWithDocuments int
 }
@@ -43,9 +62,10 @@ type ReceiptsMetrics struct {
 type ActivitiesMetrics struct {
 	TotalActivities              int
 	AverageDaysBetweenActivities float64
+	LastActivityDate             *time.Time
 }
 
-// AlertsMetrics holds alerts statistics (placeholder)
+// AlertsMetrics holds alerts statistics
 type AlertsMetrics struct {
 	AlertsCount    int
 	AlertsResolved int
@@ -94,14 +114,19 @@ func (r *repository) ExistsClosureReport(ctx context.Context, campaignID uuid.UU
 
 func (r *repository) GetDonationMetrics(ctx context.Context, campaignID uuid.UUID) (DonationMetrics, error) {
 	var result struct {
-		TotalRaised    float64
+		TotalRaised    money.Amount
+		OrganicRaised  money.Amount
+		MatchedRaised  money.Amount
 		TotalDonors    int64
 		TotalDonations int64
 	}
 
+	// Anonymous donations without donor have a NULL donor_id and are not counted as donors
 	err := r.db.WithContext(ctx).Raw(`
 		SELECT
-			COALESCE(SUM(amount), 0) as total_raised,
+			COALESCE(SUM(amount - refunded_amount), 0) as total_raised,
+			COALESCE(SUM(amount - refunded_amount) FILTER (WHERE matching_pledge_id IS NULL), 0) as organic_raised,
+			COALESCE(SUM(amount - refunded_amount) FILTER (WHERE matching_pledge_id IS NOT NULL), 0) as matched_raised,
 			COUNT(DISTINCT donor_id) as total_donors,
 			COUNT(*) as total_donations
 		FROM donations
@@ -112,16 +137,53 @@ func (r *repository) GetDonationMetrics(ctx context.Context, campaignID uuid.UUI
 		return DonationMetrics{}, err
 	}
 
+	// Donations are stored converted to the campaign currency; the breakdown shows what donors gave
+	// in each currency. Partial refunds reduce the original amount in the same proportion.
+	var byCurrency []struct {
+		Currency       string
+		OriginalAmount money.Amount // Rounded to the cent on scan
+		Amount         money.Amount
+		Donations      int64
+	}
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT
+			original_currency as currency,
+			COALESCE(SUM(original_amount * (amount - refunded_amount) / amount), 0) as original_amount,
+			COALESCE(SUM(amount - refunded_amount), 0) as amount,
+			COUNT(*) as donations
+		FROM donations
+		WHERE campaign_id = ? AND status = 'completed'
+		GROUP BY original_currency
+		ORDER BY original_currency
+	`, campaignID).Scan(&byCurrency).Error
+
+	if err != nil {
+		return DonationMetrics{}, err
+	}
+
+	raisedByCurrency := make([]CurrencyTotal, len(byCurrency))
+	for i, total := range byCurrency {
+		raisedByCurrency[i] = CurrencyTotal{
+			Currency:       total.Currency,
+			OriginalAmount: total.OriginalAmount,
+			Amount:         total.Amount,
+			Donations:      int(total.Donations),
+		}
+	}
+
 	return DonationMetrics{
-		TotalRaised:    result.TotalRaised,
-		TotalDonors:    int(result.TotalDonors),
-		TotalDonations: int(result.TotalDonations),
+		TotalRaised:      result.TotalRaised,
+		OrganicRaised:    result.OrganicRaised,
+		MatchedRaised:    result.MatchedRaised,
+		TotalDonors:      int(result.TotalDonors),
+		TotalDonations:   int(result.TotalDonations),
+		RaisedByCurrency: raisedByCurrency,
 	}, nil
 }
 
 func (r *repository) GetReceiptsMetrics(ctx context.Context, campaignID uuid.UUID) (ReceiptsMetrics, error) {
 	var result struct {
-		TotalExpenses         float64
+		TotalExpenses         money.Amount
 		TotalReceipts         int64
 		ReceiptsWithDocuments int64
 	}
@@ -149,15 +211,16 @@ func (r *repository) GetReceiptsMetrics(ctx context.Context, campaignID uuid.UUI
 func (r *repository) GetActivitiesMetrics(ctx context.Context, campaignID uuid.UUID) (ActivitiesMetrics, error) {
 	var result struct {
 		TotalActivities int64
+		LastActivity    *time.Time
 		AvgDays         float64
 	}
 
-	// Get total activities count
+	// Get total activities count and most recent activity date
 	err := r.db.WithContext(ctx).Raw(`
-		SELECT COUNT(*) as total_activities
+		SELECT COUNT(*) as total_activities, MAX(date) as last_activity
 		FROM activities
 		WHERE campaign_id = ?
-	`, campaignID).Scan(&result.TotalActivities).Error
+	`, campaignID).Scan(&result).Error
 
 	if err != nil {
 		return ActivitiesMetrics{}, err
@@ -187,9 +250,11 @@ func (r *repository) GetActivitiesMetrics(ctx context.Context, campaignID uuid.U
 	return ActivitiesMetrics{
 		TotalActivities:              int(result.TotalActivities),
 		AverageDaysBetweenActivities: result.AvgDays,
+		LastActivityDate:             result.LastActivity,
 	}, nil
 }
 
+// GetAlertsMetrics counts the campaign alerts. Dismissed alerts were unfounded and are not counted.
 func (r *repository) GetAlertsMetrics(ctx context.Context, campaignID uuid.UUID) (AlertsMetrics, error) {
 	var result struct {
 		AlertsCount    int64
@@ -198,15 +263,14 @@ func (r *repository) GetAlertsMetrics(ctx context.Context, campaignID uuid.UUID)
 
 	err := r.db.WithContext(ctx).Raw(`
 		SELECT
-			COUNT(*) as alerts_count,
+			COUNT(CASE WHEN status <> 'dismissed' THEN 1 END) as alerts_count,
 			COUNT(CASE WHEN status = 'resolved' THEN 1 END) as alerts_resolved
 		FROM campaign_alerts
 		WHERE campaign_id = ?
 	`, campaignID).Scan(&result).Error
 
 	if err != nil {
-		// Return zeros if alerts table doesn't exist or other error
-		return AlertsMetrics{}, nil
+		return AlertsMetrics{}, err
 	}
 
 	return AlertsMetrics{
@@ -219,7 +283,7 @@ func (r *repository) GetReceiptSummaries(ctx context.Context, campaignID uuid.UU
 	var results []struct {
 		Provider    string
 		Name        string
-		Total       float64
+		Total       money.Amount
 		Date        string
 		DocumentURL *string
 	}
@@ -276,3 +340,138 @@ func (r *repository) GetActivitySummaries(ctx context.Context, campaignID uuid.U
 
 	return summaries, nil
 }
+
+// GetPostContractEdits returns the campaign edits recorded after the contract was accepted
+func (r *repository) GetPostContractEdits(ctx context.Context, campaignID uuid.UUID) ([]CampaignEditSummary, error) {
+	var results []CampaignEditSummary
+
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT e.field, e.old_value, e.new_value, e.created_at
+		FROM campaign_edits e
+		JOIN campaign_contracts cc ON cc.campaign_id = e.campaign_id
+		WHERE e.campaign_id = ? AND e.created_at > cc.accepted_at
+		ORDER BY e.created_at
+	`, campaignID).Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// FindClosureCandidates returns active or paused campaigns without a closure report that
+// have reached their goal with completed donations or are past their end date
+func (r *repository) FindClosureCandidates(ctx context.Context, now time.Time) ([]ClosureCandidate, error) {
+	var results []ClosureCandidate
+
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			c.id AS campaign_id,
+			c.title AS campaign_title,
+			c.status AS campaign_status,
+			c.goal AS campaign_goal,
+			c.end_date,
+			COALESCE(d.total_raised, 0) AS total_raised,
+			CASE
+				WHEN COALESCE(d.total_raised, 0) >= c.goal THEN 'goal_reached'
+				ELSE 'end_date'
+			END AS closure_type
+		FROM campaigns c
+		LEFT JOIN (
+			SELECT campaign_id, SUM(amount - refunded_amount) AS total_raised
+			FROM donations
+			WHERE status = 'completed'
+			GROUP BY campaign_id
+		) d ON d.campaign_id = c.id
+		WHERE c.status IN ('active', 'paused')
+			AND c.archived_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM campaign_closure_reports r WHERE r.campaign_id = c.id)
+			AND (c.end_date < ? OR COALESCE(d.total_raised, 0) >= c.goal)
+		ORDER BY c.end_date
+	`, now).Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *repository) GetActiveScoringRules(ctx context.Context) (ScoringRulesVersion, error) {
+	var model ScoringRulesModel
+	if err := r.db.WithContext(ctx).Where("is_active = ?", true).First(&model).Error; err != nil {
+		return ScoringRulesVersion{}, err
+	}
+	return model.ToEntity(), nil
+}
+
+func (r *repository) GetScoringRules(ctx context.Context, version int) (ScoringRulesVersion, error) {
+	var model ScoringRulesModel
+	if err := r.db.WithContext(ctx).Where("version = ?", version).First(&model).Error; err != nil {
+		return ScoringRulesVersion{}, err
+	}
+	return model.ToEntity(), nil
+}
+
+func (r *repository) ListScoringRules(ctx context.Context) ([]ScoringRulesVersion, error) {
+	var models []ScoringRulesModel
+	if err := r.db.WithContext(ctx).Order("version DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	versions := make([]ScoringRulesVersion, len(models))
+	for i, model := range models {
+		versions[i] = model.ToEntity()
+	}
+	return versions, nil
+}
+
+// CreateScoringRules stores a new rules version; the version number is assigned by the database
+func (r *repository) CreateScoringRules(ctx context.Context, rules ScoringRulesVersion) (ScoringRulesVersion, error) {
+	var model ScoringRulesModel
+	model.FromEntity(rules)
+	model.Version = 0
+	model.IsActive = false
+
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return ScoringRulesVersion{}, err
+	}
+	return model.ToEntity(), nil
+}
+
+// ActivateScoringRules makes the given version the only active one
+func (r *repository) ActivateScoringRules(ctx context.Context, version int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&ScoringRulesModel{}).Where("is_active = ?", true).Update("is_active", false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&ScoringRulesModel{}).Where("version = ?", version).Update("is_active", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *repository) CreateRescore(ctx context.Context, rescore TransparencyRescore) error {
+	var model TransparencyRescoreModel
+	model.FromEntity(rescore)
+	return r.db.WithContext(ctx).Create(&model).Error
+}
+
+func (r *repository) ListRescores(ctx context.Context, campaignID uuid.UUID) ([]TransparencyRescore, error) {
+	var models []TransparencyRescoreModel
+	if err := r.db.WithContext(ctx).Where("campaign_id = ?", campaignID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	rescores := make([]TransparencyRescore, len(models))
+	for i, model := range models {
+		rescores[i] = model.ToEntity()
+	}
+	return rescores, nil
+}
@@ -0,0 +1,39 @@
+package closure
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"dona_tutti_api/scheduler"
+
+	"gorm.io/gorm"
+)
+
+// AutoClosureLockKey is the Postgres advisory lock key held while the automatic closure runs,
+// so that only one API replica closes campaigns at a time
+const AutoClosureLockKey int64 = 4815162342
+
+// DefaultAutoClosureInterval is how often the scheduler looks for campaigns to close
+const DefaultAutoClosureInterval = time.Hour
+
+// NewScheduler creates the scheduler that periodically closes campaigns that reached their goal or end date
+func NewScheduler(service Service, db *gorm.DB, interval time.Duration) *scheduler.Scheduler {
+	if interval <= 0 {
+		interval = DefaultAutoClosureInterval
+	}
+	return scheduler.New("Auto-closure", db, AutoClosureLockKey, interval, func(ctx context.Context) error {
+		result, err := service.RunAutoClosure(ctx, false)
+		if err != nil {
+			return err
+		}
+
+		if len(result.Candidates) > 0 {
+			log.Printf("✅ Auto-closure closed %d of %d campaigns", len(result.Closed), len(result.Candidates))
+		}
+		for campaignID, reason := range result.Failed {
+			log.Printf("⚠️  Auto-closure could not close campaign %s: %s", campaignID, reason)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,165 @@
+package closure
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimelinessTier awards points when activities are posted on average every MaxDays or less
+type TimelinessTier struct {
+	MaxDays float64 `json:"max_days"`
+	Points  float64 `json:"points"`
+}
+
+// ScoringRules holds the weights and thresholds used to calculate the transparency score
+type ScoringRules struct {
+	// Documentation: share of receipts with an attached document
+	DocumentationMax float64 `json:"documentation_max"`
+
+	// Activities: one activity expected per ActivityPeriodDays of campaign
+	ActivityMax              float64 `json:"activity_max"`
+	ActivityExtraRatioWeight float64 `json:"activity_extra_ratio_weight"`
+	ActivityRatioCap         float64 `json:"activity_ratio_cap"`
+	ActivityPeriodDays       float64 `json:"activity_period_days"`
+
+	// Goal progress: proportional to the share of the goal raised
+	GoalProgressMax float64 `json:"goal_progress_max"`
+
+	// Timeliness: average days between activities, tiers checked in ascending order
+	TimelinessTiers   []TimelinessTier `json:"timeliness_tiers"`
+	TimelinessDefault float64          `json:"timeliness_default"`
+
+	// Alerts: deduction per unresolved alert
+	AlertPenalty       float64 `json:"alert_penalty"`
+	AlertsDeductionMax float64 `json:"alerts_deduction_max"`
+
+	// Bonus
+	ContractBonus         float64 `json:"contract_bonus"`
+	DonorsBonusThreshold  int     `json:"donors_bonus_threshold"`
+	DonorsBonus           float64 `json:"donors_bonus"`
+	ExpenseRatioThreshold float64 `json:"expense_ratio_threshold"`
+	ExpenseBonus          float64 `json:"expense_bonus"`
+	EarlyClosureBonus     float64 `json:"early_closure_bonus"`
+}
+
+// ScoringRulesVersion is a stored, immutable version of the scoring rules
+type ScoringRulesVersion struct {
+	Version   int          `json:"version"`
+	Rules     ScoringRules `json:"rules"`
+	Notes     *string      `json:"notes,omitempty"`
+	IsActive  bool         `json:"is_active"`
+	CreatedBy *uuid.UUID   `json:"created_by,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// CreateScoringRulesRequest represents a request to publish a new rules version
+type CreateScoringRulesRequest struct {
+	Rules    ScoringRules `json:"rules"`
+	Notes    *string      `json:"notes,omitempty"`
+	Activate bool         `json:"activate"`
+}
+
+// RescoreRequest represents a request to re-score a closed campaign
+type RescoreRequest struct {
+	RulesVersion *int `json:"rules_version,omitempty"` // Defaults to the active version
+}
+
+// TransparencyRescore is the score of a closed campaign under another rules version
+type TransparencyRescore struct {
+	ID                    uuid.UUID             `json:"id"`
+	ClosureReportID       uuid.UUID             `json:"closure_report_id"`
+	CampaignID            uuid.UUID             `json:"campaign_id"`
+	RulesVersion          int                   `json:"rules_version"`
+	TransparencyScore     float64               `json:"transparency_score"`
+	TransparencyBreakdown TransparencyBreakdown `json:"transparency_breakdown"`
+	RescoredBy            *uuid.UUID            `json:"rescored_by,omitempty"`
+	CreatedAt             time.Time             `json:"created_at"`
+}
+
+// DefaultScoringRules returns the original scoring rules, used when no version is stored
+func DefaultScoringRules() ScoringRules {
+	return ScoringRules{
+		DocumentationMax:         30,
+		ActivityMax:              25,
+		ActivityExtraRatioWeight: 0.2,
+		ActivityRatioCap:         1.5,
+		ActivityPeriodDays:       30,
+		GoalProgressMax:          20,
+		TimelinessTiers: []TimelinessTier{
+			{MaxDays: 7, Points: 15},
+			{MaxDays: 14, Points: 12},
+			{MaxDays: 30, Points: 8},
+		},
+		TimelinessDefault:     5,
+		AlertPenalty:          2,
+		AlertsDeductionMax:    10,
+		ContractBonus:         3,
+		DonorsBonusThreshold:  10,
+		DonorsBonus:           2,
+		ExpenseRatioThreshold: 0.8,
+		ExpenseBonus:          3,
+		EarlyClosureBonus:     2,
+	}
+}
+
+// TimelinessMax returns the highest timeliness score reachable
+func (r ScoringRules) TimelinessMax() float64 {
+	max := r.TimelinessDefault
+	for _, tier := range r.TimelinessTiers {
+		if tier.Points > max {
+			max = tier.Points
+		}
+	}
+	return max
+}
+
+// BonusMax returns the highest bonus reachable
+func (r ScoringRules) BonusMax() float64 {
+	return r.ContractBonus + r.DonorsBonus + r.ExpenseBonus + r.EarlyClosureBonus
+}
+
+// Validate checks that the rules can be used to score campaigns
+func (r ScoringRules) Validate() error {
+	nonNegative := map[string]float64{
+		"documentation_max":           r.DocumentationMax,
+		"activity_max":                r.ActivityMax,
+		"activity_extra_ratio_weight": r.ActivityExtraRatioWeight,
+		"goal_progress_max":           r.GoalProgressMax,
+		"timeliness_default":          r.TimelinessDefault,
+		"alert_penalty":               r.AlertPenalty,
+		"alerts_deduction_max":        r.AlertsDeductionMax,
+		"contract_bonus":              r.ContractBonus,
+		"donors_bonus":                r.DonorsBonus,
+		"expense_ratio_threshold":     r.ExpenseRatioThreshold,
+		"expense_bonus":               r.ExpenseBonus,
+		"early_closure_bonus":         r.EarlyClosureBonus,
+	}
+	for field, value := range nonNegative {
+		if value < 0 {
+			return fmt.Errorf("%s cannot be negative", field)
+		}
+	}
+
+	if r.ActivityRatioCap < 1 {
+		return fmt.Errorf("activity_ratio_cap must be at least 1")
+	}
+	if r.ActivityPeriodDays <= 0 {
+		return fmt.Errorf("activity_period_days must be greater than 0")
+	}
+	if r.DonorsBonusThreshold < 0 {
+		return fmt.Errorf("donors_bonus_threshold cannot be negative")
+	}
+
+	for i, tier := range r.TimelinessTiers {
+		if tier.MaxDays <= 0 || tier.Points < 0 {
+			return fmt.Errorf("timeliness_tiers[%d] must have positive max_days and non-negative points", i)
+		}
+		if i > 0 && tier.MaxDays <= r.TimelinessTiers[i-1].MaxDays {
+			return fmt.Errorf("timeliness_tiers must be sorted by ascending max_days")
+		}
+	}
+
+	return nil
+}
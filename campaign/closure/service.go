@@ -7,6 +7,7 @@ import (
 	"math"
 	"time"
 
+	"dona_tutti_api/money"
 	"dona_tutti_api/s3client"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -20,13 +21,25 @@ type Service interface {
 	GetClosureReport(ctx context.Context, campaignID uuid.UUID) (*CampaignClosureReport, error)
 	GetPublicAuditReport(ctx context.Context, campaignID uuid.UUID) (*PublicAuditReport, error)
 	HasClosureReport(ctx context.Context, campaignID uuid.UUID) (bool, error)
+	FindClosureCandidates(ctx context.Context) ([]ClosureCandidate, error)
+	RunAutoClosure(ctx context.Context, dryRun bool) (*AutoClosureResult, error)
+	GetTransparencyPreview(ctx context.Context, campaignID uuid.UUID) (*TransparencyPreview, error)
+
+	// Scoring rules
+	ListScoringRules(ctx context.Context) ([]ScoringRulesVersion, error)
+	GetActiveScoringRules(ctx context.Context) (ScoringRulesVersion, error)
+	CreateScoringRules(ctx context.Context, req CreateScoringRulesRequest, createdBy *uuid.UUID) (ScoringRulesVersion, error)
+	ActivateScoringRules(ctx context.Context, version int) error
+	RescoreCampaign(ctx context.Context, campaignID uuid.UUID, rulesVersion *int, rescoredBy *uuid.UUID) (*TransparencyRescore, error)
+	ListRescores(ctx context.Context, campaignID uuid.UUID) ([]TransparencyRescore, error)
 }
 
 // CampaignInfo represents minimal campaign information needed for closure
 type CampaignInfo struct {
 	ID          uuid.UUID
 	Title       string
-	Goal        float64
+	Goal        money.Amount
+	Currency    string
 	OrganizerID uuid.UUID
 	Status      string
 	StartDate   time.Time
@@ -116,60 +129,26 @@ func (s *service) CloseCampaign(ctx context.Context, campaignID uuid.UUID, closu
 	}
 
 	// 6. Gather all metrics
-	donationMetrics, err := s.repo.GetDonationMetrics(ctx, campaignID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get donation metrics: %w", err)
-	}
-
-	receiptsMetrics, err := s.repo.GetReceiptsMetrics(ctx, campaignID)
+	closureMetrics, err := s.gatherClosureMetrics(ctx, campaignInfo, organizerName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get receipts metrics: %w", err)
+		return nil, err
 	}
 
-	activitiesMetrics, err := s.repo.GetActivitiesMetrics(ctx, campaignID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get activities metrics: %w", err)
-	}
-
-	alertsMetrics, _ := s.repo.GetAlertsMetrics(ctx, campaignID) // Ignore error for placeholder
-
-	// 7. Check if campaign has contract
-	hasContract, _ := s.contractService.HasContract(ctx, campaignID)
-
-	// 8. Calculate transparency score
-	closureMetrics := ClosureMetrics{
-		CampaignGoal:                 campaignInfo.Goal,
-		CampaignStart:                campaignInfo.StartDate,
-		CampaignEnd:                  campaignInfo.EndDate,
-		HasContract:                  hasContract,
-		CampaignTitle:                campaignInfo.Title,
-		OrganizerName:                organizerName,
-		OrganizerID:                  campaignInfo.OrganizerID,
-		TotalRaised:                  donationMetrics.TotalRaised,
-		TotalDonors:                  donationMetrics.TotalDonors,
-		TotalDonations:               donationMetrics.TotalDonations,
-		TotalExpenses:                receiptsMetrics.TotalExpenses,
-		TotalReceipts:                receiptsMetrics.TotalReceipts,
-		ReceiptsWithDocuments:        receiptsMetrics.ReceiptsWithDocuments,
-		TotalActivities:              activitiesMetrics.TotalActivities,
-		AverageDaysBetweenActivities: activitiesMetrics.AverageDaysBetweenActivities,
-		AlertsCount:                  alertsMetrics.AlertsCount,
-		AlertsResolved:               alertsMetrics.AlertsResolved,
-	}
-
-	breakdown := s.calculateTransparencyScore(closureMetrics, closureType == ClosureTypeManual)
+	// 7. Calculate transparency score
+	rules := s.activeScoringRules(ctx)
+	breakdown := s.calculateTransparencyScore(closureMetrics, closureType == ClosureTypeManual, rules.Rules)
 	transparencyScore := breakdown.Total()
 
-	// 9. Calculate goal percentage
+	// 8. Calculate goal percentage
 	goalPercentage := 0.0
 	if campaignInfo.Goal > 0 {
-		goalPercentage = (donationMetrics.TotalRaised / campaignInfo.Goal) * 100
+		goalPercentage = closureMetrics.TotalRaised.Ratio(campaignInfo.Goal) * 100
 		if goalPercentage > 100 {
 			goalPercentage = 100
 		}
 	}
 
-	// 10. Create closure report
+	// 9. Create closure report
 	closedAt := time.Now()
 	report := CampaignClosureReport{
 		ID:                    uuid.New(),
@@ -177,44 +156,112 @@ func (s *service) CloseCampaign(ctx context.Context, campaignID uuid.UUID, closu
 		ClosureType:           closureType,
 		ClosureReason:         reason,
 		ClosedBy:              closedBy,
-		TotalRaised:           donationMetrics.TotalRaised,
-		TotalDonors:           donationMetrics.TotalDonors,
-		TotalDonations:        donationMetrics.TotalDonations,
+		Currency:              campaignInfo.Currency,
+		TotalRaised:           closureMetrics.TotalRaised,
+		OrganicRaised:         closureMetrics.OrganicRaised,
+		MatchedRaised:         closureMetrics.MatchedRaised,
+		TotalDonors:           closureMetrics.TotalDonors,
+		TotalDonations:        closureMetrics.TotalDonations,
 		CampaignGoal:          campaignInfo.Goal,
 		GoalPercentage:        goalPercentage,
-		TotalExpenses:         receiptsMetrics.TotalExpenses,
-		TotalReceipts:         receiptsMetrics.TotalReceipts,
-		ReceiptsWithDocuments: receiptsMetrics.ReceiptsWithDocuments,
-		TotalActivities:       activitiesMetrics.TotalActivities,
+		TotalExpenses:         closureMetrics.TotalExpenses,
+		TotalReceipts:         closureMetrics.TotalReceipts,
+		ReceiptsWithDocuments: closureMetrics.ReceiptsWithDocuments,
+		TotalActivities:       closureMetrics.TotalActivities,
+		ActivityIntervalDays:  closureMetrics.AverageDaysBetweenActivities,
+		CampaignStart:         closureMetrics.CampaignStart,
+		CampaignEnd:           closureMetrics.CampaignEnd,
+		HasContract:           closureMetrics.HasContract,
 		TransparencyScore:     transparencyScore,
 		TransparencyBreakdown: breakdown,
-		AlertsCount:           alertsMetrics.AlertsCount,
-		AlertsResolved:        alertsMetrics.AlertsResolved,
+		RulesVersion:          rules.Version,
+		AlertsCount:           closureMetrics.AlertsCount,
+		AlertsResolved:        closureMetrics.AlertsResolved,
 		ClosedAt:              closedAt,
 		CreatedAt:             closedAt,
 	}
 
-	// 11. Save closure report
+	// 10. Save closure report
 	if err := s.repo.CreateClosureReport(ctx, report); err != nil {
 		return nil, fmt.Errorf("failed to create closure report: %w", err)
 	}
 
-	// 12. Update campaign status to completed
+	// 11. Update campaign status to completed
 	if err := s.campaignService.UpdateStatus(ctx, campaignID, "completed"); err != nil {
 		return nil, fmt.Errorf("failed to update campaign status: %w", err)
 	}
 
-	// 13. Generate PDF asynchronously
+	// 12. Generate PDF asynchronously
 	go s.generateAndUploadPDF(context.Background(), campaignID, campaignInfo, organizerName, report, closureMetrics)
 
 	return &report, nil
 }
 
+// gatherClosureMetrics collects the donation, receipt, activity and alert metrics used to score a campaign
+func (s *service) gatherClosureMetrics(ctx context.Context, campaignInfo CampaignInfo, organizerName string) (ClosureMetrics, error) {
+	campaignID := campaignInfo.ID
+
+	donationMetrics, err := s.repo.GetDonationMetrics(ctx, campaignID)
+	if err != nil {
+		return ClosureMetrics{}, fmt.Errorf("failed to get donation metrics: %w", err)
+	}
+
+	receiptsMetrics, err := s.repo.GetReceiptsMetrics(ctx, campaignID)
+	if err != nil {
+		return ClosureMetrics{}, fmt.Errorf("failed to get receipts metrics: %w", err)
+	}
+
+	activitiesMetrics, err := s.repo.GetActivitiesMetrics(ctx, campaignID)
+	if err != nil {
+		return ClosureMetrics{}, fmt.Errorf("failed to get activities metrics: %w", err)
+	}
+
+	alertsMetrics, err := s.repo.GetAlertsMetrics(ctx, campaignID)
+	if err != nil {
+		return ClosureMetrics{}, fmt.Errorf("failed to get alerts metrics: %w", err)
+	}
+
+	// Check if campaign has contract
+	hasContract, _ := s.contractService.HasContract(ctx, campaignID)
+
+	return ClosureMetrics{
+		CampaignGoal:                 campaignInfo.Goal,
+		CampaignStart:                campaignInfo.StartDate,
+		CampaignEnd:                  campaignInfo.EndDate,
+		HasContract:                  hasContract,
+		CampaignTitle:                campaignInfo.Title,
+		OrganizerName:                organizerName,
+		OrganizerID:                  campaignInfo.OrganizerID,
+		Currency:                     campaignInfo.Currency,
+		TotalRaised:                  donationMetrics.TotalRaised,
+		OrganicRaised:                donationMetrics.OrganicRaised,
+		MatchedRaised:                donationMetrics.MatchedRaised,
+		RaisedByCurrency:             donationMetrics.RaisedByCurrency,
+		TotalDonors:                  donationMetrics.TotalDonors,
+		TotalDonations:               donationMetrics.TotalDonations,
+		TotalExpenses:                receiptsMetrics.TotalExpenses,
+		TotalReceipts:                receiptsMetrics.TotalReceipts,
+		ReceiptsWithDocuments:        receiptsMetrics.ReceiptsWithDocuments,
+		TotalActivities:              activitiesMetrics.TotalActivities,
+		AverageDaysBetweenActivities: activitiesMetrics.AverageDaysBetweenActivities,
+		LastActivityDate:             activitiesMetrics.LastActivityDate,
+		AlertsCount:                  alertsMetrics.AlertsCount,
+		AlertsResolved:               alertsMetrics.AlertsResolved,
+	}, nil
+}
+
 // generateAndUploadPDF generates the audit PDF and uploads it to S3
 func (s *service) generateAndUploadPDF(ctx context.Context, campaignID uuid.UUID, campaignInfo CampaignInfo, organizerName string, report CampaignClosureReport, metrics ClosureMetrics) {
 	// Get receipt and activity summaries for PDF
 	receiptSummaries, _ := s.repo.GetReceiptSummaries(ctx, campaignID)
 	activitySummaries, _ := s.repo.GetActivitySummaries(ctx, campaignID)
+	campaignEdits, _ := s.repo.GetPostContractEdits(ctx, campaignID)
+
+	// Scoring rules the report was calculated with
+	rules, err := s.repo.GetScoringRules(ctx, report.RulesVersion)
+	if err != nil {
+		rules = ScoringRulesVersion{Version: report.RulesVersion, Rules: DefaultScoringRules()}
+	}
 
 	// Build audit report data
 	data := AuditReportData{
@@ -227,7 +274,11 @@ func (s *service) generateAndUploadPDF(ctx context.Context, campaignID uuid.UUID
 		ClosedAt:              report.ClosedAt,
 		ClosureType:           report.ClosureType,
 		ClosureReason:         report.ClosureReason,
+		Currency:              report.Currency,
+		RaisedByCurrency:      metrics.RaisedByCurrency,
 		TotalRaised:           report.TotalRaised,
+		OrganicRaised:         report.OrganicRaised,
+		MatchedRaised:         report.MatchedRaised,
 		GoalPercentage:        report.GoalPercentage,
 		TotalDonors:           report.TotalDonors,
 		TotalDonations:        report.TotalDonations,
@@ -239,6 +290,9 @@ func (s *service) generateAndUploadPDF(ctx context.Context, campaignID uuid.UUID
 		Activities:            activitySummaries,
 		TransparencyScore:     report.TransparencyScore,
 		TransparencyBreakdown: report.TransparencyBreakdown,
+		RulesVersion:          rules.Version,
+		ScoringRules:          rules.Rules,
+		CampaignEdits:         campaignEdits,
 	}
 
 	// Generate PDF
@@ -280,92 +334,85 @@ func (s *service) generateAndUploadPDF(ctx context.Context, campaignID uuid.UUID
 	}
 }
 
-// calculateTransparencyScore calculates the transparency score based on metrics
-func (s *service) calculateTransparencyScore(metrics ClosureMetrics, closedBeforeEndDate bool) TransparencyBreakdown {
+// calculateTransparencyScore calculates the transparency score based on metrics and scoring rules
+func (s *service) calculateTransparencyScore(metrics ClosureMetrics, closedBeforeEndDate bool, rules ScoringRules) TransparencyBreakdown {
 	breakdown := TransparencyBreakdown{}
 
-	// 1. DOCUMENTATION (30 pts max)
+	// 1. DOCUMENTATION (30 pts max by default)
 	// Percentage of receipts with attached document
 	// If no receipts exist, score remains 0 (default value)
 	if metrics.TotalReceipts > 0 {
 		docPercentage := float64(metrics.ReceiptsWithDocuments) / float64(metrics.TotalReceipts)
-		breakdown.DocumentationScore = docPercentage * 30.0
+		breakdown.DocumentationScore = docPercentage * rules.DocumentationMax
 	}
 
-	// 2. ACTIVITIES (25 pts max)
-	// Minimum 1 activity per month of active campaign
-	campaignDurationMonths := math.Ceil(metrics.CampaignEnd.Sub(metrics.CampaignStart).Hours() / (24 * 30))
-	if campaignDurationMonths < 1 {
-		campaignDurationMonths = 1
+	// 2. ACTIVITIES (25 pts max by default)
+	// Minimum 1 activity per period (a month by default) of active campaign
+	campaignDurationPeriods := math.Ceil(metrics.CampaignEnd.Sub(metrics.CampaignStart).Hours() / (24 * rules.ActivityPeriodDays))
+	if campaignDurationPeriods < 1 {
+		campaignDurationPeriods = 1
 	}
-	expectedActivities := int(campaignDurationMonths)
+	expectedActivities := int(campaignDurationPeriods)
 	if metrics.TotalActivities > 0 {
 		activityRatio := float64(metrics.TotalActivities) / float64(expectedActivities)
 		if activityRatio > 1 {
-			activityRatio = 1 + (activityRatio-1)*0.2 // Bonus for more activities
+			activityRatio = 1 + (activityRatio-1)*rules.ActivityExtraRatioWeight // Bonus for more activities
 		}
-		if activityRatio > 1.5 {
-			activityRatio = 1.5
+		if activityRatio > rules.ActivityRatioCap {
+			activityRatio = rules.ActivityRatioCap
 		}
-		breakdown.ActivityScore = activityRatio * 25.0 / 1.5
-		if breakdown.ActivityScore > 25 {
-			breakdown.ActivityScore = 25
+		breakdown.ActivityScore = activityRatio * rules.ActivityMax / rules.ActivityRatioCap
+		if breakdown.ActivityScore > rules.ActivityMax {
+			breakdown.ActivityScore = rules.ActivityMax
 		}
 	}
 
-	// 3. GOAL PROGRESS (20 pts max)
-	// Rewards reaching or getting close to goal
+	// 3. GOAL PROGRESS (20 pts max by default)
+	// Proportional to the share of the goal raised
 	if metrics.CampaignGoal > 0 {
-		goalRatio := metrics.TotalRaised / metrics.CampaignGoal
-		if goalRatio >= 1 {
-			breakdown.GoalProgressScore = 20.0
-		} else if goalRatio >= 0.75 {
-			breakdown.GoalProgressScore = 15.0 + (goalRatio-0.75)*20.0
-		} else if goalRatio >= 0.5 {
-			breakdown.GoalProgressScore = 10.0 + (goalRatio-0.5)*20.0
-		} else {
-			breakdown.GoalProgressScore = goalRatio * 20.0
+		goalRatio := metrics.TotalRaised.Ratio(metrics.CampaignGoal)
+		if goalRatio > 1 {
+			goalRatio = 1
 		}
+		breakdown.GoalProgressScore = goalRatio * rules.GoalProgressMax
 	}
 
-	// 4. TIMELINESS (15 pts max)
+	// 4. TIMELINESS (15 pts max by default)
 	// Based on frequency of activities
-	avgDays := metrics.AverageDaysBetweenActivities
-	if avgDays <= 7 {
-		breakdown.TimelinessScore = 15.0
-	} else if avgDays <= 14 {
-		breakdown.TimelinessScore = 12.0
-	} else if avgDays <= 30 {
-		breakdown.TimelinessScore = 8.0
-	} else {
-		breakdown.TimelinessScore = 5.0
+	breakdown.TimelinessScore = rules.TimelinessDefault
+	for _, tier := range rules.TimelinessTiers {
+		if metrics.AverageDaysBetweenActivities <= tier.MaxDays {
+			breakdown.TimelinessScore = tier.Points
+			break
+		}
 	}
 
-	// 5. ALERTS DEDUCTION (0 to -10 pts) - PLACEHOLDER
+	// 5. ALERTS DEDUCTION (0 to -10 pts by default)
+	// Penalty per alert still pending or under investigation
 	if metrics.AlertsCount > 0 {
 		unresolvedAlerts := metrics.AlertsCount - metrics.AlertsResolved
-		breakdown.AlertsDeductionScore = float64(unresolvedAlerts) * -2.0
-		if breakdown.AlertsDeductionScore < -10 {
-			breakdown.AlertsDeductionScore = -10
+		breakdown.AlertsDeductionScore = float64(unresolvedAlerts) * -rules.AlertPenalty
+		if breakdown.AlertsDeductionScore < -rules.AlertsDeductionMax {
+			breakdown.AlertsDeductionScore = -rules.AlertsDeductionMax
 		}
 	}
 
-	// 6. BONUS (10 pts max)
+	// 6. BONUS (10 pts max by default)
 	bonus := 0.0
 	if metrics.HasContract {
-		bonus += 3.0
+		bonus += rules.ContractBonus
 	}
-	if metrics.TotalDonors >= 10 {
-		bonus += 2.0
+	if metrics.TotalDonors >= rules.DonorsBonusThreshold {
+		bonus += rules.DonorsBonus
 	}
 	if metrics.TotalRaised > 0 && metrics.TotalExpenses > 0 {
-		expenseRatio := metrics.TotalExpenses / metrics.TotalRaised
-		if expenseRatio >= 0.8 {
-			bonus += 3.0
+		expenseRatio := metrics.TotalExpenses.Ratio(metrics.TotalRaised)
+		if expenseRatio >= rules.ExpenseRatioThreshold {
+			bonus += rules.ExpenseBonus
 		}
 	}
 	if closedBeforeEndDate {
-		bonus += 2.0
+		bonus += rules.EarlyClosureBonus
 	}
 	breakdown.BonusScore = bonus
 
@@ -406,7 +453,10 @@ func (s *service) GetPublicAuditReport(ctx context.Context, campaignID uuid.UUID
 		CampaignTitle:     campaignInfo.Title,
 		OrganizerName:     organizerName,
 		ClosedAt:          report.ClosedAt,
+		Currency:          report.Currency,
 		TotalRaised:       report.TotalRaised,
+		OrganicRaised:     report.OrganicRaised,
+		MatchedRaised:     report.MatchedRaised,
 		CampaignGoal:      report.CampaignGoal,
 		GoalPercentage:    report.GoalPercentage,
 		TotalDonors:       report.TotalDonors,
@@ -420,3 +470,263 @@ func (s *service) GetPublicAuditReport(ctx context.Context, campaignID uuid.UUID
 func (s *service) HasClosureReport(ctx context.Context, campaignID uuid.UUID) (bool, error) {
 	return s.repo.ExistsClosureReport(ctx, campaignID)
 }
+
+// FindClosureCandidates lists the campaigns that the automatic closure would close right now
+func (s *service) FindClosureCandidates(ctx context.Context) ([]ClosureCandidate, error) {
+	candidates, err := s.repo.FindClosureCandidates(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find closure candidates: %w", err)
+	}
+	return candidates, nil
+}
+
+// RunAutoClosure closes every campaign that reached its goal or end date.
+// With dryRun only the candidates are returned and nothing is closed.
+func (s *service) RunAutoClosure(ctx context.Context, dryRun bool) (*AutoClosureResult, error) {
+	candidates, err := s.FindClosureCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AutoClosureResult{
+		DryRun:     dryRun,
+		RunAt:      time.Now(),
+		Candidates: candidates,
+		Closed:     []uuid.UUID{},
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	for _, candidate := range candidates {
+		// System closures have no closing user
+		if _, err := s.CloseCampaign(ctx, candidate.CampaignID, candidate.ClosureType, nil, nil); err != nil {
+			if result.Failed == nil {
+				result.Failed = make(map[string]string)
+			}
+			result.Failed[candidate.CampaignID.String()] = err.Error()
+			continue
+		}
+		result.Closed = append(result.Closed, candidate.CampaignID)
+	}
+
+	return result, nil
+}
+
+// GetTransparencyPreview computes the transparency score of an open campaign as if it closed
+// today at its end date, with hints on how to improve it
+func (s *service) GetTransparencyPreview(ctx context.Context, campaignID uuid.UUID) (*TransparencyPreview, error) {
+	campaignInfo, err := s.campaignService.GetCampaignForClosure(ctx, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("campaign not found: %w", err)
+	}
+
+	if campaignInfo.Status != "active" && campaignInfo.Status != "paused" {
+		return nil, fmt.Errorf("transparency preview is only available for active or paused campaigns, current status: %s", campaignInfo.Status)
+	}
+
+	metrics, err := s.gatherClosureMetrics(ctx, campaignInfo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rules := s.activeScoringRules(ctx)
+	breakdown := s.calculateTransparencyScore(metrics, false, rules.Rules)
+	now := time.Now()
+
+	return &TransparencyPreview{
+		CampaignID:            campaignID,
+		CampaignStatus:        campaignInfo.Status,
+		TransparencyScore:     breakdown.Total(),
+		TransparencyBreakdown: breakdown,
+		RulesVersion:          rules.Version,
+		Hints:                 s.transparencyHints(metrics, breakdown, rules.Rules, now),
+		CalculatedAt:          now,
+	}, nil
+}
+
+// transparencyHints lists actionable suggestions for every score category below its maximum
+func (s *service) transparencyHints(metrics ClosureMetrics, breakdown TransparencyBreakdown, rules ScoringRules, now time.Time) []TransparencyHint {
+	hints := []TransparencyHint{}
+
+	// Documentation
+	if metrics.TotalReceipts == 0 {
+		hints = append(hints, TransparencyHint{
+			Category:        "documentation",
+			Message:         "no receipts uploaded yet",
+			PotentialPoints: rules.DocumentationMax - breakdown.DocumentationScore,
+		})
+	} else if missing := metrics.TotalReceipts - metrics.ReceiptsWithDocuments; missing > 0 {
+		hints = append(hints, TransparencyHint{
+			Category:        "documentation",
+			Message:         fmt.Sprintf("%d receipts missing documents", missing),
+			PotentialPoints: rules.DocumentationMax - breakdown.DocumentationScore,
+		})
+	}
+
+	// Activities
+	if metrics.LastActivityDate == nil {
+		hints = append(hints, TransparencyHint{
+			Category:        "activities",
+			Message:         "no activity posted yet",
+			PotentialPoints: rules.ActivityMax - breakdown.ActivityScore,
+		})
+	} else if daysSince := int(now.Sub(*metrics.LastActivityDate).Hours() / 24); len(rules.TimelinessTiers) > 0 && float64(daysSince) > rules.TimelinessTiers[0].MaxDays {
+		hints = append(hints, TransparencyHint{
+			Category:        "activities",
+			Message:         fmt.Sprintf("no activity posted in %d days", daysSince),
+			PotentialPoints: rules.ActivityMax - breakdown.ActivityScore,
+		})
+	} else if breakdown.ActivityScore < rules.ActivityMax {
+		hints = append(hints, TransparencyHint{
+			Category:        "activities",
+			Message:         fmt.Sprintf("post at least one activity every %.0f days of campaign", rules.ActivityPeriodDays),
+			PotentialPoints: rules.ActivityMax - breakdown.ActivityScore,
+		})
+	}
+
+	// Goal progress
+	if breakdown.GoalProgressScore < rules.GoalProgressMax && metrics.CampaignGoal > 0 {
+		hints = append(hints, TransparencyHint{
+			Category:        "goal_progress",
+			Message:         fmt.Sprintf("%.0f%% of the goal raised so far", metrics.TotalRaised.Ratio(metrics.CampaignGoal)*100),
+			PotentialPoints: rules.GoalProgressMax - breakdown.GoalProgressScore,
+		})
+	}
+
+	// Timeliness
+	if breakdown.TimelinessScore < rules.TimelinessMax() {
+		hints = append(hints, TransparencyHint{
+			Category:        "timeliness",
+			Message:         fmt.Sprintf("activities are posted every %.0f days on average", metrics.AverageDaysBetweenActivities),
+			PotentialPoints: rules.TimelinessMax() - breakdown.TimelinessScore,
+		})
+	}
+
+	// Alerts
+	if unresolved := metrics.AlertsCount - metrics.AlertsResolved; unresolved > 0 {
+		hints = append(hints, TransparencyHint{
+			Category:        "alerts",
+			Message:         fmt.Sprintf("%d unresolved alerts", unresolved),
+			PotentialPoints: -breakdown.AlertsDeductionScore,
+		})
+	}
+
+	// Bonus
+	if !metrics.HasContract {
+		hints = append(hints, TransparencyHint{
+			Category:        "bonus",
+			Message:         "campaign contract not signed",
+			PotentialPoints: rules.ContractBonus,
+		})
+	}
+	if metrics.TotalRaised > 0 && metrics.TotalExpenses.Ratio(metrics.TotalRaised) < rules.ExpenseRatioThreshold {
+		hints = append(hints, TransparencyHint{
+			Category:        "bonus",
+			Message: fmt.Sprintf("documented expenses cover %.0f%% of funds raised, at least %.0f%% is required",
+				metrics.TotalExpenses.Ratio(metrics.TotalRaised)*100, rules.ExpenseRatioThreshold*100),
+			PotentialPoints: rules.ExpenseBonus,
+		})
+	}
+
+	return hints
+}
+
+// activeScoringRules returns the active scoring rules, falling back to the defaults (version 1)
+// if they cannot be loaded
+func (s *service) activeScoringRules(ctx context.Context) ScoringRulesVersion {
+	rules, err := s.repo.GetActiveScoringRules(ctx)
+	if err != nil {
+		fmt.Printf("Warning: failed to load active scoring rules, using defaults: %v\n", err)
+		return ScoringRulesVersion{Version: 1, Rules: DefaultScoringRules(), IsActive: true}
+	}
+	return rules
+}
+
+func (s *service) ListScoringRules(ctx context.Context) ([]ScoringRulesVersion, error) {
+	return s.repo.ListScoringRules(ctx)
+}
+
+func (s *service) GetActiveScoringRules(ctx context.Context) (ScoringRulesVersion, error) {
+	rules, err := s.repo.GetActiveScoringRules(ctx)
+	if err != nil {
+		return ScoringRulesVersion{}, fmt.Errorf("active scoring rules not found: %w", err)
+	}
+	return rules, nil
+}
+
+// CreateScoringRules publishes a new rules version. Existing versions are never modified.
+func (s *service) CreateScoringRules(ctx context.Context, req CreateScoringRulesRequest, createdBy *uuid.UUID) (ScoringRulesVersion, error) {
+	if err := req.Rules.Validate(); err != nil {
+		return ScoringRulesVersion{}, fmt.Errorf("invalid scoring rules: %w", err)
+	}
+
+	created, err := s.repo.CreateScoringRules(ctx, ScoringRulesVersion{
+		Rules:     req.Rules,
+		Notes:     req.Notes,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return ScoringRulesVersion{}, fmt.Errorf("failed to create scoring rules: %w", err)
+	}
+
+	if req.Activate {
+		if err := s.repo.ActivateScoringRules(ctx, created.Version); err != nil {
+			return ScoringRulesVersion{}, fmt.Errorf("failed to activate scoring rules: %w", err)
+		}
+		created.IsActive = true
+	}
+
+	return created, nil
+}
+
+func (s *service) ActivateScoringRules(ctx context.Context, version int) error {
+	if err := s.repo.ActivateScoringRules(ctx, version); err != nil {
+		return fmt.Errorf("failed to activate scoring rules version %d: %w", version, err)
+	}
+	return nil
+}
+
+// RescoreCampaign scores a closed campaign under another rules version. Only the scoring inputs stored
+// in the closure report are used, so later changes to the campaign do not affect the score, and the
+// original report is left untouched.
+func (s *service) RescoreCampaign(ctx context.Context, campaignID uuid.UUID, rulesVersion *int, rescoredBy *uuid.UUID) (*TransparencyRescore, error) {
+	report, err := s.repo.GetClosureReport(ctx, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("closure report not found: %w", err)
+	}
+
+	var rules ScoringRulesVersion
+	if rulesVersion != nil {
+		rules, err = s.repo.GetScoringRules(ctx, *rulesVersion)
+	} else {
+		rules, err = s.repo.GetActiveScoringRules(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scoring rules not found: %w", err)
+	}
+
+	breakdown := s.calculateTransparencyScore(report.ScoringMetrics(), report.ClosureType == ClosureTypeManual, rules.Rules)
+
+	rescore := TransparencyRescore{
+		ID:                    uuid.New(),
+		ClosureReportID:       report.ID,
+		CampaignID:            campaignID,
+		RulesVersion:          rules.Version,
+		TransparencyScore:     breakdown.Total(),
+		TransparencyBreakdown: breakdown,
+		RescoredBy:            rescoredBy,
+		CreatedAt:             time.Now(),
+	}
+
+	if err := s.repo.CreateRescore(ctx, rescore); err != nil {
+		return nil, fmt.Errorf("failed to save rescore: %w", err)
+	}
+
+	return &rescore, nil
+}
+
+func (s *service) ListRescores(ctx context.Context, campaignID uuid.UUID) ([]TransparencyRescore, error) {
+	return s.repo.ListRescores(ctx, campaignID)
+}
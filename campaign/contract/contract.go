@@ -3,6 +3,8 @@ package contract
 import (
 	"time"
 
+	"dona_tutti_api/money"
+
 	"github.com/google/uuid"
 )
 
@@ -28,7 +30,7 @@ type AcceptanceMetadata struct {
 type ContractData struct {
 	CampaignID       uuid.UUID
 	CampaignTitle    string
-	CampaignGoal     float64
+	CampaignGoal     money.Amount
 	OrganizerID      uuid.UUID
 	OrganizerName    string
 	OrganizerEmail   string
@@ -44,7 +44,7 @@ func (g *pdfGenerator) Generate(data ContractData) ([]byte, string, error) {
 
 	pdf.SetFont("Arial", "", 10)
 	pdf.MultiCell(190, 6, fmt.Sprintf("Titulo de la campana: %s", data.CampaignTitle), "", "L", false)
-	pdf.MultiCell(190, 6, fmt.Sprintf("Objetivo de recaudacion: $%.2f", data.CampaignGoal), "", "L", false)
+	pdf.MultiCell(190, 6, fmt.Sprintf("Objetivo de recaudacion: $%s", data.CampaignGoal), "", "L", false)
 	pdf.MultiCell(190, 6, fmt.Sprintf("ID de la campana: %s", data.CampaignID.String()), "", "L", false)
 	pdf.Ln(5)
 
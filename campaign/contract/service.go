@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"dona_tutti_api/money"
 	"dona_tutti_api/s3client"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -31,7 +32,7 @@ type PDFGenerator interface {
 type CampaignInfo struct {
 	ID          uuid.UUID
 	Title       string
-	Goal        float64
+	Goal        money.Amount
 	OrganizerID uuid.UUID
 	Status      string
 }
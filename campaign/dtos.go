@@ -3,22 +3,96 @@ package campaign
 import (
 	"time"
 
+	"dona_tutti_api/money"
+
 	"github.com/google/uuid"
 )
 
 type CampaignRequest struct {
-	ID                uuid.UUID `json:"id"`
-	CreatedAt         time.Time `json:"created_at"`
-	Title             string    `json:"title"`
-	Description       string    `json:"description"`
-	Image             string    `json:"image"`
-	Goal              float64   `json:"goal"`
-	StartDate         time.Time `json:"start_date"`
-	EndDate           time.Time `json:"end_date"`
-	Location          string    `json:"location"`
-	CategoryId        uuid.UUID `json:"category"`
-	Urgency           int       `json:"urgency"`
-	OrganizerId       uuid.UUID `json:"organizer"`
-	Status            string    `json:"status"`
-	PaymentMethodsIds []int     `json:"payment_methods_ids,omitempty"`
+	ID                uuid.UUID    `json:"id"`
+	CreatedAt         time.Time    `json:"created_at"`
+	Title             string       `json:"title"`
+	Description       string       `json:"description"`
+	Image             string       `json:"image"`
+	Goal              money.Amount `json:"goal"`
+	Currency          string       `json:"currency,omitempty"`
+	StartDate         time.Time    `json:"start_date"`
+	EndDate           time.Time    `json:"end_date"`
+	Location          string       `json:"location"`
+	CategoryId        uuid.UUID    `json:"category"`
+	Urgency           int          `json:"urgency"`
+	OrganizerId       uuid.UUID    `json:"organizer"`
+	Status            string       `json:"status"`
+	PaymentMethodsIds []int        `json:"payment_methods_ids,omitempty"`
+}
+
+// CampaignUpdateRequest represents a partial update request for campaigns
+type CampaignUpdateRequest struct {
+	Title            *string                  `json:"title,omitempty"`
+	Description      *string                  `json:"description,omitempty"`
+	Goal             *money.Amount            `json:"goal,omitempty"`
+	Currency         *string                  `json:"currency,omitempty"`
+	StartDate        *time.Time               `json:"start_date,omitempty"`
+	EndDate          *time.Time               `json:"end_date,omitempty"`
+	Location         *string                  `json:"location,omitempty"`
+	Urgency          *int                     `json:"urgency,omitempty"`
+	CategoryId       *uuid.UUID               `json:"category,omitempty"`
+	BeneficiaryName  *string                  `json:"beneficiary_name,omitempty"`
+	BeneficiaryAge   *int                     `json:"beneficiary_age,omitempty"`
+	CurrentSituation *string                  `json:"current_situation,omitempty"`
+	UrgencyReason    *string                  `json:"urgency_reason,omitempty"`
+	PaymentMethods   *[]CampaignPaymentMethod `json:"payment_methods,omitempty"`
+}
+
+// Campaign listing defaults
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Sort fields accepted by the campaign listing
+const (
+	SortByCreatedAt = "created_at"
+	SortByUrgency   = "urgency"
+	SortByEndDate   = "end_date"
+	SortByGoal      = "goal"
+	SortByRaised    = "raised"
+)
+
+// CampaignListFilter represents the filters, sorting and pagination for campaign listings
+type CampaignListFilter struct {
+	Statuses    []string
+	CategoryID  *uuid.UUID
+	OrganizerID *uuid.UUID
+	Location    string
+	UrgencyMin  *int
+	UrgencyMax  *int
+	DateFrom    *time.Time
+	DateTo      *time.Time
+	Query       string
+	SortBy      string
+	SortDesc    bool
+	Page        int
+	PageSize    int
+	// IncludeHidden lets admins filter by statuses outside PublicListStatuses
+	IncludeHidden bool
+}
+
+// CampaignListResult represents a page of campaigns with total counts
+type CampaignListResult struct {
+	Data       []Campaign `json:"data"`
+	Total      int64      `json:"total"`
+	Page       int        `json:"page"`
+	PageSize   int        `json:"page_size"`
+	TotalPages int        `json:"total_pages"`
+}
+
+// CampaignSearchResponse represents a page of full-text search results
+type CampaignSearchResponse struct {
+	Data       []CampaignSearchResult `json:"data"`
+	Query      string                 `json:"query"`
+	Total      int64                  `json:"total"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+	TotalPages int                    `json:"total_pages"`
 }
@@ -4,26 +4,39 @@ import (
 	"dona_tutti_api/campaign/activity"
 	"dona_tutti_api/campaign/receipts"
 	"dona_tutti_api/donation"
+	apierrors "dona_tutti_api/errors"
 	"dona_tutti_api/middleware"
 	"dona_tutti_api/s3client"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type Handler struct {
-	service  Service
-	s3Client *s3client.Client
+	service     Service
+	s3Client    *s3client.Client
+	rbacService middleware.RBACService
 }
 
-func NewHandler(service Service, s3Client *s3client.Client) *Handler {
-	return &Handler{service: service, s3Client: s3Client}
+func NewHandler(service Service, s3Client *s3client.Client, rbacService middleware.RBACService) *Handler {
+	return &Handler{service: service, s3Client: s3Client, rbacService: rbacService}
 }
 
+// Rate limit of the public donation endpoint, per client
+const (
+	publicDonationRateLimit = 10 // requests per minute
+	publicDonationBurst     = 5
+)
+
 // RegisterRoutes registers all campaign routes with RBAC authorization
 func RegisterRoutes(g *echo.Group, service Service, activityService activity.Service, receiptsService receipts.Service, donationService donation.Service, s3Client *s3client.Client, rbacService middleware.RBACService) {
-	handler := NewHandler(service, s3Client)
+	handler := NewHandler(service, s3Client, rbacService)
 	activityHandler := activity.NewHandler(activityService)
 	receiptsHandler := receipts.NewHandler(receiptsService, s3Client)
 	donationHandler := donation.NewHandler(donationService)
@@ -34,13 +47,17 @@ func RegisterRoutes(g *echo.Group, service Service, activityService activity.Ser
 
 	// Public routes (no authentication required)
 	campaignGroup.GET("/:id", handler.GetCampaign)
-	campaignGroup.GET("", handler.ListCampaigns)
+	campaignGroup.GET("", handler.ListCampaigns, middleware.OptionalAuth())
+	campaignGroup.GET("/summary", handler.GetSummary)
+	campaignGroup.GET("/search", handler.SearchCampaigns)
 	campaignGroup.GET("/:campaignId/activities", activityHandler.GetActivitiesByCampaign)
 	campaignGroup.GET("/:campaignId/activities/:id", activityHandler.GetActivity)
 	campaignGroup.GET("/:campaignId/receipts", receiptsHandler.GetReceiptsByCampaign)
 	campaignGroup.GET("/:campaignId/receipts/:id", receiptsHandler.GetReceipt)
 	campaignGroup.GET("/:campaignId/donations", donationHandler.GetDonationsByCampaign)
 	campaignGroup.GET("/:campaignId/donations/:id", donationHandler.GetDonation)
+	campaignGroup.POST("/:campaignId/donate", donationHandler.CreatePublicDonation,
+		middleware.OptionalAuth(), middleware.RateLimit(publicDonationRateLimit, publicDonationBurst))
 
 	// Protected routes with authentication
 	authGroup := campaignGroup.Group("", middleware.RequireAuth())
@@ -49,10 +66,13 @@ func RegisterRoutes(g *echo.Group, service Service, activityService activity.Ser
 	adminGroup := authGroup.Group("", rbacMiddleware.RequireRole("admin"))
 	adminGroup.POST("", handler.CreateCampaign)
 	adminGroup.DELETE("/:id", handler.DeleteCampaign)
+	adminGroup.GET("/archived", handler.ListArchivedCampaigns)
+	adminGroup.POST("/:id/restore", handler.RestoreCampaign)
 	//adminGroup.GET("", handler.ListCampaigns)
 
 	// Campaign upload routes
 	adminGroup.POST("/:id/upload", handler.UploadCampaignImage)
+	adminGroup.GET("/:id/edits", handler.GetCampaignEdits)
 
 	// Activity admin routes
 	adminGroup.POST("/:campaignId/activities", activityHandler.CreateActivity)
@@ -69,6 +89,7 @@ func RegisterRoutes(g *echo.Group, service Service, activityService activity.Ser
 	adminGroup.POST("/:campaignId/donations", donationHandler.CreateDonation)
 	adminGroup.PUT("/:campaignId/donations/:id", donationHandler.UpdateDonation)
 	adminGroup.PATCH("/:campaignId/donations/:id/status", donationHandler.UpdateDonationStatus)
+	adminGroup.GET("/:campaignId/donations/:id/history", donationHandler.GetDonationStatusHistory)
 
 	// Admin or owner routes (using Combine for OR logic)
 	adminOrOwnerGroup := authGroup.Group("", rbacMiddleware.Combine(
@@ -78,21 +99,116 @@ func RegisterRoutes(g *echo.Group, service Service, activityService activity.Ser
 	adminOrOwnerGroup.PUT("/:id", handler.UpdateCampaign)
 }
 
-// @Summary List all campaigns
-// @Description Get a list of all campaigns
+// @Summary List campaigns
+// @Description Get a paginated list of campaigns. Drafts and rejected campaigns are hidden; only admins can list them with a status filter.
 // @Tags campaigns
 // @Accept json
 // @Produce json
-// @Success 200 {array} Campaign
+// @Param status query string false "Comma-separated list of statuses"
+// @Param category query string false "Category ID"
+// @Param organizer query string false "Organizer ID"
+// @Param location query string false "Location (partial match)"
+// @Param urgency_min query int false "Minimum urgency (1-10)"
+// @Param urgency_max query int false "Maximum urgency (1-10)"
+// @Param date_from query string false "Campaigns running on or after this date (YYYY-MM-DD)"
+// @Param date_to query string false "Campaigns running on or before this date (YYYY-MM-DD)"
+// @Param q query string false "Free-text search on title, description and location"
+// @Param sort query string false "Sort field: created_at, urgency, end_date, goal, raised"
+// @Param order query string false "Sort order: asc or desc (default desc)"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} CampaignListResult
 // @Failure 400 {object} errors.APIError
 // @Router /campaigns [get]
 func (h *Handler) ListCampaigns(c echo.Context) error {
-	campaigns, err := h.service.ListCampaigns(c.Request().Context())
+	filter, err := parseListFilter(c)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	return c.JSON(http.StatusOK, campaigns)
+	// Only admins may ask for drafts and rejected campaigns
+	if len(filter.Statuses) > 0 {
+		if userID := getUserID(c); userID != nil {
+			isAdmin, err := h.rbacService.HasRole(c.Request().Context(), *userID, "admin")
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Authorization check failed")
+			}
+			filter.IncludeHidden = isAdmin
+		}
+	}
+
+	result, err := h.service.ListCampaigns(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// @Summary Search campaigns
+// @Description Full-text search on campaign title, description, current situation and beneficiary name. Matching is accent-insensitive and results are ranked by relevance with highlighted snippets.
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param q query string true "Search text"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Success 200 {object} CampaignSearchResponse
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/search [get]
+func (h *Handler) SearchCampaigns(c echo.Context) error {
+	page, pageSize, err := parsePagination(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	results, err := h.service.SearchCampaigns(c.Request().Context(), c.QueryParam("q"), page, pageSize)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// @Summary Get platform summary
+// @Description Get platform-wide campaign and donation statistics in one currency, optionally filtered by date range and category
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param date_from query string false "Start date (YYYY-MM-DD)"
+// @Param date_to query string false "End date (YYYY-MM-DD)"
+// @Param category query string false "Category ID"
+// @Param currency query string false "Currency of the totals (ARS, USD), defaults to ARS. Only campaigns in this currency are included."
+// @Success 200 {object} Summary
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/summary [get]
+func (h *Handler) GetSummary(c echo.Context) error {
+	filter := SummaryFilter{Currency: c.QueryParam("currency")}
+
+	if value := c.QueryParam("category"); value != "" {
+		categoryID, err := uuid.Parse(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid category ID")
+		}
+		filter.CategoryID = &categoryID
+	}
+
+	dateFrom, err := parseDateParam(c, "date_from", false)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	dateTo, err := parseDateParam(c, "date_to", true)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	filter.DateFrom, filter.DateTo = dateFrom, dateTo
+
+	summary, err := h.service.GetSummary(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, summary)
 }
 
 // @Summary Get campaign by ID
@@ -142,19 +258,65 @@ func (h *Handler) CreateCampaign(c echo.Context) error {
 	return c.JSON(http.StatusCreated, campaign)
 }
 
-// @Summary Update campaign details
-// @Description Update campaign details by ID
+// @Summary Update campaign details (partial update)
+// @Description Update campaign details by ID. Only provided fields will be updated. Drafts are freely editable; active and paused campaigns only accept description, goal (capped increase), end date, urgency, urgency reason and current situation.
 // @Tags campaigns
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "Campaign ID"
-// @Param campaign body Campaign true "Campaign details"
-// @Success 200
+// @Param campaign body CampaignUpdateRequest true "Campaign update details"
+// @Success 200 {object} Campaign
 // @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Failure 500 {object} errors.APIError
 // @Router /campaigns/{id} [put]
 func (h *Handler) UpdateCampaign(c echo.Context) error {
-	// Implementation needed
-	return echo.NewHTTPError(http.StatusNotImplemented, "Update campaign details not implemented")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	var updateReq CampaignUpdateRequest
+	if err := c.Bind(&updateReq); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	if err := h.service.UpdateCampaign(c.Request().Context(), id, updateReq, getUserID(c)); err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	// Get updated campaign to return
+	updatedCampaign, err := h.service.GetCampaign(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, updatedCampaign)
+}
+
+// @Summary Get campaign edit history
+// @Description Get the field-level history of edits made to a campaign
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {array} CampaignEdit
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/{id}/edits [get]
+func (h *Handler) GetCampaignEdits(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	edits, err := h.service.ListCampaignEdits(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, edits)
 }
 
 // @Summary Upload campaign image
@@ -218,15 +380,198 @@ func (h *Handler) UploadCampaignImage(c echo.Context) error {
 }
 
 // @Summary Delete a campaign
-// @Description Delete a campaign by ID
+// @Description Archive (soft-delete) a campaign by ID. With hard=true the campaign is permanently deleted, which is only allowed for drafts without donations.
 // @Tags campaigns
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "Campaign ID"
+// @Param hard query bool false "Permanently delete the campaign"
 // @Success 204
 // @Failure 400 {object} errors.APIError
 // @Router /campaigns/{id} [delete]
 func (h *Handler) DeleteCampaign(c echo.Context) error {
-	// Implementation needed
-	return echo.NewHTTPError(http.StatusNotImplemented, "Delete campaign not implemented")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	if c.QueryParam("hard") == "true" {
+		err = h.service.DeleteCampaign(c.Request().Context(), id)
+	} else {
+		err = h.service.ArchiveCampaign(c.Request().Context(), id, getUserID(c))
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary Restore an archived campaign
+// @Description Restore a previously archived campaign so it is visible again
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} Campaign
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/{id}/restore [post]
+func (h *Handler) RestoreCampaign(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	if err := h.service.RestoreCampaign(c.Request().Context(), id); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	campaign, err := h.service.GetCampaign(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, campaign)
+}
+
+// @Summary List archived campaigns
+// @Description Get a list of all archived campaigns
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} Campaign
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/archived [get]
+func (h *Handler) ListArchivedCampaigns(c echo.Context) error {
+	campaigns, err := h.service.ListArchivedCampaigns(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, campaigns)
+}
+
+// errorStatus maps a campaign service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// getUserID returns the authenticated user ID from the context, if any
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
+
+// parseListFilter builds a CampaignListFilter from the request query parameters
+func parseListFilter(c echo.Context) (CampaignListFilter, error) {
+	filter := CampaignListFilter{
+		Location: c.QueryParam("location"),
+		Query:    strings.TrimSpace(c.QueryParam("q")),
+		SortBy:   c.QueryParam("sort"),
+		SortDesc: c.QueryParam("order") != "asc",
+	}
+
+	if status := c.QueryParam("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				filter.Statuses = append(filter.Statuses, s)
+			}
+		}
+	}
+
+	for param, target := range map[string]**uuid.UUID{
+		"category":  &filter.CategoryID,
+		"organizer": &filter.OrganizerID,
+	} {
+		if value := c.QueryParam(param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s ID", param)
+			}
+			*target = &id
+		}
+	}
+
+	for param, target := range map[string]**int{
+		"urgency_min": &filter.UrgencyMin,
+		"urgency_max": &filter.UrgencyMax,
+	} {
+		if value := c.QueryParam(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s", param)
+			}
+			*target = &n
+		}
+	}
+
+	dateFrom, err := parseDateParam(c, "date_from", false)
+	if err != nil {
+		return filter, err
+	}
+	dateTo, err := parseDateParam(c, "date_to", true)
+	if err != nil {
+		return filter, err
+	}
+	filter.DateFrom, filter.DateTo = dateFrom, dateTo
+
+	filter.Page, filter.PageSize, err = parsePagination(c)
+	if err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// parsePagination parses the optional page and page_size query parameters
+func parsePagination(c echo.Context) (page, pageSize int, err error) {
+	if value := c.QueryParam("page"); value != "" {
+		if page, err = strconv.Atoi(value); err != nil {
+			return 0, 0, fmt.Errorf("invalid page")
+		}
+	}
+	if value := c.QueryParam("page_size"); value != "" {
+		if pageSize, err = strconv.Atoi(value); err != nil {
+			return 0, 0, fmt.Errorf("invalid page_size")
+		}
+	}
+	return page, pageSize, nil
+}
+
+// parseDateParam parses an optional YYYY-MM-DD query parameter. When endOfDay is set the
+// returned time is moved to the last instant of that day so the range is inclusive.
+func parseDateParam(c echo.Context, param string, endOfDay bool) (*time.Time, error) {
+	value := c.QueryParam(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s, expected YYYY-MM-DD", param)
+	}
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return &date, nil
 }
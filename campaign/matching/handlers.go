@@ -0,0 +1,170 @@
+package matching
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests for matching pledges
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new matching pledge handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the matching pledge routes. They are only available to admins.
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.POST("/campaigns/:campaignId/matching-pledges", h.CreatePledge)
+	adminGroup.GET("/matching-pledges", h.ListPledges)
+	adminGroup.GET("/matching-pledges/:id", h.GetPledge)
+	adminGroup.POST("/matching-pledges/:id/cancel", h.CancelPledge)
+}
+
+// @Summary Create a matching pledge
+// @Description Register a sponsor organizer pledging to match the donations of a campaign at a ratio (1 doubles every donation), up to a cap in the campaign currency, during a time window. Donations completed in the window get a linked donation made by the sponsor.
+// @Tags matching-pledges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Param pledge body CreatePledgeRequest true "Matching pledge"
+// @Success 201 {object} Pledge
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/{campaignId}/matching-pledges [post]
+func (h *Handler) CreatePledge(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	var req CreatePledgeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	pledge, err := h.service.CreatePledge(c.Request().Context(), campaignID, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, pledge)
+}
+
+// @Summary List matching pledges
+// @Description Get the matching pledges, most recent first
+// @Tags matching-pledges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id query string false "Campaign ID"
+// @Param status query string false "Pledge status (active, cancelled)"
+// @Success 200 {array} Pledge
+// @Failure 400 {object} errors.APIError
+// @Router /matching-pledges [get]
+func (h *Handler) ListPledges(c echo.Context) error {
+	var filter PledgeFilter
+	if value := c.QueryParam("campaign_id"); value != "" {
+		campaignID, err := uuid.Parse(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+		}
+		filter.CampaignID = &campaignID
+	}
+	if value := c.QueryParam("status"); value != "" {
+		status := PledgeStatus(value)
+		filter.Status = &status
+	}
+
+	pledges, err := h.service.ListPledges(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, pledges)
+}
+
+// @Summary Get matching pledge by ID
+// @Description Get a matching pledge with the amount matched so far and what is left of its cap
+// @Tags matching-pledges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pledge ID"
+// @Success 200 {object} Pledge
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /matching-pledges/{id} [get]
+func (h *Handler) GetPledge(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid pledge ID")
+	}
+
+	pledge, err := h.service.GetPledge(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, pledge)
+}
+
+// @Summary Cancel a matching pledge
+// @Description Stop a pledge from matching new donations. Donations it already matched are kept.
+// @Tags matching-pledges
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pledge ID"
+// @Success 200 {object} Pledge
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /matching-pledges/{id}/cancel [post]
+func (h *Handler) CancelPledge(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid pledge ID")
+	}
+
+	pledge, err := h.service.CancelPledge(c.Request().Context(), id, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, pledge)
+}
+
+// errorStatus maps a matching pledge service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,79 @@
+package matching
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// PledgeStatus represents the state of a matching pledge
+type PledgeStatus string
+
+const (
+	PledgeStatusActive    PledgeStatus = "active"
+	PledgeStatusCancelled PledgeStatus = "cancelled"
+)
+
+// IsValidPledgeStatus checks if a pledge status is valid
+func IsValidPledgeStatus(status PledgeStatus) bool {
+	switch status {
+	case PledgeStatusActive, PledgeStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Pledge is a sponsor's commitment to match the donations of a campaign at a ratio, up to a cap,
+// during a time window
+type Pledge struct {
+	ID                 uuid.UUID    `json:"id"`
+	CampaignID         uuid.UUID    `json:"campaign_id"`
+	SponsorOrganizerID uuid.UUID    `json:"sponsor_organizer_id"`
+	SponsorName        string       `json:"sponsor_name"`
+	SponsorDonorID     uuid.UUID    `json:"sponsor_donor_id"` // Donor the matched donations are made by
+	Ratio              float64      `json:"ratio"`            // Amount given per unit donated, 1 doubles every donation
+	Cap                money.Amount `json:"cap"`
+	MatchedAmount      money.Amount `json:"matched_amount"`
+	RemainingAmount    money.Amount `json:"remaining_amount"`
+	Currency           string       `json:"currency"` // Campaign currency, of the cap and every matched amount
+	StartsAt           time.Time    `json:"starts_at"`
+	EndsAt             time.Time    `json:"ends_at"`
+	Status             PledgeStatus `json:"status"`
+	CreatedBy          *uuid.UUID   `json:"created_by,omitempty"`
+	CancelledBy        *uuid.UUID   `json:"cancelled_by,omitempty"`
+	CancelledAt        *time.Time   `json:"cancelled_at,omitempty"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+}
+
+// IsOpenAt reports whether the pledge matches donations made at the given time
+func (p Pledge) IsOpenAt(at time.Time) bool {
+	return p.Status == PledgeStatusActive && !at.Before(p.StartsAt) && at.Before(p.EndsAt) && p.MatchedAmount < p.Cap
+}
+
+// MatchAmount returns what the pledge gives for a donation, limited by what is left of the cap
+func (p Pledge) MatchAmount(donated money.Amount) money.Amount {
+	amount := donated.MulRate(p.Ratio)
+	if remaining := p.Cap - p.MatchedAmount; amount > remaining {
+		return remaining
+	}
+	return amount
+}
+
+// PledgeFilter represents the optional filters when listing pledges
+type PledgeFilter struct {
+	CampaignID *uuid.UUID
+	Status     *PledgeStatus
+}
+
+// CreatePledgeRequest represents a sponsor pledging to match the donations of a campaign
+type CreatePledgeRequest struct {
+	SponsorOrganizerID uuid.UUID    `json:"sponsor_organizer_id" validate:"required"`
+	Ratio              float64      `json:"ratio" validate:"required"`
+	Cap                money.Amount `json:"cap" validate:"required"` // In the campaign currency
+	StartsAt           *time.Time   `json:"starts_at,omitempty"`     // Defaults to now
+	EndsAt             time.Time    `json:"ends_at" validate:"required"`
+}
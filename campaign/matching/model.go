@@ -0,0 +1,77 @@
+package matching
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// PledgeModel represents the database table structure with GORM tags
+type PledgeModel struct {
+	ID                 uuid.UUID    `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	CampaignID         uuid.UUID    `gorm:"column:campaign_id;type:uuid;not null;index"`
+	SponsorOrganizerID uuid.UUID    `gorm:"column:sponsor_organizer_id;type:uuid;not null"`
+	SponsorDonorID     uuid.UUID    `gorm:"column:sponsor_donor_id;type:uuid;not null"`
+	Ratio              float64      `gorm:"column:ratio;type:decimal(6,2);not null"`
+	Cap                money.Amount `gorm:"column:cap;type:decimal(10,2);not null"`
+	MatchedAmount      money.Amount `gorm:"column:matched_amount;type:decimal(10,2);not null;default:0"`
+	Currency           string       `gorm:"column:currency;type:varchar(3);not null"`
+	StartsAt           time.Time    `gorm:"column:starts_at;not null"`
+	EndsAt             time.Time    `gorm:"column:ends_at;not null"`
+	Status             string       `gorm:"column:status;type:varchar(20);not null;default:active"`
+	CreatedBy          *uuid.UUID   `gorm:"column:created_by;type:uuid"`
+	CancelledBy        *uuid.UUID   `gorm:"column:cancelled_by;type:uuid"`
+	CancelledAt        *time.Time   `gorm:"column:cancelled_at"`
+	CreatedAt          time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt          time.Time    `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (PledgeModel) TableName() string {
+	return "matching_pledges"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m PledgeModel) ToEntity() Pledge {
+	return Pledge{
+		ID:                 m.ID,
+		CampaignID:         m.CampaignID,
+		SponsorOrganizerID: m.SponsorOrganizerID,
+		SponsorDonorID:     m.SponsorDonorID,
+		Ratio:              m.Ratio,
+		Cap:                m.Cap,
+		MatchedAmount:      m.MatchedAmount,
+		RemainingAmount:    m.Cap - m.MatchedAmount,
+		Currency:           m.Currency,
+		StartsAt:           m.StartsAt,
+		EndsAt:             m.EndsAt,
+		Status:             PledgeStatus(m.Status),
+		CreatedBy:          m.CreatedBy,
+		CancelledBy:        m.CancelledBy,
+		CancelledAt:        m.CancelledAt,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *PledgeModel) FromEntity(entity Pledge) {
+	m.ID = entity.ID
+	m.CampaignID = entity.CampaignID
+	m.SponsorOrganizerID = entity.SponsorOrganizerID
+	m.SponsorDonorID = entity.SponsorDonorID
+	m.Ratio = entity.Ratio
+	m.Cap = entity.Cap
+	m.MatchedAmount = entity.MatchedAmount
+	m.Currency = entity.Currency
+	m.StartsAt = entity.StartsAt
+	m.EndsAt = entity.EndsAt
+	m.Status = string(entity.Status)
+	m.CreatedBy = entity.CreatedBy
+	m.CancelledBy = entity.CancelledBy
+	m.CancelledAt = entity.CancelledAt
+	m.CreatedAt = entity.CreatedAt
+	m.UpdatedAt = entity.UpdatedAt
+}
@@ -0,0 +1,213 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dona_tutti_api/donation"
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentMethodCode is the payment method of the donations made by sponsors matching other donations
+const PaymentMethodCode = "matching"
+
+var (
+	// ErrPledgeNotActive is returned when a pledge was cancelled
+	ErrPledgeNotActive = errors.New("matching pledge is not active")
+	// ErrPledgeExhausted is returned when the whole cap of a pledge was already matched
+	ErrPledgeExhausted = errors.New("matching pledge cap was reached")
+	// ErrAlreadyMatched is returned when a pledge already matched a donation
+	ErrAlreadyMatched = errors.New("donation was already matched by this pledge")
+)
+
+type Repository interface {
+	CreatePledge(ctx context.Context, pledge Pledge) error
+	GetPledge(ctx context.Context, id uuid.UUID) (Pledge, error)
+	ListPledges(ctx context.Context, filter PledgeFilter) ([]Pledge, error)
+	ListOpenPledges(ctx context.Context, campaignID uuid.UUID, at time.Time) ([]Pledge, error)
+	CancelPledge(ctx context.Context, id uuid.UUID, cancelledBy *uuid.UUID, cancelledAt time.Time) error
+	MatchDonation(ctx context.Context, pledgeID uuid.UUID, donated money.Amount, matched donation.Donation) (donation.Donation, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreatePledge(ctx context.Context, pledge Pledge) error {
+	var model PledgeModel
+	model.FromEntity(pledge)
+	return r.db.WithContext(ctx).Create(&model).Error
+}
+
+// pledgeRow is a pledge with the name of its sponsor
+type pledgeRow struct {
+	PledgeModel
+	SponsorName string
+}
+
+func (r *repository) pledgesQuery(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("matching_pledges mp").
+		Select("mp.*, o.name AS sponsor_name").
+		Joins("JOIN organizers o ON o.id = mp.sponsor_organizer_id")
+}
+
+func (r *repository) GetPledge(ctx context.Context, id uuid.UUID) (Pledge, error) {
+	var rows []pledgeRow
+	if err := r.pledgesQuery(ctx).Where("mp.id = ?", id).Scan(&rows).Error; err != nil {
+		return Pledge{}, err
+	}
+	if len(rows) == 0 {
+		return Pledge{}, gorm.ErrRecordNotFound
+	}
+
+	return rows[0].toEntity(), nil
+}
+
+// ListPledges returns the pledges matching the filter, most recent first
+func (r *repository) ListPledges(ctx context.Context, filter PledgeFilter) ([]Pledge, error) {
+	query := r.pledgesQuery(ctx)
+	if filter.CampaignID != nil {
+		query = query.Where("mp.campaign_id = ?", *filter.CampaignID)
+	}
+	if filter.Status != nil {
+		query = query.Where("mp.status = ?", string(*filter.Status))
+	}
+
+	var rows []pledgeRow
+	if err := query.Order("mp.created_at DESC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return toEntities(rows), nil
+}
+
+// ListOpenPledges returns the active pledges of a campaign whose window contains the given time and
+// whose cap was not reached, oldest first so earlier sponsors match first
+func (r *repository) ListOpenPledges(ctx context.Context, campaignID uuid.UUID, at time.Time) ([]Pledge, error) {
+	var rows []pledgeRow
+	err := r.pledgesQuery(ctx).
+		Where("mp.campaign_id = ? AND mp.status = ?", campaignID, string(PledgeStatusActive)).
+		Where("mp.starts_at <= ? AND mp.ends_at > ?", at, at).
+		Where("mp.matched_amount < mp.cap").
+		Order("mp.created_at ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toEntities(rows), nil
+}
+
+// CancelPledge stops an active pledge. Donations it already matched are kept.
+func (r *repository) CancelPledge(ctx context.Context, id uuid.UUID, cancelledBy *uuid.UUID, cancelledAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&PledgeModel{}).
+		Where("id = ? AND status = ?", id, string(PledgeStatusActive)).
+		Updates(map[string]interface{}{
+			"status":       string(PledgeStatusCancelled),
+			"cancelled_by": cancelledBy,
+			"cancelled_at": cancelledAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPledgeNotActive
+	}
+	return nil
+}
+
+// MatchDonation creates the completed donation of a pledge matching another donation and adds it to
+// the matched amount of the pledge. The pledge is locked so concurrent matches cannot exceed its cap;
+// the amount given for the donated amount is limited to what is left of the cap.
+func (r *repository) MatchDonation(ctx context.Context, pledgeID uuid.UUID, donated money.Amount, matched donation.Donation) (donation.Donation, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pledgeModel PledgeModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", pledgeID).First(&pledgeModel).Error
+		if err != nil {
+			return err
+		}
+		pledge := pledgeModel.ToEntity()
+		if pledge.Status != PledgeStatusActive {
+			return ErrPledgeNotActive
+		}
+
+		var existing int64
+		err = tx.Model(&donation.DonationModel{}).
+			Where("matching_pledge_id = ? AND matched_donation_id = ?", pledgeID, matched.MatchedDonationID).
+			Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrAlreadyMatched
+		}
+
+		matched.Amount = pledge.MatchAmount(donated)
+		if !matched.Amount.IsPositive() {
+			return ErrPledgeExhausted
+		}
+		matched.OriginalAmount = matched.Amount
+
+		var paymentMethodIDs []int
+		err = tx.Table("payment_methods").Where("code = ?", PaymentMethodCode).Pluck("id", &paymentMethodIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(paymentMethodIDs) == 0 {
+			return errors.New("matching payment method not found")
+		}
+		matched.PaymentMethodID = paymentMethodIDs[0]
+
+		var donationModel donation.DonationModel
+		donationModel.FromEntity(matched)
+		if err := tx.Create(&donationModel).Error; err != nil {
+			return err
+		}
+
+		var historyModel donation.StatusHistoryModel
+		historyModel.FromEntity(donation.StatusHistoryEntry{
+			ID:         uuid.New(),
+			DonationID: matched.ID,
+			ToStatus:   donation.DonationStatusCompleted,
+			Source:     donation.StatusSourceMatching,
+			CreatedAt:  matched.Date,
+		})
+		if err := tx.Create(&historyModel).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&PledgeModel{}).
+			Where("id = ?", pledgeID).
+			Update("matched_amount", gorm.Expr("matched_amount + ?", matched.Amount)).Error
+	})
+	if err != nil {
+		return donation.Donation{}, err
+	}
+
+	return matched, nil
+}
+
+func toEntities(rows []pledgeRow) []Pledge {
+	pledges := make([]Pledge, len(rows))
+	for i, row := range rows {
+		pledges[i] = row.toEntity()
+	}
+	return pledges
+}
+
+func (row pledgeRow) toEntity() Pledge {
+	pledge := row.PledgeModel.ToEntity()
+	pledge.SponsorName = row.SponsorName
+	return pledge
+}
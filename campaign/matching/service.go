@@ -0,0 +1,235 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"dona_tutti_api/donation"
+	"dona_tutti_api/donor"
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/organizer"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxRatio is the largest amount a sponsor can give per unit donated
+const MaxRatio = 100
+
+type Service interface {
+	CreatePledge(ctx context.Context, campaignID uuid.UUID, req CreatePledgeRequest, createdBy *uuid.UUID) (Pledge, error)
+	GetPledge(ctx context.Context, id uuid.UUID) (Pledge, error)
+	ListPledges(ctx context.Context, filter PledgeFilter) ([]Pledge, error)
+	CancelPledge(ctx context.Context, id uuid.UUID, cancelledBy *uuid.UUID) (Pledge, error)
+	MatchDonation(ctx context.Context, d donation.Donation) error
+}
+
+// CampaignService defines the campaign operations needed by matching pledges
+type CampaignService interface {
+	GetCampaignCurrency(ctx context.Context, campaignID uuid.UUID) (string, error)
+}
+
+// OrganizerService defines the organizer operations needed to identify sponsors
+type OrganizerService interface {
+	GetOrganizer(ctx context.Context, id uuid.UUID) (organizer.Organizer, error)
+}
+
+// DonorService defines the donor operations needed to record the donations of sponsors
+type DonorService interface {
+	CreateDonor(ctx context.Context, donor donor.Donor) (uuid.UUID, error)
+	FindDonorByEmail(ctx context.Context, email string) (donor.Donor, error)
+}
+
+type service struct {
+	repo             Repository
+	campaignService  CampaignService
+	organizerService OrganizerService
+	donorService     DonorService
+}
+
+func NewService(repo Repository, campaignService CampaignService, organizerService OrganizerService, donorService DonorService) Service {
+	return &service{
+		repo:             repo,
+		campaignService:  campaignService,
+		organizerService: organizerService,
+		donorService:     donorService,
+	}
+}
+
+// CreatePledge registers a sponsor organizer pledging to match the donations of a campaign
+func (s *service) CreatePledge(ctx context.Context, campaignID uuid.UUID, req CreatePledgeRequest, createdBy *uuid.UUID) (Pledge, error) {
+	if req.SponsorOrganizerID == uuid.Nil {
+		return Pledge{}, apierrors.NewFieldValidationError("sponsor_organizer_id", "sponsor organizer is required")
+	}
+	if req.Ratio <= 0 || req.Ratio > MaxRatio {
+		return Pledge{}, apierrors.NewFieldValidationError("ratio", fmt.Sprintf("ratio must be greater than 0 and at most %d", MaxRatio))
+	}
+	if !req.Cap.IsPositive() {
+		return Pledge{}, apierrors.NewFieldValidationError("cap", "cap must be greater than 0")
+	}
+	if req.Cap > donation.MaxDonationAmount {
+		return Pledge{}, apierrors.NewFieldValidationError("cap", fmt.Sprintf("cap cannot exceed %s", donation.MaxDonationAmount))
+	}
+
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	if req.EndsAt.IsZero() {
+		return Pledge{}, apierrors.NewFieldValidationError("ends_at", "end of the matching window is required")
+	}
+	if !req.EndsAt.After(startsAt) {
+		return Pledge{}, apierrors.NewFieldValidationError("ends_at", "matching window must end after it starts")
+	}
+	if !req.EndsAt.After(now) {
+		return Pledge{}, apierrors.NewFieldValidationError("ends_at", "matching window cannot end in the past")
+	}
+
+	campaignCurrency, err := s.campaignService.GetCampaignCurrency(ctx, campaignID)
+	if err != nil {
+		return Pledge{}, fmt.Errorf("failed to get campaign currency: %w", err)
+	}
+
+	sponsor, err := s.organizerService.GetOrganizer(ctx, req.SponsorOrganizerID)
+	if err != nil {
+		return Pledge{}, fmt.Errorf("failed to get sponsor organizer: %w", err)
+	}
+	sponsorDonorID, err := s.getOrCreateSponsorDonor(ctx, sponsor)
+	if err != nil {
+		return Pledge{}, err
+	}
+
+	pledge := Pledge{
+		ID:                 uuid.New(),
+		CampaignID:         campaignID,
+		SponsorOrganizerID: sponsor.ID,
+		SponsorDonorID:     sponsorDonorID,
+		Ratio:              req.Ratio,
+		Cap:                req.Cap,
+		Currency:           campaignCurrency,
+		StartsAt:           startsAt,
+		EndsAt:             req.EndsAt,
+		Status:             PledgeStatusActive,
+		CreatedBy:          createdBy,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if err := s.repo.CreatePledge(ctx, pledge); err != nil {
+		return Pledge{}, fmt.Errorf("failed to create matching pledge: %w", err)
+	}
+
+	log.Printf("🤝 %s pledged to match donations to campaign %s up to %s", sponsor.Name, campaignID.String(), pledge.Cap.Format(pledge.Currency))
+	return s.GetPledge(ctx, pledge.ID)
+}
+
+// getOrCreateSponsorDonor returns the donor the matched donations of a sponsor are made by
+func (s *service) getOrCreateSponsorDonor(ctx context.Context, sponsor organizer.Organizer) (uuid.UUID, error) {
+	if sponsor.Email != "" {
+		existingDonor, err := s.donorService.FindDonorByEmail(ctx, sponsor.Email)
+		if err == nil {
+			return existingDonor.ID, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, fmt.Errorf("error searching donor by email: %w", err)
+		}
+	}
+
+	donorID, err := s.donorService.CreateDonor(ctx, donor.Donor{
+		FirstName: sponsor.Name,
+		Email:     sponsor.Email,
+		Phone:     sponsor.Phone,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create sponsor donor: %w", err)
+	}
+	return donorID, nil
+}
+
+func (s *service) GetPledge(ctx context.Context, id uuid.UUID) (Pledge, error) {
+	pledge, err := s.repo.GetPledge(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Pledge{}, apierrors.NewNotFoundError("matching pledge not found")
+		}
+		return Pledge{}, fmt.Errorf("failed to get matching pledge: %w", err)
+	}
+	return pledge, nil
+}
+
+func (s *service) ListPledges(ctx context.Context, filter PledgeFilter) ([]Pledge, error) {
+	if filter.Status != nil && !IsValidPledgeStatus(*filter.Status) {
+		return nil, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid pledge status: %s", *filter.Status))
+	}
+
+	pledges, err := s.repo.ListPledges(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matching pledges: %w", err)
+	}
+	return pledges, nil
+}
+
+// CancelPledge stops a pledge from matching new donations. Donations it already matched are kept.
+func (s *service) CancelPledge(ctx context.Context, id uuid.UUID, cancelledBy *uuid.UUID) (Pledge, error) {
+	if _, err := s.GetPledge(ctx, id); err != nil {
+		return Pledge{}, err
+	}
+
+	if err := s.repo.CancelPledge(ctx, id, cancelledBy, time.Now()); err != nil {
+		if errors.Is(err, ErrPledgeNotActive) {
+			return Pledge{}, apierrors.NewValidationError(err.Error())
+		}
+		return Pledge{}, fmt.Errorf("failed to cancel matching pledge: %w", err)
+	}
+
+	return s.GetPledge(ctx, id)
+}
+
+// MatchDonation creates a matched donation for every open pledge of the campaign of a completed
+// donation, until the caps of the pledges are reached. Donations made by sponsors are not matched.
+func (s *service) MatchDonation(ctx context.Context, d donation.Donation) error {
+	if d.IsMatched() || d.Status != donation.DonationStatusCompleted {
+		return nil
+	}
+
+	pledges, err := s.repo.ListOpenPledges(ctx, d.CampaignID, d.Date)
+	if err != nil {
+		return fmt.Errorf("failed to list matching pledges: %w", err)
+	}
+
+	for _, pledge := range pledges {
+		if pledge.Currency != d.Currency || !pledge.MatchAmount(d.NetAmount()).IsPositive() {
+			continue
+		}
+
+		pledgeID, donationID := pledge.ID, d.ID
+		message := fmt.Sprintf("Contrapartida de %s", pledge.SponsorName)
+		matched, err := s.repo.MatchDonation(ctx, pledge.ID, d.NetAmount(), donation.Donation{
+			ID:                uuid.New(),
+			CampaignID:        d.CampaignID,
+			Currency:          pledge.Currency,
+			OriginalCurrency:  pledge.Currency,
+			ExchangeRate:      1,
+			DonorID:           &pledge.SponsorDonorID,
+			Date:              time.Now(),
+			Message:           &message,
+			Status:            donation.DonationStatusCompleted,
+			MatchingPledgeID:  &pledgeID,
+			MatchedDonationID: &donationID,
+		})
+		if err != nil {
+			// Another donation reached the cap or the pledge was cancelled meanwhile
+			if errors.Is(err, ErrPledgeExhausted) || errors.Is(err, ErrPledgeNotActive) || errors.Is(err, ErrAlreadyMatched) {
+				continue
+			}
+			return fmt.Errorf("failed to match donation with pledge %s: %w", pledge.ID.String(), err)
+		}
+
+		log.Printf("🤝 Donation %s matched by %s with %s", d.ID.String(), pledge.SponsorName, matched.Amount.Format(matched.Currency))
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package campaign
 import (
 	"time"
 
+	"dona_tutti_api/money"
+
 	"github.com/google/uuid"
 )
 
@@ -15,6 +17,34 @@ type CampaignPaymentMethodModel struct {
 	Instructions    *string `gorm:"column:instructions"`
 }
 
+// CampaignSearchModel represents a campaign row returned by full-text search
+type CampaignSearchModel struct {
+	CampaignModel
+	Rank           float64 `gorm:"column:rank"`
+	TitleHighlight string  `gorm:"column:title_highlight"`
+	Snippet        string  `gorm:"column:snippet"`
+}
+
+// DonationStatsModel represents the aggregated donation totals of a campaign
+type DonationStatsModel struct {
+	CampaignID    uuid.UUID    `gorm:"column:campaign_id"`
+	RaisedAmount  money.Amount `gorm:"column:raised_amount"`
+	MatchedAmount money.Amount `gorm:"column:matched_amount"`
+	DonorCount    int          `gorm:"column:donor_count"`
+	DonationCount int          `gorm:"column:donation_count"`
+}
+
+// MatchingPledgeModel represents a matching pledge row shown with a campaign
+type MatchingPledgeModel struct {
+	ID            uuid.UUID    `gorm:"column:id"`
+	SponsorName   string       `gorm:"column:sponsor_name"`
+	Ratio         float64      `gorm:"column:ratio"`
+	Cap           money.Amount `gorm:"column:cap"`
+	MatchedAmount money.Amount `gorm:"column:matched_amount"`
+	StartsAt      time.Time    `gorm:"column:starts_at"`
+	EndsAt        time.Time    `gorm:"column:ends_at"`
+}
+
 // CampaignModel represents the database table structure with GORM tags
 type CampaignModel struct {
 	ID               uuid.UUID                    `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
@@ -23,7 +53,8 @@ type CampaignModel struct {
 	Title            string                       `gorm:"column:title;not null"`
 	Description      string                       `gorm:"column:description;not null"`
 	Image            string                       `gorm:"column:image"`
-	Goal             float64                      `gorm:"column:goal;not null;check:goal > 0"`
+	Goal             money.Amount                 `gorm:"column:goal;not null;check:goal > 0"`
+	Currency         string                       `gorm:"column:currency;type:varchar(3);not null;default:ARS"`
 	StartDate        time.Time                    `gorm:"column:start_date;not null"`
 	EndDate          time.Time                    `gorm:"column:end_date;not null"`
 	Location         string                       `gorm:"column:location"`
@@ -36,6 +67,8 @@ type CampaignModel struct {
 	BeneficiaryAge   int                          `gorm:"column:beneficiary_age"`
 	CurrentSituation string                       `gorm:"column:current_situation"`
 	UrgencyReason    string                       `gorm:"column:urgency_reason"`
+	ArchivedAt       *time.Time                   `gorm:"column:archived_at"`
+	ArchivedBy       *uuid.UUID                   `gorm:"column:archived_by;type:uuid"`
 }
 
 // TableName specifies the table name for GORM
@@ -52,6 +85,7 @@ func (m CampaignModel) ToEntity() Campaign {
 		Description: m.Description,
 		Image:       m.Image,
 		Goal:        m.Goal,
+		Currency:    m.Currency,
 		StartDate:   m.StartDate,
 		EndDate:     m.EndDate,
 		Location:    m.Location,
@@ -60,6 +94,7 @@ func (m CampaignModel) ToEntity() Campaign {
 		Organizer:   nil, // Will be populated by repository/service if needed
 		OrganizerID: m.OrganizerID,
 		Status:      m.Status,
+		ArchivedAt:  m.ArchivedAt,
 	}
 
 	// Map beneficiary fields (handle empty strings as nil)
@@ -101,12 +136,14 @@ func (m *CampaignModel) FromEntity(entity Campaign) {
 	m.Description = entity.Description
 	m.Image = entity.Image
 	m.Goal = entity.Goal
+	m.Currency = entity.Currency
 	m.StartDate = entity.StartDate
 	m.EndDate = entity.EndDate
 	m.Location = entity.Location
 	m.Urgency = entity.Urgency
 	m.Status = entity.Status
 	m.CategoryID = entity.CategoryId
+	m.ArchivedAt = entity.ArchivedAt
 
 	// Extract organizer ID if organizer is provided
 	if entity.Organizer != nil {
@@ -127,3 +164,46 @@ func (m *CampaignModel) FromEntity(entity Campaign) {
 		m.UrgencyReason = *entity.UrgencyReason
 	}
 }
+
+// CampaignEditModel represents the campaign_edits table structure with GORM tags
+type CampaignEditModel struct {
+	ID             uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	CampaignID     uuid.UUID  `gorm:"column:campaign_id;type:uuid;not null;index"`
+	EditedBy       *uuid.UUID `gorm:"column:edited_by;type:uuid"`
+	CampaignStatus string     `gorm:"column:campaign_status;not null"`
+	Field          string     `gorm:"column:field;not null"`
+	OldValue       string     `gorm:"column:old_value"`
+	NewValue       string     `gorm:"column:new_value"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (CampaignEditModel) TableName() string {
+	return "campaign_edits"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m CampaignEditModel) ToEntity() CampaignEdit {
+	return CampaignEdit{
+		ID:             m.ID,
+		CampaignID:     m.CampaignID,
+		EditedBy:       m.EditedBy,
+		CampaignStatus: m.CampaignStatus,
+		Field:          m.Field,
+		OldValue:       m.OldValue,
+		NewValue:       m.NewValue,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *CampaignEditModel) FromEntity(entity CampaignEdit) {
+	m.ID = entity.ID
+	m.CampaignID = entity.CampaignID
+	m.EditedBy = entity.EditedBy
+	m.CampaignStatus = entity.CampaignStatus
+	m.Field = entity.Field
+	m.OldValue = entity.OldValue
+	m.NewValue = entity.NewValue
+	m.CreatedAt = entity.CreatedAt
+}
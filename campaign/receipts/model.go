@@ -3,23 +3,25 @@ package receipts
 import (
 	"time"
 
+	"dona_tutti_api/money"
+
 	"github.com/google/uuid"
 )
 
 // ReceiptModel represents the database table structure with GORM tags
 type ReceiptModel struct {
-	ID          uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
-	CampaignID  uuid.UUID  `gorm:"column:campaign_id;type:uuid;not null;index"`
-	Provider    string     `gorm:"column:provider;type:varchar(255);not null"`
-	Name        string     `gorm:"column:name;type:varchar(255);not null"`
-	Description string     `gorm:"column:description;type:text"`
-	Total       float64    `gorm:"column:total;type:decimal(12,2);not null"`
-	Quantity    int        `gorm:"column:quantity;type:integer;default:1"`
-	Date        time.Time  `gorm:"column:date;not null;index"`
-	DocumentURL *string    `gorm:"column:document_url;type:varchar(500)"`
-	Note        *string    `gorm:"column:note;type:text"`
-	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+	ID          uuid.UUID    `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	CampaignID  uuid.UUID    `gorm:"column:campaign_id;type:uuid;not null;index"`
+	Provider    string       `gorm:"column:provider;type:varchar(255);not null"`
+	Name        string       `gorm:"column:name;type:varchar(255);not null"`
+	Description string       `gorm:"column:description;type:text"`
+	Total       money.Amount `gorm:"column:total;type:decimal(12,2);not null"`
+	Quantity    int          `gorm:"column:quantity;type:integer;default:1"`
+	Date        time.Time    `gorm:"column:date;not null;index"`
+	DocumentURL *string      `gorm:"column:document_url;type:varchar(500)"`
+	Note        *string      `gorm:"column:note;type:text"`
+	CreatedAt   time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time    `gorm:"column:updated_at;autoUpdateTime"`
 }
 
 // TableName specifies the table name for GORM
@@ -2,12 +2,20 @@ package campaign
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"time"
+
+	"dona_tutti_api/money"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// ErrCampaignNotFound is returned when a campaign does not exist or was archived
+var ErrCampaignNotFound = errors.New("campaign not found")
+
 type campaignRepository struct {
 	db *gorm.DB
 }
@@ -19,13 +27,26 @@ func NewCampaignRepository(db *gorm.DB) *campaignRepository {
 }
 
 func (r *campaignRepository) GetCampaign(ctx context.Context, id uuid.UUID) (Campaign, error) {
+	return r.getCampaign(ctx, id, false)
+}
+
+// GetCampaignIncludingArchived returns a campaign even if it has been archived
+func (r *campaignRepository) GetCampaignIncludingArchived(ctx context.Context, id uuid.UUID) (Campaign, error) {
+	return r.getCampaign(ctx, id, true)
+}
+
+func (r *campaignRepository) getCampaign(ctx context.Context, id uuid.UUID, includeArchived bool) (Campaign, error) {
 	var campaignModel CampaignModel
 
 	// Get the campaign model
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&campaignModel).Error
+	query := r.db.WithContext(ctx).Where("id = ?", id)
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	err := query.First(&campaignModel).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return Campaign{}, fmt.Errorf("campaign with id %s not found", id.String())
+			return Campaign{}, fmt.Errorf("%w: %s", ErrCampaignNotFound, id.String())
 		}
 		return Campaign{}, fmt.Errorf("failed to get campaign: %w", err)
 	}
@@ -42,31 +63,259 @@ func (r *campaignRepository) GetCampaign(ctx context.Context, id uuid.UUID) (Cam
 	}
 
 	campaignModel.PaymentMethods = paymentMethods
-	return campaignModel.ToEntity(), nil
+	campaigns := []Campaign{campaignModel.ToEntity()}
+	if err := r.attachDonationStats(ctx, campaigns); err != nil {
+		return Campaign{}, err
+	}
+
+	matchingPledges, err := r.getMatchingPledges(ctx, id)
+	if err != nil {
+		fmt.Printf("Warning: failed to get matching pledges for campaign %s: %v\n", id.String(), err)
+	}
+	campaigns[0].MatchingPledges = matchingPledges
+
+	return campaigns[0], nil
 }
 
-func (r *campaignRepository) ListCampaigns(ctx context.Context) ([]Campaign, error) {
-	type Campaigns struct {
-		CampaignModel
+// getMatchingPledges returns the pledges of a campaign that were not cancelled, with what is left of their caps
+func (r *campaignRepository) getMatchingPledges(ctx context.Context, campaignID uuid.UUID) ([]MatchingPledge, error) {
+	var models []MatchingPledgeModel
+	err := r.db.WithContext(ctx).
+		Table("matching_pledges mp").
+		Select("mp.id, o.name AS sponsor_name, mp.ratio, mp.cap, mp.matched_amount, mp.starts_at, mp.ends_at").
+		Joins("JOIN organizers o ON o.id = mp.sponsor_organizer_id").
+		Where("mp.campaign_id = ? AND mp.status = 'active'", campaignID).
+		Order("mp.starts_at ASC").
+		Scan(&models).Error
+	if err != nil {
+		return nil, err
 	}
 
-	var campaignModels []Campaigns
+	now := time.Now()
+	pledges := make([]MatchingPledge, len(models))
+	for i, model := range models {
+		pledges[i] = MatchingPledge{
+			ID:              model.ID,
+			SponsorName:     model.SponsorName,
+			Ratio:           model.Ratio,
+			Cap:             model.Cap,
+			MatchedAmount:   model.MatchedAmount,
+			RemainingAmount: model.Cap - model.MatchedAmount,
+			StartsAt:        model.StartsAt,
+			EndsAt:          model.EndsAt,
+			IsOpen:          !now.Before(model.StartsAt) && now.Before(model.EndsAt) && model.MatchedAmount < model.Cap,
+		}
+	}
+	return pledges, nil
+}
 
+// attachDonationStats loads the completed donation totals of all given campaigns in a single query
+func (r *campaignRepository) attachDonationStats(ctx context.Context, campaigns []Campaign) error {
+	if len(campaigns) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(campaigns))
+	for i, campaign := range campaigns {
+		ids[i] = campaign.ID
+	}
+
+	var stats []DonationStatsModel
 	err := r.db.WithContext(ctx).
+		Table("donations").
+		Select(`campaign_id,
+			COALESCE(SUM(amount - refunded_amount), 0) AS raised_amount,
+			COALESCE(SUM(amount - refunded_amount) FILTER (WHERE matching_pledge_id IS NOT NULL), 0) AS matched_amount,
+			COUNT(DISTINCT donor_id) FILTER (WHERE matching_pledge_id IS NULL) AS donor_count,
+			COUNT(*) FILTER (WHERE matching_pledge_id IS NULL) AS donation_count`).
+		Where("campaign_id IN ? AND status = 'completed'", ids).
+		Group("campaign_id").
+		Scan(&stats).Error
+	if err != nil {
+		return fmt.Errorf("failed to get campaign donation stats: %w", err)
+	}
+
+	statsByCampaign := make(map[uuid.UUID]DonationStatsModel, len(stats))
+	for _, stat := range stats {
+		statsByCampaign[stat.CampaignID] = stat
+	}
+
+	for i := range campaigns {
+		stat := statsByCampaign[campaigns[i].ID]
+		campaigns[i].DonationStats = DonationStats{
+			RaisedAmount:  stat.RaisedAmount,
+			MatchedAmount: stat.MatchedAmount,
+			DonorCount:    stat.DonorCount,
+			DonationCount: stat.DonationCount,
+		}
+		if campaigns[i].Goal > 0 {
+			progress := stat.RaisedAmount.Ratio(campaigns[i].Goal) * 100
+			campaigns[i].ProgressPercentage = math.Round(progress*100) / 100
+		}
+	}
+
+	return nil
+}
+
+// ListCampaigns returns a filtered, sorted page of non-archived campaigns along with the total count
+func (r *campaignRepository) ListCampaigns(ctx context.Context, filter CampaignListFilter) ([]Campaign, int64, error) {
+	query := r.db.WithContext(ctx).
 		Table("campaigns").
-		Select(`campaigns.*`).
-		Order("campaigns.created_at DESC").
-		Scan(&campaignModels).Error
+		Where("campaigns.archived_at IS NULL")
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("campaigns.status IN ?", filter.Statuses)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("campaigns.category_id = ?", *filter.CategoryID)
+	}
+	if filter.OrganizerID != nil {
+		query = query.Where("campaigns.organizer_id = ?", *filter.OrganizerID)
+	}
+	if filter.Location != "" {
+		query = query.Where("campaigns.location ILIKE ?", "%"+filter.Location+"%")
+	}
+	if filter.UrgencyMin != nil {
+		query = query.Where("campaigns.urgency >= ?", *filter.UrgencyMin)
+	}
+	if filter.UrgencyMax != nil {
+		query = query.Where("campaigns.urgency <= ?", *filter.UrgencyMax)
+	}
+	// Date range matches campaigns whose running period overlaps the range
+	if filter.DateFrom != nil {
+		query = query.Where("campaigns.end_date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("campaigns.start_date <= ?", *filter.DateTo)
+	}
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		query = query.Where("(campaigns.title ILIKE ? OR campaigns.description ILIKE ? OR campaigns.location ILIKE ?)", like, like, like)
+	}
 
+	// Count before applying sorting and pagination
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count campaigns: %w", err)
+	}
+
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	switch filter.SortBy {
+	case SortByRaised:
+		query = query.
+			Joins(`LEFT JOIN (
+				SELECT campaign_id, SUM(amount - refunded_amount) AS raised
+				FROM donations
+				WHERE status = 'completed'
+				GROUP BY campaign_id
+			) raised ON raised.campaign_id = campaigns.id`).
+			Order("COALESCE(raised.raised, 0) " + direction)
+	case SortByUrgency, SortByEndDate, SortByGoal:
+		query = query.Order("campaigns." + filter.SortBy + " " + direction)
+	default:
+		query = query.Order("campaigns.created_at " + direction)
+	}
+
+	var campaignModels []CampaignModel
+	err := query.
+		Select("campaigns.*").
+		Order("campaigns.id").
+		Limit(filter.PageSize).
+		Offset((filter.Page - 1) * filter.PageSize).
+		Scan(&campaignModels).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+		return nil, 0, fmt.Errorf("failed to list campaigns: %w", err)
 	}
 
 	// Convert to domain entities
 	campaigns := make([]Campaign, len(campaignModels))
 	for i, model := range campaignModels {
+		campaigns[i] = model.ToEntity()
+	}
+
+	if err := r.attachDonationStats(ctx, campaigns); err != nil {
+		return nil, 0, err
+	}
+
+	return campaigns, total, nil
+}
+
+// SearchCampaigns runs an accent-insensitive Spanish full-text search over visible campaigns,
+// ordered by relevance
+func (r *campaignRepository) SearchCampaigns(ctx context.Context, query string, statuses []string, page, pageSize int) ([]CampaignSearchResult, int64, error) {
+	const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+
+	base := r.db.WithContext(ctx).
+		Table("campaigns, websearch_to_tsquery('es_unaccent', ?) AS query", query).
+		Where("campaigns.archived_at IS NULL").
+		Where("campaigns.status IN ?", statuses).
+		Where("campaigns.search_vector @@ query")
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	var models []CampaignSearchModel
+	err := base.
+		Select(`campaigns.*,
+			ts_rank(campaigns.search_vector, query) AS rank,
+			ts_headline('es_unaccent', campaigns.title, query, ?) AS title_highlight,
+			ts_headline('es_unaccent', COALESCE(campaigns.description, '') || ' ' || COALESCE(campaigns.current_situation, ''), query, ?) AS snippet`,
+			headlineOptions, headlineOptions).
+		Order("rank DESC").
+		Order("campaigns.created_at DESC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Scan(&models).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search campaigns: %w", err)
+	}
+
+	campaigns := make([]Campaign, len(models))
+	for i, model := range models {
 		campaigns[i] = model.CampaignModel.ToEntity()
 	}
+	if err := r.attachDonationStats(ctx, campaigns); err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]CampaignSearchResult, len(models))
+	for i, model := range models {
+		results[i] = CampaignSearchResult{
+			Campaign:       campaigns[i],
+			Rank:           model.Rank,
+			TitleHighlight: model.TitleHighlight,
+			Snippet:        model.Snippet,
+		}
+	}
+
+	return results, total, nil
+}
+
+func (r *campaignRepository) ListArchivedCampaigns(ctx context.Context) ([]Campaign, error) {
+	var campaignModels []CampaignModel
+
+	err := r.db.WithContext(ctx).
+		Where("archived_at IS NOT NULL").
+		Order("archived_at DESC").
+		Find(&campaignModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived campaigns: %w", err)
+	}
+
+	campaigns := make([]Campaign, len(campaignModels))
+	for i, model := range campaignModels {
+		campaigns[i] = model.ToEntity()
+	}
+
+	if err := r.attachDonationStats(ctx, campaigns); err != nil {
+		return nil, err
+	}
 
 	return campaigns, nil
 }
@@ -92,28 +341,325 @@ func (r *campaignRepository) UpdateCampaignImage(ctx context.Context, id uuid.UU
 		Update("image", imageURL).Error
 }
 
-func (r *campaignRepository) UpdateStatus(ctx context.Context, campaignID uuid.UUID, status string) error {
+// UpdateCampaign saves the editable campaign fields and records the edit history in a single transaction.
+// When paymentMethods is not nil, the campaign payment methods are synced in the same transaction.
+func (r *campaignRepository) UpdateCampaign(ctx context.Context, campaign Campaign, edits []CampaignEdit, paymentMethods *[]CampaignPaymentMethod) error {
+	var campaignModel CampaignModel
+	campaignModel.FromEntity(campaign)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&CampaignModel{}).
+			Where("id = ?", campaign.ID).
+			Select("title", "description", "goal", "currency", "start_date", "end_date", "location", "urgency",
+				"category_id", "beneficiary_name", "beneficiary_age", "current_situation", "urgency_reason", "updated_at").
+			Updates(&campaignModel).Error
+		if err != nil {
+			return fmt.Errorf("failed to update campaign: %w", err)
+		}
+
+		if paymentMethods != nil {
+			if err := syncPaymentMethods(tx, campaign.ID, *paymentMethods); err != nil {
+				return err
+			}
+		}
+
+		if len(edits) == 0 {
+			return nil
+		}
+
+		editModels := make([]CampaignEditModel, len(edits))
+		for i, edit := range edits {
+			editModels[i].FromEntity(edit)
+		}
+		if err := tx.Create(&editModels).Error; err != nil {
+			return fmt.Errorf("failed to record campaign edits: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// syncPaymentMethods makes the active payment methods of a campaign match the provided ones. Rows of
+// methods that stay keep their ID, transfer details and cash locations; removed methods are deactivated
+// rather than deleted, since bank statements and cash batches may reference them, and are reactivated
+// if added again.
+func syncPaymentMethods(tx *gorm.DB, campaignID uuid.UUID, paymentMethods []CampaignPaymentMethod) error {
+	var existing []struct {
+		ID              int
+		PaymentMethodID int
+	}
+	err := tx.Table("campaign_payment_methods").
+		Select("id, payment_method_id").
+		Where("campaign_id = ?", campaignID).
+		Scan(&existing).Error
+	if err != nil {
+		return fmt.Errorf("failed to get campaign payment methods: %w", err)
+	}
+
+	existingIDs := make(map[int]int, len(existing))
+	for _, row := range existing {
+		existingIDs[row.PaymentMethodID] = row.ID
+	}
+
+	kept := make(map[int]bool, len(paymentMethods))
+	for _, paymentMethod := range paymentMethods {
+		kept[paymentMethod.PaymentMethodID] = true
+
+		if id, ok := existingIDs[paymentMethod.PaymentMethodID]; ok {
+			err := tx.Table("campaign_payment_methods").
+				Where("id = ?", id).
+				Updates(map[string]interface{}{
+					"instructions": paymentMethod.Instructions,
+					"is_active":    true,
+					"updated_at":   time.Now(),
+				}).Error
+			if err != nil {
+				return fmt.Errorf("failed to update campaign payment method: %w", err)
+			}
+			continue
+		}
+
+		err := tx.Exec(`
+			INSERT INTO campaign_payment_methods (campaign_id, payment_method_id, instructions, is_active, created_at, updated_at)
+			VALUES (?, ?, ?, true, NOW(), NOW())
+		`, campaignID, paymentMethod.PaymentMethodID, paymentMethod.Instructions).Error
+		if err != nil {
+			return fmt.Errorf("failed to create campaign payment method: %w", err)
+		}
+	}
+
+	for _, row := range existing {
+		if kept[row.PaymentMethodID] {
+			continue
+		}
+		err := tx.Table("campaign_payment_methods").
+			Where("id = ? AND is_active = ?", row.ID, true).
+			Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
+		if err != nil {
+			return fmt.Errorf("failed to deactivate campaign payment method: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetActivationGoal returns the goal a campaign had when it left draft, taken from its first goal
+// edit after that. It returns nil when the goal was never edited since, so the current goal is it.
+func (r *campaignRepository) GetActivationGoal(ctx context.Context, campaignID uuid.UUID) (*money.Amount, error) {
+	var oldValues []string
+	err := r.db.WithContext(ctx).
+		Model(&CampaignEditModel{}).
+		Where("campaign_id = ? AND field = ? AND campaign_status <> ?", campaignID, "goal", StatusDraft).
+		Order("created_at ASC").
+		Limit(1).
+		Pluck("old_value", &oldValues).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal edits: %w", err)
+	}
+	if len(oldValues) == 0 {
+		return nil, nil
+	}
+
+	goal, err := money.Parse(oldValues[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid goal in campaign edits: %w", err)
+	}
+	return &goal, nil
+}
+
+func (r *campaignRepository) ListCampaignEdits(ctx context.Context, campaignID uuid.UUID) ([]CampaignEdit, error) {
+	var models []CampaignEditModel
+	err := r.db.WithContext(ctx).
+		Where("campaign_id = ?", campaignID).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaign edits: %w", err)
+	}
+
+	edits := make([]CampaignEdit, len(models))
+	for i, model := range models {
+		edits[i] = model.ToEntity()
+	}
+
+	return edits, nil
+}
+
+func (r *campaignRepository) ArchiveCampaign(ctx context.Context, id uuid.UUID, archivedBy *uuid.UUID) error {
 	return r.db.WithContext(ctx).Model(&CampaignModel{}).
-		Where("id = ?", campaignID).
-		Update("status", status).Error
+		Where("id = ? AND archived_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"archived_at": time.Now(),
+			"archived_by": archivedBy,
+		}).Error
 }
 
-func (r *campaignRepository) GetSummary(ctx context.Context) (Summary, error) {
-	var summary Summary
+func (r *campaignRepository) RestoreCampaign(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&CampaignModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"archived_at": nil,
+			"archived_by": nil,
+		}).Error
+}
+
+// DeleteCampaign permanently removes a campaign; related rows are removed by ON DELETE CASCADE
+func (r *campaignRepository) DeleteCampaign(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&CampaignModel{}).Error; err != nil {
+		return fmt.Errorf("failed to delete campaign: %w", err)
+	}
+	return nil
+}
 
+func (r *campaignRepository) CountDonations(ctx context.Context, campaignID uuid.UUID) (int64, error) {
+	var count int64
 	err := r.db.WithContext(ctx).
-		Raw(`
-                       SELECT
-                               COUNT(DISTINCT c.id) AS total_campaigns,
-                               COALESCE(SUM(c.goal), 0) AS total_goal,
-                               COUNT(DISTINCT d.donor_id) AS total_contributors
-                       FROM campaigns c
-                       LEFT JOIN donations d ON c.id = d.campaign_id
-               `).
-		Scan(&summary).Error
-
-	if err != nil {
-		return Summary{}, fmt.Errorf(" to get summary: %w", err)
+		Table("donations").
+		Where("campaign_id = ?", campaignID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count campaign donations: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateStatus changes the status of a campaign. Completing a campaign ends its recurring donations
+// in the same transaction, so that none is charged after the campaign finished.
+func (r *campaignRepository) UpdateStatus(ctx context.Context, campaignID uuid.UUID, status string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&CampaignModel{}).
+			Where("id = ?", campaignID).
+			Update("status", status).Error
+		if err != nil || status != StatusCompleted {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE donation_subscriptions
+			SET status = 'ended', ended_at = NOW(), ended_reason = 'campaign_completed', updated_at = NOW()
+			WHERE campaign_id = ? AND status IN ('active', 'paused')
+		`, campaignID).Error
+	})
+}
+
+// GetSummary computes the platform statistics. Campaign figures and donation figures are
+// aggregated in separate queries so the goal is not multiplied by the number of donations.
+func (r *campaignRepository) GetSummary(ctx context.Context, filter SummaryFilter) (Summary, error) {
+	summary := Summary{Currency: filter.Currency, CampaignsByStatus: make(map[string]int64)}
+
+	// Campaigns scope: created within the date range and in the category
+	campaignScope := func() *gorm.DB {
+		query := r.db.WithContext(ctx).Table("campaigns c").
+			Where("c.archived_at IS NULL AND c.currency = ?", filter.Currency)
+		if filter.CategoryID != nil {
+			query = query.Where("c.category_id = ?", *filter.CategoryID)
+		}
+		if filter.DateFrom != nil {
+			query = query.Where("c.created_at >= ?", *filter.DateFrom)
+		}
+		if filter.DateTo != nil {
+			query = query.Where("c.created_at <= ?", *filter.DateTo)
+		}
+		return query
+	}
+
+	// Completed donations scope: made within the date range to campaigns in the category
+	donationScope := func() *gorm.DB {
+		query := r.db.WithContext(ctx).
+			Table("donations d").
+			Joins("JOIN campaigns c ON c.id = d.campaign_id").
+			Where("d.status = 'completed' AND c.archived_at IS NULL AND c.currency = ?", filter.Currency)
+		if filter.CategoryID != nil {
+			query = query.Where("c.category_id = ?", *filter.CategoryID)
+		}
+		if filter.DateFrom != nil {
+			query = query.Where("d.date >= ?", *filter.DateFrom)
+		}
+		if filter.DateTo != nil {
+			query = query.Where("d.date <= ?", *filter.DateTo)
+		}
+		return query
+	}
+
+	// Campaign totals
+	var campaignTotals struct {
+		TotalCampaigns int64
+		TotalGoal      money.Amount
+	}
+	err := campaignScope().
+		Select("COUNT(*) AS total_campaigns, COALESCE(SUM(c.goal), 0) AS total_goal").
+		Scan(&campaignTotals).Error
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to get campaign totals: %w", err)
+	}
+	summary.TotalCampaigns = campaignTotals.TotalCampaigns
+	summary.TotalGoal = campaignTotals.TotalGoal
+
+	// Campaigns by status
+	var statusCounts []struct {
+		Status string
+		Count  int64
+	}
+	err = campaignScope().
+		Select("c.status, COUNT(*) AS count").
+		Group("c.status").
+		Scan(&statusCounts).Error
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to get campaigns by status: %w", err)
+	}
+	for _, status := range ValidStatuses() {
+		summary.CampaignsByStatus[status] = 0
+	}
+	for _, statusCount := range statusCounts {
+		summary.CampaignsByStatus[statusCount.Status] = statusCount.Count
+	}
+
+	// Organizers with at least one active campaign
+	err = campaignScope().
+		Where("c.status = ?", StatusActive).
+		Select("COUNT(DISTINCT c.organizer_id)").
+		Scan(&summary.ActiveOrganizers).Error
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to get active organizers: %w", err)
+	}
+
+	// Donation totals. Contributors are the distinct known donors: anonymous donations without
+	// donor are not counted.
+	var donationTotals struct {
+		TotalRaised       money.Amount
+		TotalDonations    int64
+		AverageDonation   money.Amount // AVG is rounded to the cent on scan
+		TotalContributors int64
+	}
+	err = donationScope().
+		Select(`COALESCE(SUM(d.amount - d.refunded_amount), 0) AS total_raised,
+			COUNT(*) AS total_donations,
+			COALESCE(AVG(d.amount - d.refunded_amount), 0) AS average_donation,
+			COUNT(DISTINCT d.donor_id) AS total_contributors`).
+		Scan(&donationTotals).Error
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to get donation totals: %w", err)
+	}
+	summary.TotalRaised = donationTotals.TotalRaised
+	summary.TotalDonations = donationTotals.TotalDonations
+	summary.AverageDonation = donationTotals.AverageDonation
+	summary.TotalContributors = donationTotals.TotalContributors
+
+	// Donors in the dashboard scope whose first completed donation happened in the last 30 days.
+	// The first donation is taken over all of their donations, not only the filtered ones.
+	since := time.Now().AddDate(0, 0, -NewDonorsWindowDays)
+	firstDonations := r.db.WithContext(ctx).
+		Table("donations").
+		Select("donor_id, MIN(date) AS first_donation_date").
+		Where("status = 'completed' AND donor_id IS NOT NULL").
+		Group("donor_id")
+	err = donationScope().
+		Joins("JOIN (?) AS first_donations ON first_donations.donor_id = d.donor_id", firstDonations).
+		Where("first_donations.first_donation_date >= ?", since).
+		Select("COUNT(DISTINCT d.donor_id)").
+		Scan(&summary.NewDonorsLast30Days).Error
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to get new donors: %w", err)
 	}
 
 	return summary, nil
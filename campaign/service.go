@@ -2,10 +2,16 @@ package campaign
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"dona_tutti_api/currency"
 	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/money"
 	"dona_tutti_api/organizer"
 	"dona_tutti_api/paymentmethod"
 
@@ -16,33 +22,53 @@ import (
 type CampaignInfo struct {
 	ID          uuid.UUID
 	Title       string
-	Goal        float64
+	Goal        money.Amount
+	Currency    string
 	OrganizerID uuid.UUID
 	Status      string
 }
 
 type Service interface {
 	GetCampaign(ctx context.Context, id uuid.UUID) (Campaign, error)
-	ListCampaigns(ctx context.Context) ([]Campaign, error)
+	ListCampaigns(ctx context.Context, filter CampaignListFilter) (CampaignListResult, error)
+	SearchCampaigns(ctx context.Context, query string, page, pageSize int) (CampaignSearchResponse, error)
 	CreateCampaign(ctx context.Context, campaign Campaign) (uuid.UUID, error)
+	UpdateCampaign(ctx context.Context, id uuid.UUID, updateReq CampaignUpdateRequest, editedBy *uuid.UUID) error
+	ListCampaignEdits(ctx context.Context, campaignID uuid.UUID) ([]CampaignEdit, error)
+	ListArchivedCampaigns(ctx context.Context) ([]Campaign, error)
+	ArchiveCampaign(ctx context.Context, id uuid.UUID, archivedBy *uuid.UUID) error
+	RestoreCampaign(ctx context.Context, id uuid.UUID) error
+	DeleteCampaign(ctx context.Context, id uuid.UUID) error
 	UpdateCampaignImage(ctx context.Context, id uuid.UUID, imageURL string) error
 	UpdateStatus(ctx context.Context, campaignID uuid.UUID, status string) error
 	GetCampaignTitle(ctx context.Context, campaignID uuid.UUID) (string, error)
 	GetCampaignInfo(ctx context.Context, campaignID uuid.UUID) (CampaignInfo, error)
 	GetCampaignStatus(ctx context.Context, campaignID uuid.UUID) (string, error)
-	GetSummary(ctx context.Context) (Summary, error)
+	GetCampaignCurrency(ctx context.Context, campaignID uuid.UUID) (string, error)
+	GetSummary(ctx context.Context, filter SummaryFilter) (Summary, error)
 }
 
 type CampaignRepository interface {
 	GetCampaign(ctx context.Context, id uuid.UUID) (Campaign, error)
-	ListCampaigns(ctx context.Context) ([]Campaign, error)
+	GetCampaignIncludingArchived(ctx context.Context, id uuid.UUID) (Campaign, error)
+	ListCampaigns(ctx context.Context, filter CampaignListFilter) ([]Campaign, int64, error)
+	SearchCampaigns(ctx context.Context, query string, statuses []string, page, pageSize int) ([]CampaignSearchResult, int64, error)
+	ListArchivedCampaigns(ctx context.Context) ([]Campaign, error)
+	ArchiveCampaign(ctx context.Context, id uuid.UUID, archivedBy *uuid.UUID) error
+	RestoreCampaign(ctx context.Context, id uuid.UUID) error
+	DeleteCampaign(ctx context.Context, id uuid.UUID) error
+	CountDonations(ctx context.Context, campaignID uuid.UUID) (int64, error)
 	CreateCampaign(ctx context.Context, campaign Campaign) error
+	UpdateCampaign(ctx context.Context, campaign Campaign, edits []CampaignEdit, paymentMethods *[]CampaignPaymentMethod) error
+	ListCampaignEdits(ctx context.Context, campaignID uuid.UUID) ([]CampaignEdit, error)
+	GetActivationGoal(ctx context.Context, campaignID uuid.UUID) (*money.Amount, error)
 	UpdateCampaignImage(ctx context.Context, id uuid.UUID, imageURL string) error
 	UpdateStatus(ctx context.Context, campaignID uuid.UUID, status string) error
-	GetSummary(ctx context.Context) (Summary, error)
+	GetSummary(ctx context.Context, filter SummaryFilter) (Summary, error)
 }
 
 type PaymentMethodService interface {
+	GetPaymentMethod(ctx context.Context, id int) (paymentmethod.PaymentMethod, error)
 	CreateCampaignPaymentMethod(ctx context.Context, req paymentmethod.CreateCampaignPaymentMethodRequest) (int, error)
 }
 
@@ -65,8 +91,87 @@ func (s *service) GetCampaign(ctx context.Context, id uuid.UUID) (Campaign, erro
 	return s.repo.GetCampaign(ctx, id)
 }
 
-func (s *service) ListCampaigns(ctx context.Context) ([]Campaign, error) {
-	return s.repo.ListCampaigns(ctx)
+func (s *service) ListCampaigns(ctx context.Context, filter CampaignListFilter) (CampaignListResult, error) {
+	// Validate filters
+	for _, status := range filter.Statuses {
+		if !IsValidStatus(status) {
+			return CampaignListResult{}, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid status: %s", status))
+		}
+		if !filter.IncludeHidden && !isPublicListStatus(status) {
+			return CampaignListResult{}, apierrors.NewFieldValidationError("status", fmt.Sprintf("campaigns with status %s are not listed publicly", status))
+		}
+	}
+	if filter.UrgencyMin != nil && filter.UrgencyMax != nil && *filter.UrgencyMin > *filter.UrgencyMax {
+		return CampaignListResult{}, apierrors.NewFieldValidationError("urgency_min", "urgency_min cannot be greater than urgency_max")
+	}
+	if filter.DateFrom != nil && filter.DateTo != nil && filter.DateFrom.After(*filter.DateTo) {
+		return CampaignListResult{}, apierrors.NewFieldValidationError("date_from", "date_from cannot be after date_to")
+	}
+	switch filter.SortBy {
+	case "", SortByCreatedAt, SortByUrgency, SortByEndDate, SortByGoal, SortByRaised:
+	default:
+		return CampaignListResult{}, apierrors.NewFieldValidationError("sort", fmt.Sprintf("invalid sort field: %s", filter.SortBy))
+	}
+
+	// Apply defaults
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = PublicListStatuses()
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = DefaultPageSize
+	}
+	if filter.PageSize > MaxPageSize {
+		filter.PageSize = MaxPageSize
+	}
+
+	campaigns, total, err := s.repo.ListCampaigns(ctx, filter)
+	if err != nil {
+		return CampaignListResult{}, err
+	}
+
+	totalPages := int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize))
+
+	return CampaignListResult{
+		Data:       campaigns,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *service) SearchCampaigns(ctx context.Context, query string, page, pageSize int) (CampaignSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return CampaignSearchResponse{}, apierrors.NewFieldValidationError("q", "search query is required")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	results, total, err := s.repo.SearchCampaigns(ctx, query, PublicListStatuses(), page, pageSize)
+	if err != nil {
+		return CampaignSearchResponse{}, err
+	}
+
+	return CampaignSearchResponse{
+		Data:       results,
+		Query:      query,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
 }
 
 func (s *service) CreateCampaign(ctx context.Context, campaign Campaign) (uuid.UUID, error) {
@@ -94,6 +199,10 @@ func (s *service) CreateCampaign(ctx context.Context, campaign Campaign) (uuid.U
 	if campaign.Goal <= 0 {
 		return uuid.Nil, apierrors.NewFieldValidationError("goal", "campaign goal must be greater than 0")
 	}
+	campaign.Currency = currency.Normalize(campaign.Currency)
+	if !currency.IsSupported(campaign.Currency) {
+		return uuid.Nil, apierrors.NewFieldValidationError("currency", fmt.Sprintf("unsupported currency: %s", campaign.Currency))
+	}
 	if campaign.Urgency < 1 || campaign.Urgency > 10 {
 		return uuid.Nil, apierrors.NewFieldValidationError("urgency", "campaign urgency must be between 1 and 10")
 	}
@@ -159,6 +268,300 @@ func (s *service) CreateCampaign(ctx context.Context, campaign Campaign) (uuid.U
 	return campaign.ID, nil
 }
 
+func (s *service) UpdateCampaign(ctx context.Context, id uuid.UUID, updateReq CampaignUpdateRequest, editedBy *uuid.UUID) error {
+	// Get existing campaign
+	existing, err := s.repo.GetCampaign(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrCampaignNotFound) {
+			return apierrors.NewNotFoundError("campaign not found")
+		}
+		return fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	if !IsEditableStatus(existing.Status) {
+		return apierrors.NewFieldValidationError("status",
+			fmt.Sprintf("campaigns in %s status cannot be edited", existing.Status))
+	}
+
+	// Apply partial updates - only update fields that are provided
+	updated := existing
+	var edits []CampaignEdit
+
+	// recordEdit validates that the field is editable in the current status and tracks the change
+	recordEdit := func(field, oldValue, newValue string) error {
+		if oldValue == newValue {
+			return nil
+		}
+		if !CanEditField(existing.Status, field) {
+			return apierrors.NewFieldValidationError(field,
+				fmt.Sprintf("%s cannot be changed while the campaign is %s", field, existing.Status))
+		}
+		edits = append(edits, CampaignEdit{
+			ID:             uuid.New(),
+			CampaignID:     id,
+			EditedBy:       editedBy,
+			CampaignStatus: existing.Status,
+			Field:          field,
+			OldValue:       oldValue,
+			NewValue:       newValue,
+			CreatedAt:      time.Now(),
+		})
+		return nil
+	}
+
+	if updateReq.Title != nil {
+		if *updateReq.Title == "" {
+			return apierrors.NewFieldValidationError("title", "campaign title cannot be empty")
+		}
+		if err := recordEdit("title", existing.Title, *updateReq.Title); err != nil {
+			return err
+		}
+		updated.Title = *updateReq.Title
+	}
+
+	if updateReq.Description != nil {
+		if *updateReq.Description == "" {
+			return apierrors.NewFieldValidationError("description", "campaign description cannot be empty")
+		}
+		if err := recordEdit("description", existing.Description, *updateReq.Description); err != nil {
+			return err
+		}
+		updated.Description = *updateReq.Description
+	}
+
+	if updateReq.Goal != nil {
+		if *updateReq.Goal <= 0 {
+			return apierrors.NewFieldValidationError("goal", "campaign goal must be greater than 0")
+		}
+		if existing.Status != StatusDraft && *updateReq.Goal != existing.Goal {
+			// Active campaigns cannot lower their goal, and increases are capped from the goal they
+			// were activated with, so repeated edits cannot raise it without limit
+			if *updateReq.Goal < existing.Goal {
+				return apierrors.NewFieldValidationError("goal", "the goal of an active campaign cannot be decreased")
+			}
+			activationGoal, err := s.repo.GetActivationGoal(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get activation goal: %w", err)
+			}
+			if activationGoal == nil {
+				activationGoal = &existing.Goal
+			}
+			maxGoal := activationGoal.MulRate(1 + MaxActiveGoalIncrease)
+			if *updateReq.Goal > maxGoal {
+				return apierrors.NewFieldValidationError("goal",
+					fmt.Sprintf("the goal of an active campaign can be increased up to %s", maxGoal))
+			}
+		}
+		if err := recordEdit("goal", existing.Goal.String(), updateReq.Goal.String()); err != nil {
+			return err
+		}
+		updated.Goal = *updateReq.Goal
+	}
+
+	// Only drafts can change currency: they have no donations yet
+	if updateReq.Currency != nil {
+		code := currency.Normalize(*updateReq.Currency)
+		if !currency.IsSupported(code) {
+			return apierrors.NewFieldValidationError("currency", fmt.Sprintf("unsupported currency: %s", code))
+		}
+		if err := recordEdit("currency", existing.Currency, code); err != nil {
+			return err
+		}
+		updated.Currency = code
+	}
+
+	if updateReq.StartDate != nil {
+		if updateReq.StartDate.IsZero() {
+			return apierrors.NewFieldValidationError("start_date", "campaign start date cannot be empty")
+		}
+		if err := recordEdit("start_date", formatDate(existing.StartDate), formatDate(*updateReq.StartDate)); err != nil {
+			return err
+		}
+		updated.StartDate = *updateReq.StartDate
+	}
+
+	if updateReq.EndDate != nil {
+		if updateReq.EndDate.IsZero() {
+			return apierrors.NewFieldValidationError("end_date", "campaign end date cannot be empty")
+		}
+		if existing.Status != StatusDraft && updateReq.EndDate.Before(time.Now()) {
+			return apierrors.NewFieldValidationError("end_date", "the end date of an active campaign must be in the future")
+		}
+		if err := recordEdit("end_date", formatDate(existing.EndDate), formatDate(*updateReq.EndDate)); err != nil {
+			return err
+		}
+		updated.EndDate = *updateReq.EndDate
+	}
+
+	if !updated.EndDate.After(updated.StartDate) {
+		return apierrors.NewFieldValidationError("end_date", "campaign end date must be after start date")
+	}
+
+	if updateReq.Location != nil {
+		if err := recordEdit("location", existing.Location, *updateReq.Location); err != nil {
+			return err
+		}
+		updated.Location = *updateReq.Location
+	}
+
+	if updateReq.Urgency != nil {
+		if *updateReq.Urgency < 1 || *updateReq.Urgency > 10 {
+			return apierrors.NewFieldValidationError("urgency", "campaign urgency must be between 1 and 10")
+		}
+		if err := recordEdit("urgency", strconv.Itoa(existing.Urgency), strconv.Itoa(*updateReq.Urgency)); err != nil {
+			return err
+		}
+		updated.Urgency = *updateReq.Urgency
+	}
+
+	if updateReq.CategoryId != nil {
+		if *updateReq.CategoryId == uuid.Nil {
+			return apierrors.NewFieldValidationError("category", "campaign category cannot be empty")
+		}
+		if err := recordEdit("category", existing.CategoryId.String(), updateReq.CategoryId.String()); err != nil {
+			return err
+		}
+		updated.CategoryId = *updateReq.CategoryId
+	}
+
+	if updateReq.BeneficiaryName != nil {
+		if err := recordEdit("beneficiary_name", stringValue(existing.BeneficiaryName), *updateReq.BeneficiaryName); err != nil {
+			return err
+		}
+		updated.BeneficiaryName = updateReq.BeneficiaryName
+	}
+
+	if updateReq.BeneficiaryAge != nil {
+		if *updateReq.BeneficiaryAge < 0 {
+			return apierrors.NewFieldValidationError("beneficiary_age", "beneficiary age cannot be negative")
+		}
+		oldAge := ""
+		if existing.BeneficiaryAge != nil {
+			oldAge = strconv.Itoa(*existing.BeneficiaryAge)
+		}
+		if err := recordEdit("beneficiary_age", oldAge, strconv.Itoa(*updateReq.BeneficiaryAge)); err != nil {
+			return err
+		}
+		updated.BeneficiaryAge = updateReq.BeneficiaryAge
+	}
+
+	if updateReq.CurrentSituation != nil {
+		if err := recordEdit("current_situation", stringValue(existing.CurrentSituation), *updateReq.CurrentSituation); err != nil {
+			return err
+		}
+		updated.CurrentSituation = updateReq.CurrentSituation
+	}
+
+	if updateReq.UrgencyReason != nil {
+		if err := recordEdit("urgency_reason", stringValue(existing.UrgencyReason), *updateReq.UrgencyReason); err != nil {
+			return err
+		}
+		updated.UrgencyReason = updateReq.UrgencyReason
+	}
+
+	// Payment methods are only touched when they changed, which recordEdit only allows for drafts
+	var paymentMethods *[]CampaignPaymentMethod
+	if updateReq.PaymentMethods != nil {
+		oldValue, newValue := formatPaymentMethods(existing.PaymentMethods), formatPaymentMethods(*updateReq.PaymentMethods)
+		if err := recordEdit("payment_methods", oldValue, newValue); err != nil {
+			return err
+		}
+		if oldValue != newValue {
+			if err := s.validatePaymentMethods(ctx, *updateReq.PaymentMethods); err != nil {
+				return err
+			}
+			paymentMethods = updateReq.PaymentMethods
+		}
+	}
+
+	if len(edits) == 0 {
+		return nil
+	}
+
+	if err := s.repo.UpdateCampaign(ctx, updated, edits, paymentMethods); err != nil {
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	return nil
+}
+
+// validatePaymentMethods checks that the payment methods of a campaign exist and are not repeated
+func (s *service) validatePaymentMethods(ctx context.Context, paymentMethods []CampaignPaymentMethod) error {
+	seen := map[int]bool{}
+	for _, paymentMethod := range paymentMethods {
+		if seen[paymentMethod.PaymentMethodID] {
+			return apierrors.NewFieldValidationError("payment_methods", "payment methods cannot be repeated")
+		}
+		seen[paymentMethod.PaymentMethodID] = true
+
+		if _, err := s.paymentMethodSvc.GetPaymentMethod(ctx, paymentMethod.PaymentMethodID); err != nil {
+			return apierrors.NewFieldValidationError("payment_methods", "invalid payment method")
+		}
+	}
+	return nil
+}
+
+func (s *service) ListCampaignEdits(ctx context.Context, campaignID uuid.UUID) ([]CampaignEdit, error) {
+	return s.repo.ListCampaignEdits(ctx, campaignID)
+}
+
+func (s *service) ListArchivedCampaigns(ctx context.Context) ([]Campaign, error) {
+	return s.repo.ListArchivedCampaigns(ctx)
+}
+
+// ArchiveCampaign soft-deletes a campaign, keeping its donations, receipts and closure reports
+func (s *service) ArchiveCampaign(ctx context.Context, id uuid.UUID, archivedBy *uuid.UUID) error {
+	if _, err := s.repo.GetCampaign(ctx, id); err != nil {
+		return fmt.Errorf("campaign not found: %w", err)
+	}
+
+	if err := s.repo.ArchiveCampaign(ctx, id, archivedBy); err != nil {
+		return fmt.Errorf("failed to archive campaign: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) RestoreCampaign(ctx context.Context, id uuid.UUID) error {
+	campaign, err := s.repo.GetCampaignIncludingArchived(ctx, id)
+	if err != nil {
+		return fmt.Errorf("campaign not found: %w", err)
+	}
+
+	if campaign.ArchivedAt == nil {
+		return apierrors.NewValidationError("campaign is not archived")
+	}
+
+	if err := s.repo.RestoreCampaign(ctx, id); err != nil {
+		return fmt.Errorf("failed to restore campaign: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCampaign permanently deletes a campaign. Only drafts without donations can be deleted.
+func (s *service) DeleteCampaign(ctx context.Context, id uuid.UUID) error {
+	campaign, err := s.repo.GetCampaignIncludingArchived(ctx, id)
+	if err != nil {
+		return fmt.Errorf("campaign not found: %w", err)
+	}
+
+	if campaign.Status != StatusDraft {
+		return apierrors.NewFieldValidationError("status",
+			fmt.Sprintf("only draft campaigns can be permanently deleted, current status: %s", campaign.Status))
+	}
+
+	donationsCount, err := s.repo.CountDonations(ctx, id)
+	if err != nil {
+		return err
+	}
+	if donationsCount > 0 {
+		return apierrors.NewValidationError("campaigns with donations cannot be permanently deleted, archive it instead")
+	}
+
+	return s.repo.DeleteCampaign(ctx, id)
+}
+
 func (s *service) UpdateCampaignImage(ctx context.Context, id uuid.UUID, imageURL string) error {
 	// Check if campaign exists
 	_, err := s.repo.GetCampaign(ctx, id)
@@ -174,8 +577,15 @@ func (s *service) UpdateCampaignImage(ctx context.Context, id uuid.UUID, imageUR
 	return nil
 }
 
-func (s *service) GetSummary(ctx context.Context) (Summary, error) {
-	return s.repo.GetSummary(ctx)
+func (s *service) GetSummary(ctx context.Context, filter SummaryFilter) (Summary, error) {
+	if filter.DateFrom != nil && filter.DateTo != nil && filter.DateFrom.After(*filter.DateTo) {
+		return Summary{}, apierrors.NewFieldValidationError("date_from", "date_from cannot be after date_to")
+	}
+	filter.Currency = currency.Normalize(filter.Currency)
+	if !currency.IsSupported(filter.Currency) {
+		return Summary{}, apierrors.NewFieldValidationError("currency", fmt.Sprintf("unsupported currency: %s", filter.Currency))
+	}
+	return s.repo.GetSummary(ctx, filter)
 }
 
 func (s *service) UpdateStatus(ctx context.Context, campaignID uuid.UUID, status string) error {
@@ -228,6 +638,7 @@ func (s *service) GetCampaignInfo(ctx context.Context, campaignID uuid.UUID) (Ca
 		ID:          campaign.ID,
 		Title:       campaign.Title,
 		Goal:        campaign.Goal,
+		Currency:    campaign.Currency,
 		OrganizerID: campaign.OrganizerID,
 		Status:      campaign.Status,
 	}, nil
@@ -240,3 +651,34 @@ func (s *service) GetCampaignStatus(ctx context.Context, campaignID uuid.UUID) (
 	}
 	return campaign.Status, nil
 }
+
+func (s *service) GetCampaignCurrency(ctx context.Context, campaignID uuid.UUID) (string, error) {
+	campaign, err := s.repo.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return "", fmt.Errorf("campaign not found: %w", err)
+	}
+	return campaign.Currency, nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func formatDate(date time.Time) string {
+	return date.Format(time.RFC3339)
+}
+
+func formatPaymentMethods(paymentMethods []CampaignPaymentMethod) string {
+	parts := make([]string, len(paymentMethods))
+	for i, pm := range paymentMethods {
+		parts[i] = strconv.Itoa(pm.PaymentMethodID)
+		if pm.Instructions != nil && *pm.Instructions != "" {
+			parts[i] += " (" + *pm.Instructions + ")"
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,94 @@
+package currency
+
+import (
+	"strings"
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// Supported currency codes (ISO 4217)
+const (
+	ARS = "ARS"
+	USD = "USD"
+)
+
+// Default is the currency of campaigns and donations that do not set one
+const Default = ARS
+
+// IsSupported checks if donations can be made in a currency
+func IsSupported(code string) bool {
+	switch code {
+	case ARS, USD:
+		return true
+	default:
+		return false
+	}
+}
+
+// Normalize upper-cases a currency code and defaults empty codes
+func Normalize(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return Default
+	}
+	return code
+}
+
+// RateSource identifies how an exchange rate was entered
+type RateSource string
+
+const (
+	SourceManual RateSource = "manual"
+	SourceImport RateSource = "import"
+)
+
+// ExchangeRate means 1 unit of FromCurrency is worth Rate units of ToCurrency from EffectiveAt on
+type ExchangeRate struct {
+	ID           uuid.UUID  `json:"id"`
+	FromCurrency string     `json:"from_currency"`
+	ToCurrency   string     `json:"to_currency"`
+	Rate         float64    `json:"rate"`
+	EffectiveAt  time.Time  `json:"effective_at"`
+	Source       RateSource `json:"source"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CreateExchangeRateRequest represents an admin entering a rate
+type CreateExchangeRateRequest struct {
+	FromCurrency string     `json:"from_currency"`
+	ToCurrency   string     `json:"to_currency"`
+	Rate         float64    `json:"rate"`
+	EffectiveAt  *time.Time `json:"effective_at,omitempty"` // Defaults to now
+}
+
+// ExchangeRateFilter represents the optional filters of the rate listing
+type ExchangeRateFilter struct {
+	FromCurrency *string
+	ToCurrency   *string
+}
+
+// ImportError describes an invalid line of an imported rates file
+type ImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportResult summarizes a rates file import. Files with errors are not imported at all.
+type ImportResult struct {
+	Imported int           `json:"imported"`
+	Errors   []ImportError `json:"errors,omitempty"`
+}
+
+// Conversion is an amount converted to another currency with the rate that was used
+type Conversion struct {
+	Amount           money.Amount `json:"amount"`
+	Currency         string       `json:"currency"`
+	OriginalAmount   money.Amount `json:"original_amount"`
+	OriginalCurrency string       `json:"original_currency"`
+	Rate             float64      `json:"rate"`
+	RateID           *uuid.UUID   `json:"rate_id,omitempty"` // Nil when no conversion was needed
+}
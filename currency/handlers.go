@@ -0,0 +1,174 @@
+package currency
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests for exchange rates
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new exchange rates handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the exchange rate routes
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	// Public routes: donors can see the rate their donation will be converted with
+	g.GET("/exchange-rates", h.ListRates)
+	g.GET("/exchange-rates/:id", h.GetRate)
+
+	// Admin routes
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.POST("/exchange-rates", h.CreateRate)
+	adminGroup.POST("/exchange-rates/import", h.ImportRates)
+}
+
+// @Summary List exchange rates
+// @Description Get the stored exchange rates, newest first, optionally filtered by currency pair
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Param from query string false "Source currency (ARS, USD)"
+// @Param to query string false "Target currency (ARS, USD)"
+// @Success 200 {array} ExchangeRate
+// @Failure 400 {object} errors.APIError
+// @Router /exchange-rates [get]
+func (h *Handler) ListRates(c echo.Context) error {
+	var filter ExchangeRateFilter
+	if value := c.QueryParam("from"); value != "" {
+		filter.FromCurrency = &value
+	}
+	if value := c.QueryParam("to"); value != "" {
+		filter.ToCurrency = &value
+	}
+
+	rates, err := h.service.ListRates(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, rates)
+}
+
+// @Summary Get exchange rate by ID
+// @Description Get an exchange rate, such as the one used to convert a donation
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Param id path string true "Exchange rate ID"
+// @Success 200 {object} ExchangeRate
+// @Failure 404 {object} errors.APIError
+// @Router /exchange-rates/{id} [get]
+func (h *Handler) GetRate(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exchange rate ID")
+	}
+
+	rate, err := h.service.GetRate(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, rate)
+}
+
+// @Summary Create exchange rate
+// @Description Store a rate meaning 1 unit of from_currency is worth rate units of to_currency from effective_at on. Donations use the latest rate in effect when they are made.
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rate body CreateExchangeRateRequest true "Exchange rate"
+// @Success 201 {object} ExchangeRate
+// @Failure 400 {object} errors.APIError
+// @Router /exchange-rates [post]
+func (h *Handler) CreateRate(c echo.Context) error {
+	var req CreateExchangeRateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	rate, err := h.service.CreateRate(c.Request().Context(), req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, rate)
+}
+
+// @Summary Import exchange rates
+// @Description Import rates from a CSV file with the columns from_currency, to_currency, rate and effective_at (YYYY-MM-DD or RFC 3339). The header row is optional. If any row is invalid nothing is imported and the errors are returned.
+// @Tags exchange-rates
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file"
+// @Success 201 {object} ImportResult
+// @Failure 400 {object} ImportResult
+// @Router /exchange-rates/import [post]
+func (h *Handler) ImportRates(c echo.Context) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "File is required")
+	}
+
+	if ext := strings.ToLower(filepath.Ext(file.Filename)); ext != ".csv" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Only CSV files are allowed")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open file")
+	}
+	defer src.Close()
+
+	result, err := h.service.ImportRates(c.Request().Context(), src, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+	if len(result.Errors) > 0 {
+		return c.JSON(http.StatusBadRequest, result)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// errorStatus maps a currency service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// getUserID returns the authenticated user ID from the context, if any
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,50 @@
+package currency
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeRateModel represents the exchange rates table
+type ExchangeRateModel struct {
+	ID           uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	FromCurrency string     `gorm:"column:from_currency;type:varchar(3);not null"`
+	ToCurrency   string     `gorm:"column:to_currency;type:varchar(3);not null"`
+	Rate         float64    `gorm:"column:rate;type:decimal(18,8);not null"`
+	EffectiveAt  time.Time  `gorm:"column:effective_at;not null"`
+	Source       string     `gorm:"column:source;type:varchar(20);not null;default:manual"`
+	CreatedBy    *uuid.UUID `gorm:"column:created_by;type:uuid"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (ExchangeRateModel) TableName() string {
+	return "exchange_rates"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m ExchangeRateModel) ToEntity() ExchangeRate {
+	return ExchangeRate{
+		ID:           m.ID,
+		FromCurrency: m.FromCurrency,
+		ToCurrency:   m.ToCurrency,
+		Rate:         m.Rate,
+		EffectiveAt:  m.EffectiveAt,
+		Source:       RateSource(m.Source),
+		CreatedBy:    m.CreatedBy,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *ExchangeRateModel) FromEntity(entity ExchangeRate) {
+	m.ID = entity.ID
+	m.FromCurrency = entity.FromCurrency
+	m.ToCurrency = entity.ToCurrency
+	m.Rate = entity.Rate
+	m.EffectiveAt = entity.EffectiveAt
+	m.Source = string(entity.Source)
+	m.CreatedBy = entity.CreatedBy
+	m.CreatedAt = entity.CreatedAt
+}
@@ -0,0 +1,79 @@
+package currency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetRate(ctx context.Context, id uuid.UUID) (ExchangeRate, error)
+	ListRates(ctx context.Context, filter ExchangeRateFilter) ([]ExchangeRate, error)
+	CreateRates(ctx context.Context, rates []ExchangeRate) error
+	GetLatestRate(ctx context.Context, from, to string, at time.Time) (ExchangeRate, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetRate(ctx context.Context, id uuid.UUID) (ExchangeRate, error) {
+	var model ExchangeRateModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return ExchangeRate{}, err
+	}
+	return model.ToEntity(), nil
+}
+
+func (r *repository) ListRates(ctx context.Context, filter ExchangeRateFilter) ([]ExchangeRate, error) {
+	query := r.db.WithContext(ctx).Model(&ExchangeRateModel{})
+	if filter.FromCurrency != nil {
+		query = query.Where("from_currency = ?", *filter.FromCurrency)
+	}
+	if filter.ToCurrency != nil {
+		query = query.Where("to_currency = ?", *filter.ToCurrency)
+	}
+
+	var models []ExchangeRateModel
+	if err := query.Order("effective_at DESC, created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	rates := make([]ExchangeRate, len(models))
+	for i, model := range models {
+		rates[i] = model.ToEntity()
+	}
+	return rates, nil
+}
+
+// CreateRates stores the rates in a single transaction
+func (r *repository) CreateRates(ctx context.Context, rates []ExchangeRate) error {
+	models := make([]ExchangeRateModel, len(rates))
+	for i, rate := range rates {
+		models[i].FromEntity(rate)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&models).Error
+	})
+}
+
+// GetLatestRate returns the most recent rate in effect at the given time between two currencies,
+// stored in either direction
+func (r *repository) GetLatestRate(ctx context.Context, from, to string, at time.Time) (ExchangeRate, error) {
+	var model ExchangeRateModel
+	err := r.db.WithContext(ctx).
+		Where("((from_currency = ? AND to_currency = ?) OR (from_currency = ? AND to_currency = ?)) AND effective_at <= ?",
+			from, to, to, from, at).
+		Order("effective_at DESC, created_at DESC").
+		First(&model).Error
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	return model.ToEntity(), nil
+}
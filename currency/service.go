@@ -0,0 +1,240 @@
+package currency
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxImportRows is the maximum number of rates in an imported file
+const MaxImportRows = 10000
+
+type Service interface {
+	GetRate(ctx context.Context, id uuid.UUID) (ExchangeRate, error)
+	ListRates(ctx context.Context, filter ExchangeRateFilter) ([]ExchangeRate, error)
+	CreateRate(ctx context.Context, req CreateExchangeRateRequest, createdBy *uuid.UUID) (ExchangeRate, error)
+	ImportRates(ctx context.Context, file io.Reader, createdBy *uuid.UUID) (ImportResult, error)
+	Convert(ctx context.Context, amount money.Amount, from, to string, at time.Time) (Conversion, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) GetRate(ctx context.Context, id uuid.UUID) (ExchangeRate, error) {
+	rate, err := s.repo.GetRate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ExchangeRate{}, apierrors.NewNotFoundError("exchange rate not found")
+		}
+		return ExchangeRate{}, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+func (s *service) ListRates(ctx context.Context, filter ExchangeRateFilter) ([]ExchangeRate, error) {
+	for field, code := range map[string]*string{"from_currency": filter.FromCurrency, "to_currency": filter.ToCurrency} {
+		if code == nil {
+			continue
+		}
+		*code = Normalize(*code)
+		if !IsSupported(*code) {
+			return nil, apierrors.NewFieldValidationError(field, fmt.Sprintf("unsupported currency: %s", *code))
+		}
+	}
+	return s.repo.ListRates(ctx, filter)
+}
+
+// CreateRate stores a rate entered by an admin
+func (s *service) CreateRate(ctx context.Context, req CreateExchangeRateRequest, createdBy *uuid.UUID) (ExchangeRate, error) {
+	effectiveAt := time.Now()
+	if req.EffectiveAt != nil {
+		effectiveAt = *req.EffectiveAt
+	}
+
+	rate, err := newRate(req.FromCurrency, req.ToCurrency, req.Rate, effectiveAt, SourceManual, createdBy)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+
+	if err := s.repo.CreateRates(ctx, []ExchangeRate{rate}); err != nil {
+		return ExchangeRate{}, fmt.Errorf("failed to create exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+// ImportRates loads rates from a CSV file with the columns from_currency, to_currency, rate and
+// effective_at (YYYY-MM-DD or RFC 3339). A header row is optional. The file is imported only if
+// every row is valid.
+func (s *service) ImportRates(ctx context.Context, file io.Reader, createdBy *uuid.UUID) (ImportResult, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var result ImportResult
+	var rates []ExchangeRate
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Line: line, Message: err.Error()})
+			break
+		}
+
+		// Skip the header and blank lines
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "from_currency") {
+			continue
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		rate, err := parseRateRecord(record, createdBy)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		rates = append(rates, rate)
+
+		if len(rates) > MaxImportRows {
+			return ImportResult{}, apierrors.NewValidationError(fmt.Sprintf("file cannot contain more than %d rates", MaxImportRows))
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+	if len(rates) == 0 {
+		return ImportResult{}, apierrors.NewValidationError("file does not contain any exchange rate")
+	}
+
+	if err := s.repo.CreateRates(ctx, rates); err != nil {
+		return ImportResult{}, fmt.Errorf("failed to import exchange rates: %w", err)
+	}
+
+	result.Imported = len(rates)
+	return result, nil
+}
+
+// Convert converts an amount with the latest rate in effect at the given time. Rates stored in the
+// opposite direction are inverted. The converted amount is rounded to the cent.
+func (s *service) Convert(ctx context.Context, amount money.Amount, from, to string, at time.Time) (Conversion, error) {
+	from, to = Normalize(from), Normalize(to)
+	if !IsSupported(from) {
+		return Conversion{}, apierrors.NewFieldValidationError("currency", fmt.Sprintf("unsupported currency: %s", from))
+	}
+	if !IsSupported(to) {
+		return Conversion{}, apierrors.NewFieldValidationError("currency", fmt.Sprintf("unsupported currency: %s", to))
+	}
+
+	conversion := Conversion{
+		Amount:           amount,
+		Currency:         to,
+		OriginalAmount:   amount,
+		OriginalCurrency: from,
+		Rate:             1,
+	}
+	if from == to {
+		return conversion, nil
+	}
+
+	rate, err := s.repo.GetLatestRate(ctx, from, to, at)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Conversion{}, apierrors.NewFieldValidationError("currency",
+				fmt.Sprintf("no exchange rate available from %s to %s", from, to))
+		}
+		return Conversion{}, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	conversion.Rate = rate.Rate
+	if rate.FromCurrency != from {
+		conversion.Rate = 1 / rate.Rate
+	}
+	conversion.Amount = amount.MulRate(conversion.Rate)
+	conversion.RateID = &rate.ID
+
+	return conversion, nil
+}
+
+// parseRateRecord parses a row of an imported rates file
+func parseRateRecord(record []string, createdBy *uuid.UUID) (ExchangeRate, error) {
+	if len(record) != 4 {
+		return ExchangeRate{}, fmt.Errorf("expected 4 columns (from_currency, to_currency, rate, effective_at), got %d", len(record))
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+	if err != nil {
+		return ExchangeRate{}, fmt.Errorf("invalid rate: %s", record[2])
+	}
+
+	effectiveAt, err := parseEffectiveAt(strings.TrimSpace(record[3]))
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+
+	return newRate(record[0], record[1], value, effectiveAt, SourceImport, createdBy)
+}
+
+func parseEffectiveAt(value string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid effective_at %q, expected YYYY-MM-DD or RFC 3339", value)
+}
+
+// newRate validates and builds an exchange rate
+func newRate(from, to string, value float64, effectiveAt time.Time, source RateSource, createdBy *uuid.UUID) (ExchangeRate, error) {
+	from = strings.ToUpper(strings.TrimSpace(from))
+	to = strings.ToUpper(strings.TrimSpace(to))
+
+	if !IsSupported(from) {
+		return ExchangeRate{}, apierrors.NewFieldValidationError("from_currency", fmt.Sprintf("unsupported currency: %s", from))
+	}
+	if !IsSupported(to) {
+		return ExchangeRate{}, apierrors.NewFieldValidationError("to_currency", fmt.Sprintf("unsupported currency: %s", to))
+	}
+	if from == to {
+		return ExchangeRate{}, apierrors.NewFieldValidationError("to_currency", "currencies must be different")
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) || value <= 0 {
+		return ExchangeRate{}, apierrors.NewFieldValidationError("rate", "rate must be greater than 0")
+	}
+	if effectiveAt.IsZero() {
+		return ExchangeRate{}, apierrors.NewFieldValidationError("effective_at", "effective date is required")
+	}
+
+	return ExchangeRate{
+		ID:           uuid.New(),
+		FromCurrency: from,
+		ToCurrency:   to,
+		Rate:         value,
+		EffectiveAt:  effectiveAt,
+		Source:       source,
+		CreatedBy:    createdBy,
+		CreatedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// TryAdvisoryLock takes a session-level Postgres advisory lock on a connection dedicated to it, so
+// that the lock does not keep a transaction open while the work it protects runs. It returns false
+// when another process already holds the lock. When acquired, release must be called to unlock it
+// and give the connection back to the pool.
+func TryAdvisoryLock(ctx context.Context, db *gorm.DB, key int64) (release func(), acquired bool, err error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get a connection for the advisory lock: %w", err)
+	}
+
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("failed to take advisory lock %d: %w", key, err)
+	}
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release = func() {
+		// The work may have been cancelled, so unlocking cannot depend on its context
+		var unlocked bool
+		err := conn.QueryRowContext(context.Background(), "SELECT pg_advisory_unlock($1)", key).Scan(&unlocked)
+		if err != nil || !unlocked {
+			log.Printf("⚠️  Could not release advisory lock %d, dropping its connection: %v", key, err)
+			discardConn(conn)
+		}
+		conn.Close()
+	}
+	return release, true, nil
+}
+
+// WithAdvisoryLock runs fn while holding a session-level Postgres advisory lock, see TryAdvisoryLock.
+// It returns false without running fn when another process already holds the lock.
+func WithAdvisoryLock(ctx context.Context, db *gorm.DB, key int64, fn func(ctx context.Context) error) (bool, error) {
+	release, acquired, err := TryAdvisoryLock(ctx, db, key)
+	if err != nil || !acquired {
+		return false, err
+	}
+	defer release()
+
+	return true, fn(ctx)
+}
+
+// discardConn makes the pool close a connection instead of reusing it, which also drops its session locks
+func discardConn(conn *sql.Conn) {
+	conn.Raw(func(interface{}) error {
+		return driver.ErrBadConn
+	})
+}
@@ -0,0 +1,112 @@
+package cash
+
+import (
+	"time"
+
+	"dona_tutti_api/donation"
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// BatchStatus represents the state of a daily cash collection batch
+type BatchStatus string
+
+const (
+	BatchStatusOpen      BatchStatus = "open"      // Collectors are logging donations
+	BatchStatusSubmitted BatchStatus = "submitted" // Handed over, waiting for a second person to count it
+	BatchStatusConfirmed BatchStatus = "confirmed" // Counted, its donations are completed
+)
+
+// IsValidBatchStatus checks if a batch status is valid
+func IsValidBatchStatus(status BatchStatus) bool {
+	switch status {
+	case BatchStatusOpen, BatchStatusSubmitted, BatchStatusConfirmed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Location is a cash location with the campaign payment method it belongs to
+type Location struct {
+	ID              int
+	Name            string
+	CampaignID      uuid.UUID
+	PaymentMethodID int
+}
+
+// Batch is the cash collected at a cash location during one day
+type Batch struct {
+	ID             uuid.UUID           `json:"id"`
+	CampaignID     uuid.UUID           `json:"campaign_id"`
+	CashLocationID int                 `json:"cash_location_id"`
+	LocationName   string              `json:"location_name"`
+	CollectionDate time.Time           `json:"collection_date"`
+	Status         BatchStatus         `json:"status"`
+	LoggedTotal    money.Amount        `json:"logged_total"` // Sum of the donations logged in the batch
+	DonationCount  int                 `json:"donation_count"`
+	DeclaredTotal  *money.Amount       `json:"declared_total,omitempty"` // Cash handed over by the collector
+	CountedTotal   *money.Amount       `json:"counted_total,omitempty"`  // Cash counted by the confirmer
+	SubmittedBy    *uuid.UUID          `json:"submitted_by,omitempty"`
+	SubmittedAt    *time.Time          `json:"submitted_at,omitempty"`
+	ConfirmedBy    *uuid.UUID          `json:"confirmed_by,omitempty"`
+	ConfirmedAt    *time.Time          `json:"confirmed_at,omitempty"`
+	Notes          *string             `json:"notes,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	Donations      []CollectedDonation `json:"donations,omitempty"`
+}
+
+// CollectedDonation is a cash donation logged by a collector
+type CollectedDonation struct {
+	DonationID     uuid.UUID               `json:"donation_id"`
+	BatchID        uuid.UUID               `json:"batch_id"`
+	Amount         money.Amount            `json:"amount"`
+	Currency       string                  `json:"currency"`
+	Status         donation.DonationStatus `json:"status"`
+	IsAnonymous    bool                    `json:"is_anonymous"`
+	DonorFirstName string                  `json:"donor_first_name,omitempty"`
+	DonorLastName  string                  `json:"donor_last_name,omitempty"`
+	CollectedBy    *uuid.UUID              `json:"collected_by,omitempty"`
+	CollectedAt    time.Time               `json:"collected_at"`
+	VoidedAt       *time.Time              `json:"voided_at,omitempty"`
+	VoidReason     *string                 `json:"void_reason,omitempty"`
+}
+
+// BatchFilter represents the optional filters when listing batches
+type BatchFilter struct {
+	CampaignID     *uuid.UUID
+	CashLocationID *int
+	Status         *BatchStatus
+}
+
+// LogDonationRequest represents a collector registering cash received at a location
+type LogDonationRequest struct {
+	Amount      money.Amount        `json:"amount"` // In the campaign currency
+	Message     *string             `json:"message,omitempty"`
+	Donor       *donation.DonorInfo `json:"donor,omitempty"`        // Optional, the donation is anonymous without it
+	CollectedAt *time.Time          `json:"collected_at,omitempty"` // Defaults to now, sets the batch day
+}
+
+// SubmitBatchRequest represents a collector handing over the cash of a batch
+type SubmitBatchRequest struct {
+	DeclaredTotal *money.Amount `json:"declared_total" validate:"required"`
+	Notes         *string       `json:"notes,omitempty"`
+}
+
+// ConfirmBatchRequest represents the second person counting the cash of a batch
+type ConfirmBatchRequest struct {
+	CountedTotal *money.Amount `json:"counted_total" validate:"required"`
+	Notes        *string       `json:"notes,omitempty"`
+}
+
+// ReopenBatchRequest represents sending a submitted batch back to the collector
+type ReopenBatchRequest struct {
+	Notes string `json:"notes" validate:"required"`
+}
+
+// VoidDonationRequest represents a collector removing a donation logged by mistake
+type VoidDonationRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
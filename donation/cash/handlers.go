@@ -0,0 +1,288 @@
+package cash
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests for cash collection
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new cash collection handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the cash collection routes. They are available to admins and collectors.
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, collectorMiddleware echo.MiddlewareFunc) {
+	authGroup := g.Group("", authMiddleware)
+	collectorGroup := authGroup.Group("", collectorMiddleware)
+	collectorGroup.POST("/campaigns/:campaignId/cash-locations/:locationId/donations", h.LogDonation)
+	collectorGroup.POST("/cash-donations/:id/void", h.VoidDonation)
+	collectorGroup.GET("/cash-batches", h.ListBatches)
+	collectorGroup.GET("/cash-batches/:id", h.GetBatch)
+	collectorGroup.POST("/cash-batches/:id/submit", h.SubmitBatch)
+	collectorGroup.POST("/cash-batches/:id/confirm", h.ConfirmBatch)
+	collectorGroup.POST("/cash-batches/:id/reopen", h.ReopenBatch)
+}
+
+// @Summary Log a cash donation
+// @Description Register cash received at a cash location of the campaign. Donor information is optional, without it the donation is anonymous. The donation stays pending in the batch of the collection day until the batch is confirmed.
+// @Tags cash-collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Param locationId path int true "Cash location ID"
+// @Param donation body LogDonationRequest true "Cash donation"
+// @Success 201 {object} CollectedDonation
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /campaigns/{campaignId}/cash-locations/{locationId}/donations [post]
+func (h *Handler) LogDonation(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	locationID, err := strconv.Atoi(c.Param("locationId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid cash location ID")
+	}
+
+	var req LogDonationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	collected, err := h.service.LogDonation(c.Request().Context(), campaignID, locationID, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, collected)
+}
+
+// @Summary Void a cash donation
+// @Description Remove a donation logged by mistake from its batch while the batch is open. The donation is marked as failed.
+// @Tags cash-collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Donation ID"
+// @Param void body VoidDonationRequest true "Reason"
+// @Success 200 {object} CollectedDonation
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /cash-donations/{id}/void [post]
+func (h *Handler) VoidDonation(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donation ID")
+	}
+
+	var req VoidDonationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	collected, err := h.service.VoidDonation(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, collected)
+}
+
+// @Summary List cash batches
+// @Description Get the daily collection batches, most recent days first
+// @Tags cash-collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id query string false "Campaign ID"
+// @Param cash_location_id query int false "Cash location ID"
+// @Param status query string false "Batch status (open, submitted, confirmed)"
+// @Success 200 {array} Batch
+// @Failure 400 {object} errors.APIError
+// @Router /cash-batches [get]
+func (h *Handler) ListBatches(c echo.Context) error {
+	var filter BatchFilter
+	if value := c.QueryParam("campaign_id"); value != "" {
+		campaignID, err := uuid.Parse(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+		}
+		filter.CampaignID = &campaignID
+	}
+	if value := c.QueryParam("cash_location_id"); value != "" {
+		locationID, err := strconv.Atoi(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cash location ID")
+		}
+		filter.CashLocationID = &locationID
+	}
+	if value := c.QueryParam("status"); value != "" {
+		status := BatchStatus(value)
+		filter.Status = &status
+	}
+
+	batches, err := h.service.ListBatches(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, batches)
+}
+
+// @Summary Get cash batch by ID
+// @Description Get a batch with its logged donations
+// @Tags cash-collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Success 200 {object} Batch
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /cash-batches/{id} [get]
+func (h *Handler) GetBatch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid batch ID")
+	}
+
+	batch, err := h.service.GetBatch(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+// @Summary Submit a cash batch
+// @Description Hand over the cash of an open batch. A declared total that differs from the logged donations raises a campaign alert.
+// @Tags cash-collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Param submission body SubmitBatchRequest true "Declared total"
+// @Success 200 {object} Batch
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /cash-batches/{id}/submit [post]
+func (h *Handler) SubmitBatch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid batch ID")
+	}
+
+	var req SubmitBatchRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	batch, err := h.service.SubmitBatch(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+// @Summary Confirm a cash batch
+// @Description Count the cash of a submitted batch and complete its donations, which generates their receipts. The batch must be confirmed by someone other than the person who submitted it. A counted total that differs from the logged donations raises a campaign alert.
+// @Tags cash-collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Param confirmation body ConfirmBatchRequest true "Counted total"
+// @Success 200 {object} Batch
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /cash-batches/{id}/confirm [post]
+func (h *Handler) ConfirmBatch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid batch ID")
+	}
+
+	var req ConfirmBatchRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	batch, err := h.service.ConfirmBatch(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+// @Summary Reopen a cash batch
+// @Description Send a submitted batch back to its collectors, for instance to void a donation logged by mistake. Notes are required.
+// @Tags cash-collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Param reopen body ReopenBatchRequest true "Reason"
+// @Success 200 {object} Batch
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /cash-batches/{id}/reopen [post]
+func (h *Handler) ReopenBatch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid batch ID")
+	}
+
+	var req ReopenBatchRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	batch, err := h.service.ReopenBatch(c.Request().Context(), id, req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+// errorStatus maps a cash collection service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
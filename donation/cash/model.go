@@ -0,0 +1,95 @@
+package cash
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// BatchModel represents the database table structure with GORM tags
+type BatchModel struct {
+	ID             uuid.UUID     `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	CampaignID     uuid.UUID     `gorm:"column:campaign_id;type:uuid;not null;index"`
+	CashLocationID int           `gorm:"column:cash_location_id;not null"`
+	CollectionDate time.Time     `gorm:"column:collection_date;type:date;not null"`
+	Status         string        `gorm:"column:status;type:varchar(20);not null;default:open"`
+	DeclaredTotal  *money.Amount `gorm:"column:declared_total;type:decimal(10,2)"`
+	CountedTotal   *money.Amount `gorm:"column:counted_total;type:decimal(10,2)"`
+	SubmittedBy    *uuid.UUID    `gorm:"column:submitted_by;type:uuid"`
+	SubmittedAt    *time.Time    `gorm:"column:submitted_at"`
+	ConfirmedBy    *uuid.UUID    `gorm:"column:confirmed_by;type:uuid"`
+	ConfirmedAt    *time.Time    `gorm:"column:confirmed_at"`
+	Notes          *string       `gorm:"column:notes"`
+	CreatedAt      time.Time     `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time     `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (BatchModel) TableName() string {
+	return "cash_batches"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m BatchModel) ToEntity() Batch {
+	return Batch{
+		ID:             m.ID,
+		CampaignID:     m.CampaignID,
+		CashLocationID: m.CashLocationID,
+		CollectionDate: m.CollectionDate,
+		Status:         BatchStatus(m.Status),
+		DeclaredTotal:  m.DeclaredTotal,
+		CountedTotal:   m.CountedTotal,
+		SubmittedBy:    m.SubmittedBy,
+		SubmittedAt:    m.SubmittedAt,
+		ConfirmedBy:    m.ConfirmedBy,
+		ConfirmedAt:    m.ConfirmedAt,
+		Notes:          m.Notes,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *BatchModel) FromEntity(entity Batch) {
+	m.ID = entity.ID
+	m.CampaignID = entity.CampaignID
+	m.CashLocationID = entity.CashLocationID
+	m.CollectionDate = entity.CollectionDate
+	m.Status = string(entity.Status)
+	m.DeclaredTotal = entity.DeclaredTotal
+	m.CountedTotal = entity.CountedTotal
+	m.SubmittedBy = entity.SubmittedBy
+	m.SubmittedAt = entity.SubmittedAt
+	m.ConfirmedBy = entity.ConfirmedBy
+	m.ConfirmedAt = entity.ConfirmedAt
+	m.Notes = entity.Notes
+	m.CreatedAt = entity.CreatedAt
+	m.UpdatedAt = entity.UpdatedAt
+}
+
+// CollectedDonationModel represents the cash donations table
+type CollectedDonationModel struct {
+	DonationID  uuid.UUID  `gorm:"primaryKey;column:donation_id;type:uuid"`
+	BatchID     uuid.UUID  `gorm:"column:batch_id;type:uuid;not null;index"`
+	CollectedBy *uuid.UUID `gorm:"column:collected_by;type:uuid"`
+	CollectedAt time.Time  `gorm:"column:collected_at;not null"`
+	VoidedAt    *time.Time `gorm:"column:voided_at"`
+	VoidReason  *string    `gorm:"column:void_reason"`
+}
+
+// TableName specifies the table name for GORM
+func (CollectedDonationModel) TableName() string {
+	return "cash_donations"
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *CollectedDonationModel) FromEntity(entity CollectedDonation) {
+	m.DonationID = entity.DonationID
+	m.BatchID = entity.BatchID
+	m.CollectedBy = entity.CollectedBy
+	m.CollectedAt = entity.CollectedAt
+	m.VoidedAt = entity.VoidedAt
+	m.VoidReason = entity.VoidReason
+}
@@ -0,0 +1,297 @@
+package cash
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrBatchStatusChanged is returned when a batch is no longer in the status an operation requires
+	ErrBatchStatusChanged = errors.New("batch status was changed by another request")
+	// ErrBatchNotOpen is returned when donations are logged or voided in a submitted batch
+	ErrBatchNotOpen = errors.New("batch was already submitted")
+	// ErrDonationVoided is returned when a donation was already voided
+	ErrDonationVoided = errors.New("donation was already voided")
+)
+
+type Repository interface {
+	GetCashLocation(ctx context.Context, id int) (Location, error)
+	GetOrCreateBatch(ctx context.Context, batch Batch) (Batch, error)
+	GetBatch(ctx context.Context, id uuid.UUID) (Batch, error)
+	ListBatches(ctx context.Context, filter BatchFilter) ([]Batch, error)
+	AddDonation(ctx context.Context, collected CollectedDonation) error
+	GetCollectedDonation(ctx context.Context, donationID uuid.UUID) (CollectedDonation, error)
+	ListDonations(ctx context.Context, batchID uuid.UUID) ([]CollectedDonation, error)
+	VoidDonation(ctx context.Context, donationID uuid.UUID, reason string, voidedAt time.Time) error
+	SubmitBatch(ctx context.Context, batch Batch) error
+	ConfirmBatch(ctx context.Context, batch Batch) error
+	ReopenBatch(ctx context.Context, id uuid.UUID, notes string) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetCashLocation returns a cash location with the campaign payment method it belongs to
+func (r *repository) GetCashLocation(ctx context.Context, id int) (Location, error) {
+	var locations []Location
+	err := r.db.WithContext(ctx).
+		Table("cash_locations cl").
+		Select("cl.id, cl.location_name AS name, cpm.campaign_id, cpm.payment_method_id").
+		Joins("JOIN campaign_payment_methods cpm ON cpm.id = cl.campaign_payment_method_id").
+		Where("cl.id = ?", id).
+		Scan(&locations).Error
+	if err != nil {
+		return Location{}, err
+	}
+	if len(locations) == 0 {
+		return Location{}, gorm.ErrRecordNotFound
+	}
+	return locations[0], nil
+}
+
+// GetOrCreateBatch returns the batch of the location for the collection day, creating it if needed
+func (r *repository) GetOrCreateBatch(ctx context.Context, batch Batch) (Batch, error) {
+	var model BatchModel
+	model.FromEntity(batch)
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "cash_location_id"}, {Name: "collection_date"}},
+			DoNothing: true,
+		}).
+		Create(&model).Error
+	if err != nil {
+		return Batch{}, err
+	}
+
+	var id uuid.UUID
+	err = r.db.WithContext(ctx).
+		Model(&BatchModel{}).
+		Where("cash_location_id = ? AND collection_date = ?", batch.CashLocationID, batch.CollectionDate.Format("2006-01-02")).
+		Pluck("id", &id).Error
+	if err != nil {
+		return Batch{}, err
+	}
+
+	return r.GetBatch(ctx, id)
+}
+
+// batchRow is a batch with its location name and the totals of its donations that were not voided
+type batchRow struct {
+	BatchModel
+	LocationName  string
+	LoggedTotal   money.Amount
+	DonationCount int
+}
+
+func (r *repository) batchesQuery(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("cash_batches b").
+		Select(`b.*, cl.location_name,
+			COALESCE(SUM(d.amount) FILTER (WHERE cd.voided_at IS NULL), 0) AS logged_total,
+			COUNT(cd.donation_id) FILTER (WHERE cd.voided_at IS NULL) AS donation_count`).
+		Joins("JOIN cash_locations cl ON cl.id = b.cash_location_id").
+		Joins("LEFT JOIN cash_donations cd ON cd.batch_id = b.id").
+		Joins("LEFT JOIN donations d ON d.id = cd.donation_id").
+		Group("b.id, cl.location_name")
+}
+
+func (r *repository) GetBatch(ctx context.Context, id uuid.UUID) (Batch, error) {
+	var rows []batchRow
+	if err := r.batchesQuery(ctx).Where("b.id = ?", id).Scan(&rows).Error; err != nil {
+		return Batch{}, err
+	}
+	if len(rows) == 0 {
+		return Batch{}, gorm.ErrRecordNotFound
+	}
+
+	return rows[0].toEntity(), nil
+}
+
+// ListBatches returns the batches matching the filter, most recent days first
+func (r *repository) ListBatches(ctx context.Context, filter BatchFilter) ([]Batch, error) {
+	query := r.batchesQuery(ctx)
+	if filter.CampaignID != nil {
+		query = query.Where("b.campaign_id = ?", *filter.CampaignID)
+	}
+	if filter.CashLocationID != nil {
+		query = query.Where("b.cash_location_id = ?", *filter.CashLocationID)
+	}
+	if filter.Status != nil {
+		query = query.Where("b.status = ?", string(*filter.Status))
+	}
+
+	var rows []batchRow
+	if err := query.Order("b.collection_date DESC, cl.location_name ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	batches := make([]Batch, len(rows))
+	for i, row := range rows {
+		batches[i] = row.toEntity()
+	}
+	return batches, nil
+}
+
+// AddDonation links a logged donation to its batch if the batch is still open
+func (r *repository) AddDonation(ctx context.Context, collected CollectedDonation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOpenBatch(tx, collected.BatchID); err != nil {
+			return err
+		}
+
+		var model CollectedDonationModel
+		model.FromEntity(collected)
+		return tx.Create(&model).Error
+	})
+}
+
+// collectedDonationsQuery selects the logged donations with their amount, status and donor
+func (r *repository) collectedDonationsQuery(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("cash_donations cd").
+		Select(`cd.donation_id, cd.batch_id, cd.collected_by, cd.collected_at, cd.voided_at, cd.void_reason,
+			d.amount, d.currency, d.status, d.is_anonymous,
+			dn.first_name AS donor_first_name, dn.last_name AS donor_last_name`).
+		Joins("JOIN donations d ON d.id = cd.donation_id").
+		Joins("LEFT JOIN donors dn ON dn.id = d.donor_id")
+}
+
+func (r *repository) GetCollectedDonation(ctx context.Context, donationID uuid.UUID) (CollectedDonation, error) {
+	var donations []CollectedDonation
+	if err := r.collectedDonationsQuery(ctx).Where("cd.donation_id = ?", donationID).Scan(&donations).Error; err != nil {
+		return CollectedDonation{}, err
+	}
+	if len(donations) == 0 {
+		return CollectedDonation{}, gorm.ErrRecordNotFound
+	}
+
+	return hideAnonymousDonors(donations)[0], nil
+}
+
+func (r *repository) ListDonations(ctx context.Context, batchID uuid.UUID) ([]CollectedDonation, error) {
+	var donations []CollectedDonation
+	err := r.collectedDonationsQuery(ctx).
+		Where("cd.batch_id = ?", batchID).
+		Order("cd.collected_at ASC").
+		Scan(&donations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return hideAnonymousDonors(donations), nil
+}
+
+// VoidDonation removes a donation from the totals of its batch while the batch is open
+func (r *repository) VoidDonation(ctx context.Context, donationID uuid.UUID, reason string, voidedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model CollectedDonationModel
+		if err := tx.Where("donation_id = ?", donationID).First(&model).Error; err != nil {
+			return err
+		}
+		if err := lockOpenBatch(tx, model.BatchID); err != nil {
+			return err
+		}
+
+		result := tx.Model(&CollectedDonationModel{}).
+			Where("donation_id = ? AND voided_at IS NULL", donationID).
+			Updates(map[string]interface{}{"voided_at": voidedAt, "void_reason": reason})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDonationVoided
+		}
+		return nil
+	})
+}
+
+// SubmitBatch stores the declared total of an open batch
+func (r *repository) SubmitBatch(ctx context.Context, batch Batch) error {
+	return r.updateBatch(ctx, batch.ID, BatchStatusOpen, map[string]interface{}{
+		"status":         string(BatchStatusSubmitted),
+		"declared_total": batch.DeclaredTotal,
+		"submitted_by":   batch.SubmittedBy,
+		"submitted_at":   batch.SubmittedAt,
+		"notes":          batch.Notes,
+	})
+}
+
+// ConfirmBatch stores the counted total of a submitted batch
+func (r *repository) ConfirmBatch(ctx context.Context, batch Batch) error {
+	return r.updateBatch(ctx, batch.ID, BatchStatusSubmitted, map[string]interface{}{
+		"status":        string(BatchStatusConfirmed),
+		"counted_total": batch.CountedTotal,
+		"confirmed_by":  batch.ConfirmedBy,
+		"confirmed_at":  batch.ConfirmedAt,
+		"notes":         batch.Notes,
+	})
+}
+
+// ReopenBatch sends a submitted batch back to its collectors, clearing the submission
+func (r *repository) ReopenBatch(ctx context.Context, id uuid.UUID, notes string) error {
+	return r.updateBatch(ctx, id, BatchStatusSubmitted, map[string]interface{}{
+		"status":         string(BatchStatusOpen),
+		"declared_total": nil,
+		"submitted_by":   nil,
+		"submitted_at":   nil,
+		"notes":          notes,
+	})
+}
+
+func (r *repository) updateBatch(ctx context.Context, id uuid.UUID, from BatchStatus, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).
+		Model(&BatchModel{}).
+		Where("id = ? AND status = ?", id, string(from)).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBatchStatusChanged
+	}
+	return nil
+}
+
+// lockOpenBatch locks a batch until the end of the transaction, so it cannot be submitted while
+// its donations change
+func lockOpenBatch(tx *gorm.DB, batchID uuid.UUID) error {
+	var model BatchModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", batchID).First(&model).Error
+	if err != nil {
+		return err
+	}
+	if model.Status != string(BatchStatusOpen) {
+		return ErrBatchNotOpen
+	}
+	return nil
+}
+
+func hideAnonymousDonors(donations []CollectedDonation) []CollectedDonation {
+	for i := range donations {
+		if donations[i].IsAnonymous {
+			donations[i].DonorFirstName = ""
+			donations[i].DonorLastName = ""
+		}
+	}
+	return donations
+}
+
+func (row batchRow) toEntity() Batch {
+	batch := row.BatchModel.ToEntity()
+	batch.LocationName = row.LocationName
+	batch.LoggedTotal = row.LoggedTotal
+	batch.DonationCount = row.DonationCount
+	return batch
+}
@@ -0,0 +1,385 @@
+package cash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"dona_tutti_api/campaign/alerts"
+	"dona_tutti_api/donation"
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DonationService defines the donation operations needed by the cash collection service
+type DonationService interface {
+	CreateDonationWithRequest(ctx context.Context, campaignID uuid.UUID, req donation.CreateDonationRequest) (uuid.UUID, error)
+	UpdateDonationStatus(ctx context.Context, id uuid.UUID, status donation.DonationStatus, change donation.StatusChange) error
+}
+
+// CampaignService defines the campaign operations needed by the cash collection service
+type CampaignService interface {
+	GetCampaignCurrency(ctx context.Context, campaignID uuid.UUID) (string, error)
+}
+
+// AlertService raises the campaign alerts of cash discrepancies
+type AlertService interface {
+	ReportAlert(ctx context.Context, campaignID uuid.UUID, req alerts.ReportAlertRequest, reportedBy *uuid.UUID) (alerts.Alert, error)
+}
+
+type Service interface {
+	LogDonation(ctx context.Context, campaignID uuid.UUID, locationID int, req LogDonationRequest, collectedBy *uuid.UUID) (CollectedDonation, error)
+	VoidDonation(ctx context.Context, donationID uuid.UUID, req VoidDonationRequest, voidedBy *uuid.UUID) (CollectedDonation, error)
+	GetBatch(ctx context.Context, id uuid.UUID) (Batch, error)
+	ListBatches(ctx context.Context, filter BatchFilter) ([]Batch, error)
+	SubmitBatch(ctx context.Context, id uuid.UUID, req SubmitBatchRequest, submittedBy *uuid.UUID) (Batch, error)
+	ConfirmBatch(ctx context.Context, id uuid.UUID, req ConfirmBatchRequest, confirmedBy *uuid.UUID) (Batch, error)
+	ReopenBatch(ctx context.Context, id uuid.UUID, req ReopenBatchRequest) (Batch, error)
+}
+
+type service struct {
+	repo            Repository
+	donationService DonationService
+	campaignService CampaignService
+	alertService    AlertService
+}
+
+func NewService(repo Repository, donationService DonationService, campaignService CampaignService, alertService AlertService) Service {
+	return &service{
+		repo:            repo,
+		donationService: donationService,
+		campaignService: campaignService,
+		alertService:    alertService,
+	}
+}
+
+// LogDonation registers cash received at a location. The donation stays pending in the batch of
+// the collection day until the batch is confirmed.
+func (s *service) LogDonation(ctx context.Context, campaignID uuid.UUID, locationID int, req LogDonationRequest, collectedBy *uuid.UUID) (CollectedDonation, error) {
+	location, err := s.repo.GetCashLocation(ctx, locationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return CollectedDonation{}, apierrors.NewNotFoundError("cash location not found")
+		}
+		return CollectedDonation{}, fmt.Errorf("failed to get cash location: %w", err)
+	}
+	if location.CampaignID != campaignID {
+		return CollectedDonation{}, apierrors.NewNotFoundError("cash location not found")
+	}
+
+	now := time.Now()
+	collectedAt := now
+	if req.CollectedAt != nil {
+		if req.CollectedAt.After(now) {
+			return CollectedDonation{}, apierrors.NewFieldValidationError("collected_at", "collection time cannot be in the future")
+		}
+		collectedAt = *req.CollectedAt
+	}
+
+	batch, err := s.repo.GetOrCreateBatch(ctx, Batch{
+		ID:             uuid.New(),
+		CampaignID:     campaignID,
+		CashLocationID: locationID,
+		CollectionDate: collectionDay(collectedAt),
+		Status:         BatchStatusOpen,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		return CollectedDonation{}, fmt.Errorf("failed to get collection batch: %w", err)
+	}
+	if batch.Status != BatchStatusOpen {
+		return CollectedDonation{}, apierrors.NewValidationError(
+			fmt.Sprintf("the %s batch of %s was already %s", batch.CollectionDate.Format("2006-01-02"), location.Name, batch.Status))
+	}
+
+	donationID, err := s.donationService.CreateDonationWithRequest(ctx, campaignID, donation.CreateDonationRequest{
+		Amount:          req.Amount,
+		Message:         req.Message,
+		IsAnonymous:     req.Donor == nil,
+		PaymentMethodID: location.PaymentMethodID,
+		Donor:           req.Donor,
+	})
+	if err != nil {
+		return CollectedDonation{}, err
+	}
+
+	collected := CollectedDonation{
+		DonationID:  donationID,
+		BatchID:     batch.ID,
+		CollectedBy: collectedBy,
+		CollectedAt: collectedAt,
+	}
+	if err := s.repo.AddDonation(ctx, collected); err != nil {
+		// The batch was submitted meanwhile: the donation cannot be counted
+		notes := "cash batch was submitted before the donation was logged"
+		change := donation.StatusChange{ChangedBy: collectedBy, Source: donation.StatusSourceCashCollection, Notes: &notes}
+		if statusErr := s.donationService.UpdateDonationStatus(ctx, donationID, donation.DonationStatusFailed, change); statusErr != nil {
+			fmt.Printf("Warning: failed to mark cash donation %s as failed: %v\n", donationID.String(), statusErr)
+		}
+		if errors.Is(err, ErrBatchNotOpen) {
+			return CollectedDonation{}, apierrors.NewValidationError(err.Error())
+		}
+		return CollectedDonation{}, fmt.Errorf("failed to log cash donation: %w", err)
+	}
+
+	return s.repo.GetCollectedDonation(ctx, donationID)
+}
+
+// VoidDonation removes a donation logged by mistake from an open batch. The donation is marked as failed.
+func (s *service) VoidDonation(ctx context.Context, donationID uuid.UUID, req VoidDonationRequest, voidedBy *uuid.UUID) (CollectedDonation, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return CollectedDonation{}, apierrors.NewFieldValidationError("reason", "reason is required to void a donation")
+	}
+
+	if err := s.repo.VoidDonation(ctx, donationID, reason, time.Now()); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return CollectedDonation{}, apierrors.NewNotFoundError("cash donation not found")
+		case errors.Is(err, ErrBatchNotOpen), errors.Is(err, ErrDonationVoided):
+			return CollectedDonation{}, apierrors.NewValidationError(err.Error())
+		}
+		return CollectedDonation{}, fmt.Errorf("failed to void cash donation: %w", err)
+	}
+
+	notes := "voided by collector: " + reason
+	change := donation.StatusChange{ChangedBy: voidedBy, Source: donation.StatusSourceCashCollection, Notes: &notes}
+	if err := s.donationService.UpdateDonationStatus(ctx, donationID, donation.DonationStatusFailed, change); err != nil {
+		return CollectedDonation{}, fmt.Errorf("failed to update donation status: %w", err)
+	}
+
+	return s.repo.GetCollectedDonation(ctx, donationID)
+}
+
+// GetBatch returns a batch with all its logged donations, including the voided ones
+func (s *service) GetBatch(ctx context.Context, id uuid.UUID) (Batch, error) {
+	batch, err := s.getBatch(ctx, id)
+	if err != nil {
+		return Batch{}, err
+	}
+
+	batch.Donations, err = s.repo.ListDonations(ctx, id)
+	if err != nil {
+		return Batch{}, fmt.Errorf("failed to get batch donations: %w", err)
+	}
+
+	return batch, nil
+}
+
+func (s *service) ListBatches(ctx context.Context, filter BatchFilter) ([]Batch, error) {
+	if filter.Status != nil && !IsValidBatchStatus(*filter.Status) {
+		return nil, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid batch status: %s", *filter.Status))
+	}
+
+	batches, err := s.repo.ListBatches(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// SubmitBatch closes the day of a location with the cash the collector hands over. A declared
+// total that differs from the logged donations raises a campaign alert.
+func (s *service) SubmitBatch(ctx context.Context, id uuid.UUID, req SubmitBatchRequest, submittedBy *uuid.UUID) (Batch, error) {
+	if submittedBy == nil {
+		return Batch{}, apierrors.NewValidationError("batches must be submitted by an authenticated user")
+	}
+	if req.DeclaredTotal == nil || *req.DeclaredTotal < 0 {
+		return Batch{}, apierrors.NewFieldValidationError("declared_total", "declared total is required and cannot be negative")
+	}
+
+	batch, err := s.getBatch(ctx, id)
+	if err != nil {
+		return Batch{}, err
+	}
+	if batch.Status != BatchStatusOpen {
+		return Batch{}, apierrors.NewValidationError(fmt.Sprintf("only open batches can be submitted, batch status is %s", batch.Status))
+	}
+	if batch.DonationCount == 0 {
+		return Batch{}, apierrors.NewValidationError("batch has no donations")
+	}
+
+	now := time.Now()
+	batch.DeclaredTotal = req.DeclaredTotal
+	batch.SubmittedBy = submittedBy
+	batch.SubmittedAt = &now
+	batch.Notes = trimNotes(req.Notes)
+	if err := s.repo.SubmitBatch(ctx, batch); err != nil {
+		return Batch{}, s.batchUpdateError(err)
+	}
+
+	if *req.DeclaredTotal != batch.LoggedTotal {
+		s.reportDiscrepancy(ctx, batch, "submitted", *req.DeclaredTotal, submittedBy)
+	}
+
+	return s.GetBatch(ctx, id)
+}
+
+// ConfirmBatch is the second signature of a batch: someone other than the collector who submitted
+// it and the collectors who logged its donations counts the cash, and its donations are completed, which generates their receipts. A counted
+// total that differs from the logged donations raises a campaign alert.
+func (s *service) ConfirmBatch(ctx context.Context, id uuid.UUID, req ConfirmBatchRequest, confirmedBy *uuid.UUID) (Batch, error) {
+	if confirmedBy == nil {
+		return Batch{}, apierrors.NewValidationError("batches must be confirmed by an authenticated user")
+	}
+	if req.CountedTotal == nil || *req.CountedTotal < 0 {
+		return Batch{}, apierrors.NewFieldValidationError("counted_total", "counted total is required and cannot be negative")
+	}
+
+	batch, err := s.getBatch(ctx, id)
+	if err != nil {
+		return Batch{}, err
+	}
+	if batch.Status != BatchStatusSubmitted {
+		return Batch{}, apierrors.NewValidationError(fmt.Sprintf("only submitted batches can be confirmed, batch status is %s", batch.Status))
+	}
+	if batch.SubmittedBy != nil && *batch.SubmittedBy == *confirmedBy {
+		return Batch{}, apierrors.NewValidationError("batch must be confirmed by someone other than the person who submitted it")
+	}
+
+	donations, err := s.repo.ListDonations(ctx, id)
+	if err != nil {
+		return Batch{}, fmt.Errorf("failed to get batch donations: %w", err)
+	}
+	// The donations of a submitted batch cannot change, so this holds until the batch is confirmed
+	for _, d := range donations {
+		if d.CollectedBy != nil && *d.CollectedBy == *confirmedBy {
+			return Batch{}, apierrors.NewValidationError("batch must be confirmed by someone who did not collect any of its donations")
+		}
+	}
+
+	// The batch stays submitted until all of its donations are completed, so a failed confirmation
+	// can be retried. Donations completed by a previous attempt are left as they are.
+	notes := fmt.Sprintf("cash batch %s", batch.ID.String())
+	change := donation.StatusChange{ChangedBy: confirmedBy, Source: donation.StatusSourceCashCollection, Notes: &notes}
+	completed := 0
+	for _, d := range donations {
+		if d.VoidedAt != nil {
+			continue
+		}
+		if err := s.donationService.UpdateDonationStatus(ctx, d.DonationID, donation.DonationStatusCompleted, change); err != nil {
+			return Batch{}, fmt.Errorf("failed to complete cash donation %s, batch is still submitted: %w", d.DonationID.String(), err)
+		}
+		completed++
+	}
+
+	now := time.Now()
+	batch.CountedTotal = req.CountedTotal
+	batch.ConfirmedBy = confirmedBy
+	batch.ConfirmedAt = &now
+	if notes := trimNotes(req.Notes); notes != nil {
+		batch.Notes = notes
+	}
+	if err := s.repo.ConfirmBatch(ctx, batch); err != nil {
+		return Batch{}, s.batchUpdateError(err)
+	}
+	log.Printf("💵 Cash batch %s of %s confirmed: %d donations completed", batch.ID.String(), batch.LocationName, completed)
+
+	if *req.CountedTotal != batch.LoggedTotal {
+		s.reportDiscrepancy(ctx, batch, "counted", *req.CountedTotal, confirmedBy)
+	}
+
+	return s.GetBatch(ctx, id)
+}
+
+// ReopenBatch sends a submitted batch back to its collectors, for instance to void a donation
+// logged by mistake
+func (s *service) ReopenBatch(ctx context.Context, id uuid.UUID, req ReopenBatchRequest) (Batch, error) {
+	notes := strings.TrimSpace(req.Notes)
+	if notes == "" {
+		return Batch{}, apierrors.NewFieldValidationError("notes", "notes are required to reopen a batch")
+	}
+
+	batch, err := s.getBatch(ctx, id)
+	if err != nil {
+		return Batch{}, err
+	}
+	if batch.Status != BatchStatusSubmitted {
+		return Batch{}, apierrors.NewValidationError(fmt.Sprintf("only submitted batches can be reopened, batch status is %s", batch.Status))
+	}
+
+	// A confirmation that failed midway leaves some donations completed; the batch can only be confirmed again
+	donations, err := s.repo.ListDonations(ctx, id)
+	if err != nil {
+		return Batch{}, fmt.Errorf("failed to get batch donations: %w", err)
+	}
+	for _, d := range donations {
+		if d.VoidedAt == nil && d.Status == donation.DonationStatusCompleted {
+			return Batch{}, apierrors.NewValidationError("batch has completed donations, confirm it again instead of reopening it")
+		}
+	}
+
+	if err := s.repo.ReopenBatch(ctx, id, notes); err != nil {
+		return Batch{}, s.batchUpdateError(err)
+	}
+
+	return s.GetBatch(ctx, id)
+}
+
+// reportDiscrepancy raises a campaign alert for a batch whose cash does not match its donations.
+// The batch operation is not undone if the alert cannot be created.
+func (s *service) reportDiscrepancy(ctx context.Context, batch Batch, stage string, total money.Amount, reportedBy *uuid.UUID) {
+	currency, err := s.campaignService.GetCampaignCurrency(ctx, batch.CampaignID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get campaign currency for cash batch %s: %v\n", batch.ID.String(), err)
+	}
+
+	difference := total - batch.LoggedTotal
+	description := fmt.Sprintf("Cash batch %s of %s for %s was %s with %s, but its %d logged donations add up to %s (difference %s).",
+		batch.ID.String(), batch.LocationName, batch.CollectionDate.Format("2006-01-02"), stage,
+		total.Format(currency), batch.DonationCount, batch.LoggedTotal.Format(currency), difference.Format(currency))
+
+	_, err = s.alertService.ReportAlert(ctx, batch.CampaignID, alerts.ReportAlertRequest{
+		AlertType:   alerts.TypeCashDiscrepancy,
+		Description: description,
+		Severity:    alerts.SeverityHigh,
+	}, reportedBy)
+	if err != nil {
+		fmt.Printf("Warning: failed to report discrepancy of cash batch %s: %v\n", batch.ID.String(), err)
+		return
+	}
+	log.Printf("⚠️  Cash discrepancy in batch %s: %s %s, logged %s", batch.ID.String(), stage, total, batch.LoggedTotal)
+}
+
+func (s *service) getBatch(ctx context.Context, id uuid.UUID) (Batch, error) {
+	batch, err := s.repo.GetBatch(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Batch{}, apierrors.NewNotFoundError("cash batch not found")
+		}
+		return Batch{}, fmt.Errorf("failed to get cash batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+func (s *service) batchUpdateError(err error) error {
+	if errors.Is(err, ErrBatchStatusChanged) {
+		return apierrors.NewValidationError(err.Error())
+	}
+	return fmt.Errorf("failed to update cash batch: %w", err)
+}
+
+// collectionDay returns the calendar day a donation was collected on, which identifies its batch
+func collectionDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func trimNotes(notes *string) *string {
+	if notes == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*notes)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
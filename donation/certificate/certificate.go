@@ -0,0 +1,92 @@
+package certificate
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// FirstFiscalYear is the earliest fiscal year certificates can be issued for
+const FirstFiscalYear = 2000
+
+// Line is the money a donor gave to one campaign during a fiscal year, net of refunds
+type Line struct {
+	CampaignID    uuid.UUID    `json:"campaign_id"`
+	CampaignTitle string       `json:"campaign_title"`
+	OrganizerID   uuid.UUID    `json:"organizer_id"`
+	OrganizerName string       `json:"organizer_name"`
+	Currency      string       `json:"currency"`
+	Amount        money.Amount `json:"amount"`
+	DonationCount int          `json:"donation_count"`
+}
+
+// Total is the money a donor gave during a fiscal year in one currency, net of refunds
+type Total struct {
+	Currency string       `json:"currency"`
+	Amount   money.Amount `json:"amount"`
+}
+
+// Certificate summarizes the completed donations of a donor during a fiscal year, for tax deduction.
+// Certificates are never edited: issuing one again after its donations changed supersedes it.
+type Certificate struct {
+	ID               uuid.UUID  `json:"id"`
+	DonorID          uuid.UUID  `json:"donor_id"`
+	FiscalYear       int        `json:"fiscal_year"`
+	DonorName        string     `json:"donor_name"`
+	Lines            []Line     `json:"lines"` // One per campaign and currency
+	Totals           []Total    `json:"totals"`
+	DonationCount    int        `json:"donation_count"`
+	VerificationHash string     `json:"verification_hash"`
+	PDFURL           string     `json:"pdf_url"`
+	IssuedBy         *uuid.UUID `json:"issued_by,omitempty"`
+	SupersededAt     *time.Time `json:"superseded_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// IsCurrent reports whether the certificate was not superseded by a newer one
+func (c Certificate) IsCurrent() bool {
+	return c.SupersededAt == nil
+}
+
+// Verification is what anyone holding a certificate can check with its verification hash
+type Verification struct {
+	VerificationHash string     `json:"verification_hash"`
+	Valid            bool       `json:"valid"` // False once the certificate was superseded
+	FiscalYear       int        `json:"fiscal_year"`
+	DonorName        string     `json:"donor_name"`
+	Totals           []Total    `json:"totals"`
+	DonationCount    int        `json:"donation_count"`
+	IssuedAt         time.Time  `json:"issued_at"`
+	SupersededAt     *time.Time `json:"superseded_at,omitempty"`
+}
+
+// IssueRequest represents a request for the certificate of a fiscal year
+type IssueRequest struct {
+	FiscalYear int `json:"fiscal_year" validate:"required"`
+}
+
+type BatchStatus string
+
+const (
+	BatchStatusRunning   BatchStatus = "running"
+	BatchStatusCompleted BatchStatus = "completed"
+	BatchStatusFailed    BatchStatus = "failed"
+)
+
+// Batch is a year-end run that issues the certificates of every donor. It runs in the background and
+// is updated when it finishes.
+type Batch struct {
+	ID         uuid.UUID         `json:"id"`
+	FiscalYear int               `json:"fiscal_year"`
+	Status     BatchStatus       `json:"status"`
+	Donors     int               `json:"donors"`    // Donors with completed donations during the year
+	Issued     []uuid.UUID       `json:"issued"`    // Certificates issued or reissued
+	Unchanged  int               `json:"unchanged"` // Donors whose current certificate is up to date
+	Failed     map[string]string `json:"failed,omitempty"`
+	Error      *string           `json:"error,omitempty"` // Why a failed batch stopped
+	StartedBy  *uuid.UUID        `json:"started_by,omitempty"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+}
@@ -0,0 +1,241 @@
+package certificate
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests for tax certificates
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new tax certificate handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the tax certificate routes. Users request the certificates of their linked
+// donors, admins those of any donor and the year-end batches, and anyone can verify a certificate.
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	g.GET("/tax-certificates/verify/:hash", h.VerifyCertificate)
+
+	meGroup := g.Group("/me", authMiddleware)
+	meGroup.POST("/tax-certificates", h.IssueMyCertificates)
+	meGroup.GET("/tax-certificates", h.ListMyCertificates)
+
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.POST("/donors/:id/tax-certificates", h.IssueCertificate)
+	adminGroup.GET("/donors/:id/tax-certificates", h.ListCertificates)
+	adminGroup.POST("/tax-certificates/batch", h.StartYearEndBatch)
+	adminGroup.GET("/tax-certificates/batch/:id", h.GetBatch)
+}
+
+// @Summary Request my tax certificates
+// @Description Issue the annual tax certificates of the donors linked to the current user for a fiscal year that already ended. A certificate that is still up to date is returned as is; one whose donations changed is superseded by a new one.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body IssueRequest true "Fiscal year"
+// @Success 200 {array} Certificate
+// @Failure 400 {object} errors.APIError
+// @Failure 401 {object} errors.APIError
+// @Router /me/tax-certificates [post]
+func (h *Handler) IssueMyCertificates(c echo.Context) error {
+	userID := getUserID(c)
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	var req IssueRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	certificates, err := h.service.IssueForUser(c.Request().Context(), *userID, req.FiscalYear)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, certificates)
+}
+
+// @Summary List my tax certificates
+// @Description Get the current annual tax certificates of the donors linked to the current user, most recent fiscal year first
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} Certificate
+// @Failure 401 {object} errors.APIError
+// @Router /me/tax-certificates [get]
+func (h *Handler) ListMyCertificates(c echo.Context) error {
+	userID := getUserID(c)
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	certificates, err := h.service.ListUserCertificates(c.Request().Context(), *userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, certificates)
+}
+
+// @Summary Issue a donor tax certificate
+// @Description Issue the annual tax certificate of a donor for a fiscal year that already ended, with its completed donations net of refunds by organizer and campaign
+// @Tags donors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Donor ID"
+// @Param request body IssueRequest true "Fiscal year"
+// @Success 200 {object} Certificate
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /donors/{id}/tax-certificates [post]
+func (h *Handler) IssueCertificate(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donor ID")
+	}
+
+	var req IssueRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	certificate, err := h.service.IssueCertificate(c.Request().Context(), id, req.FiscalYear, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, certificate)
+}
+
+// @Summary List donor tax certificates
+// @Description Get the current annual tax certificates of a donor, most recent fiscal year first
+// @Tags donors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Donor ID"
+// @Success 200 {array} Certificate
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /donors/{id}/tax-certificates [get]
+func (h *Handler) ListCertificates(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donor ID")
+	}
+
+	certificates, err := h.service.ListCertificates(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, certificates)
+}
+
+// @Summary Start the year-end tax certificate batch
+// @Description Start issuing in the background the annual tax certificates of every donor with completed donations during a fiscal year that already ended. Up to date certificates are kept, so the batch can be run again after late refunds. Follow its progress with the batch ID.
+// @Tags tax-certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body IssueRequest true "Fiscal year"
+// @Success 202 {object} Batch
+// @Failure 400 {object} errors.APIError
+// @Router /tax-certificates/batch [post]
+func (h *Handler) StartYearEndBatch(c echo.Context) error {
+	var req IssueRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	batch, err := h.service.StartYearEndBatch(c.Request().Context(), req.FiscalYear, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusAccepted, batch)
+}
+
+// @Summary Get a tax certificate batch
+// @Description Get the status of a year-end tax certificate batch, with its results once it finished
+// @Tags tax-certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Success 200 {object} Batch
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /tax-certificates/batch/{id} [get]
+func (h *Handler) GetBatch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid batch ID")
+	}
+
+	batch, err := h.service.GetBatch(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+// @Summary Verify a tax certificate
+// @Description Check a tax certificate with the verification hash printed on it. Superseded certificates are reported as not valid.
+// @Tags tax-certificates
+// @Accept json
+// @Produce json
+// @Param hash path string true "Verification hash"
+// @Success 200 {object} Verification
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /tax-certificates/verify/{hash} [get]
+func (h *Handler) VerifyCertificate(c echo.Context) error {
+	verification, err := h.service.VerifyCertificate(c.Request().Context(), c.Param("hash"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, verification)
+}
+
+// errorStatus maps a tax certificate service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,202 @@
+package certificate
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LinesJSON for JSONB in PostgreSQL
+type LinesJSON []Line
+
+// Value implements the driver.Valuer interface
+func (l LinesJSON) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Scan implements the sql.Scanner interface
+func (l *LinesJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, l)
+}
+
+// TotalsJSON for JSONB in PostgreSQL
+type TotalsJSON []Total
+
+// Value implements the driver.Valuer interface
+func (t TotalsJSON) Value() (driver.Value, error) {
+	return json.Marshal(t)
+}
+
+// Scan implements the sql.Scanner interface
+func (t *TotalsJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, t)
+}
+
+// CertificateModel represents the database table structure with GORM tags
+type CertificateModel struct {
+	ID               uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	DonorID          uuid.UUID  `gorm:"column:donor_id;type:uuid;not null"`
+	FiscalYear       int        `gorm:"column:fiscal_year;not null"`
+	DonorName        string     `gorm:"column:donor_name;type:varchar(511);not null"`
+	Lines            LinesJSON  `gorm:"column:lines;type:jsonb;not null"`
+	Totals           TotalsJSON `gorm:"column:totals;type:jsonb;not null"`
+	DonationCount    int        `gorm:"column:donation_count;not null"`
+	VerificationHash string     `gorm:"column:verification_hash;type:varchar(64);not null;uniqueIndex"`
+	PDFURL           string     `gorm:"column:pdf_url;type:varchar(500);not null"`
+	IssuedBy         *uuid.UUID `gorm:"column:issued_by;type:uuid"`
+	SupersededAt     *time.Time `gorm:"column:superseded_at"`
+	CreatedAt        time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (CertificateModel) TableName() string {
+	return "tax_certificates"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m CertificateModel) ToEntity() Certificate {
+	return Certificate{
+		ID:               m.ID,
+		DonorID:          m.DonorID,
+		FiscalYear:       m.FiscalYear,
+		DonorName:        m.DonorName,
+		Lines:            []Line(m.Lines),
+		Totals:           []Total(m.Totals),
+		DonationCount:    m.DonationCount,
+		VerificationHash: m.VerificationHash,
+		PDFURL:           m.PDFURL,
+		IssuedBy:         m.IssuedBy,
+		SupersededAt:     m.SupersededAt,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *CertificateModel) FromEntity(entity Certificate) {
+	m.ID = entity.ID
+	m.DonorID = entity.DonorID
+	m.FiscalYear = entity.FiscalYear
+	m.DonorName = entity.DonorName
+	m.Lines = LinesJSON(entity.Lines)
+	m.Totals = TotalsJSON(entity.Totals)
+	m.DonationCount = entity.DonationCount
+	m.VerificationHash = entity.VerificationHash
+	m.PDFURL = entity.PDFURL
+	m.IssuedBy = entity.IssuedBy
+	m.SupersededAt = entity.SupersededAt
+	m.CreatedAt = entity.CreatedAt
+}
+
+// IssuedJSON for JSONB in PostgreSQL
+type IssuedJSON []uuid.UUID
+
+// Value implements the driver.Valuer interface
+func (i IssuedJSON) Value() (driver.Value, error) {
+	if i == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(i)
+}
+
+// Scan implements the sql.Scanner interface
+func (i *IssuedJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, i)
+}
+
+// FailedJSON for JSONB in PostgreSQL
+type FailedJSON map[string]string
+
+// Value implements the driver.Valuer interface
+func (f FailedJSON) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface
+func (f *FailedJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+// BatchModel represents the database table structure with GORM tags
+type BatchModel struct {
+	ID         uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	FiscalYear int        `gorm:"column:fiscal_year;not null"`
+	Status     string     `gorm:"column:status;type:varchar(20);not null"`
+	Donors     int        `gorm:"column:donors;not null"`
+	Issued     IssuedJSON `gorm:"column:issued;type:jsonb;not null"`
+	Unchanged  int        `gorm:"column:unchanged;not null"`
+	Failed     FailedJSON `gorm:"column:failed;type:jsonb;not null"`
+	Error      *string    `gorm:"column:error;type:text"`
+	StartedBy  *uuid.UUID `gorm:"column:started_by;type:uuid"`
+	StartedAt  time.Time  `gorm:"column:started_at"`
+	FinishedAt *time.Time `gorm:"column:finished_at"`
+}
+
+// TableName specifies the table name for GORM
+func (BatchModel) TableName() string {
+	return "tax_certificate_batches"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m BatchModel) ToEntity() Batch {
+	return Batch{
+		ID:         m.ID,
+		FiscalYear: m.FiscalYear,
+		Status:     BatchStatus(m.Status),
+		Donors:     m.Donors,
+		Issued:     []uuid.UUID(m.Issued),
+		Unchanged:  m.Unchanged,
+		Failed:     map[string]string(m.Failed),
+		Error:      m.Error,
+		StartedBy:  m.StartedBy,
+		StartedAt:  m.StartedAt,
+		FinishedAt: m.FinishedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *BatchModel) FromEntity(entity Batch) {
+	m.ID = entity.ID
+	m.FiscalYear = entity.FiscalYear
+	m.Status = string(entity.Status)
+	m.Donors = entity.Donors
+	m.Issued = IssuedJSON(entity.Issued)
+	m.Unchanged = entity.Unchanged
+	m.Failed = FailedJSON(entity.Failed)
+	m.Error = entity.Error
+	m.StartedBy = entity.StartedBy
+	m.StartedAt = entity.StartedAt
+	m.FinishedAt = entity.FinishedAt
+}
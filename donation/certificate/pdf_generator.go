@@ -0,0 +1,151 @@
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// CertificateData contains all the information needed to generate a tax certificate
+type CertificateData struct {
+	CertificateID    uuid.UUID
+	FiscalYear       int
+	DonorName        string
+	Lines            []Line
+	Totals           []Total
+	DonationCount    int
+	VerificationHash string
+	IssuedAt         time.Time
+}
+
+// PDFGenerator defines the interface for generating PDF tax certificates
+type PDFGenerator interface {
+	Generate(data CertificateData) ([]byte, error)
+}
+
+// pdfGenerator implements the PDFGenerator interface
+type pdfGenerator struct{}
+
+// NewPDFGenerator creates a new PDF generator
+func NewPDFGenerator() PDFGenerator {
+	return &pdfGenerator{}
+}
+
+// Generate creates a PDF tax certificate and returns the PDF bytes
+func (g *pdfGenerator) Generate(data CertificateData) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont("Arial", "B", 24)
+	pdf.SetTextColor(41, 128, 185) // Blue color
+	pdf.CellFormat(190, 15, "DONA TUTTI", "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Arial", "", 12)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(190, 8, "Plataforma de Donaciones", "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	pdf.SetFont("Arial", "B", 18)
+	pdf.CellFormat(190, 10, "CERTIFICADO ANUAL DE DONACIONES", "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 12)
+	pdf.CellFormat(190, 8, fmt.Sprintf("Ejercicio fiscal %d", data.FiscalYear), "", 1, "C", false, 0, "")
+	pdf.Ln(6)
+
+	pdf.SetLineWidth(0.5)
+	pdf.Line(10, pdf.GetY(), 200, pdf.GetY())
+	pdf.Ln(8)
+
+	// Certificate details
+	g.addField(pdf, "Numero de Certificado:", data.CertificateID.String())
+	g.addField(pdf, "Donante:", data.DonorName)
+	g.addField(pdf, "Periodo:", fmt.Sprintf("01/01/%d - 31/12/%d", data.FiscalYear, data.FiscalYear))
+	g.addField(pdf, "Donaciones:", fmt.Sprintf("%d", data.DonationCount))
+	pdf.Ln(4)
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.MultiCell(190, 6, "Se certifica que el donante realizo las siguientes donaciones completadas durante el ejercicio fiscal, netas de reembolsos, detalladas por organizador y campana.", "", "L", false)
+	pdf.Ln(4)
+
+	// Donations by organizer and campaign
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(240, 240, 240)
+	pdf.CellFormat(55, 8, "Organizador", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(75, 8, "Campana", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(20, 8, "Donac.", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(40, 8, "Monto", "1", 1, "R", true, 0, "")
+
+	pdf.SetFont("Arial", "", 9)
+	for _, line := range data.Lines {
+		pdf.CellFormat(55, 7, truncate(line.OrganizerName, 32), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(75, 7, truncate(line.CampaignTitle, 45), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(20, 7, fmt.Sprintf("%d", line.DonationCount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(40, 7, line.Amount.Format(line.Currency), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(6)
+
+	// Totals by currency (highlighted)
+	pdf.SetFillColor(240, 240, 240)
+	for _, total := range data.Totals {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(110, 10, fmt.Sprintf("Total donado (%s):", total.Currency), "", 0, "L", true, 0, "")
+		pdf.SetTextColor(46, 204, 113) // Green color for amount
+		pdf.CellFormat(80, 10, total.Amount.Format(total.Currency), "", 1, "R", true, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+	pdf.Ln(8)
+
+	// Verification
+	pdf.SetLineWidth(0.2)
+	pdf.Line(10, pdf.GetY(), 200, pdf.GetY())
+	pdf.Ln(6)
+
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(190, 7, "Codigo de verificacion:")
+	pdf.Ln(7)
+	pdf.SetFont("Courier", "", 9)
+	pdf.Cell(190, 6, data.VerificationHash)
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "", 9)
+	pdf.MultiCell(190, 5, "La autenticidad de este certificado puede verificarse en Dona Tutti con el codigo de verificacion. Un certificado reemplazado por uno posterior del mismo ejercicio deja de ser valido.", "", "L", false)
+
+	// Footer
+	pdf.SetY(-35)
+	pdf.SetLineWidth(0.2)
+	pdf.Line(10, pdf.GetY(), 200, pdf.GetY())
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "I", 8)
+	pdf.SetTextColor(128, 128, 128) // Grey color
+	pdf.CellFormat(190, 5, "Documento generado automaticamente por Dona Tutti", "", 1, "C", false, 0, "")
+	pdf.CellFormat(190, 5, fmt.Sprintf("Fecha de emision: %s", data.IssuedAt.Format("02/01/2006 15:04:05")), "", 1, "C", false, 0, "")
+	pdf.CellFormat(190, 5, "Este certificado es valido como constancia de donaciones para deduccion impositiva", "", 1, "C", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *pdfGenerator) addField(pdf *gofpdf.Fpdf, label, value string) {
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(60, 7, label)
+	pdf.SetFont("Arial", "", 11)
+	pdf.Cell(130, 7, value)
+	pdf.Ln(7)
+}
+
+// truncate shortens a text to fit a table cell
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-3]) + "..."
+}
@@ -0,0 +1,208 @@
+package certificate
+
+import (
+	"context"
+	"time"
+
+	"dona_tutti_api/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Donor is the donor a certificate is issued to
+type Donor struct {
+	ID        uuid.UUID
+	FirstName string
+	LastName  string
+	ErasedAt  *time.Time
+}
+
+type Repository interface {
+	GetDonor(ctx context.Context, donorID uuid.UUID) (Donor, error)
+	ListLinkedDonors(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ListDonorsWithDonations(ctx context.Context, fiscalYear int) ([]uuid.UUID, error)
+	ListLines(ctx context.Context, donorID uuid.UUID, fiscalYear int) ([]Line, error)
+	GetCurrentCertificate(ctx context.Context, donorID uuid.UUID, fiscalYear int) (Certificate, error)
+	GetCertificateByHash(ctx context.Context, hash string) (Certificate, error)
+	ListCertificates(ctx context.Context, donorIDs []uuid.UUID) ([]Certificate, error)
+	IssueCertificate(ctx context.Context, certificate Certificate) error
+	TryAdvisoryLock(ctx context.Context, key int64) (release func(), acquired bool, err error)
+	CreateBatch(ctx context.Context, batch Batch) error
+	FinishBatch(ctx context.Context, batch Batch) error
+	GetBatch(ctx context.Context, id uuid.UUID) (Batch, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetDonor(ctx context.Context, donorID uuid.UUID) (Donor, error) {
+	var donors []Donor
+	err := r.db.WithContext(ctx).
+		Table("donors").
+		Select("id, first_name, last_name, erased_at").
+		Where("id = ?", donorID).
+		Scan(&donors).Error
+	if err != nil {
+		return Donor{}, err
+	}
+	if len(donors) == 0 {
+		return Donor{}, gorm.ErrRecordNotFound
+	}
+	return donors[0], nil
+}
+
+// ListLinkedDonors returns the donors linked to a user account
+func (r *repository) ListLinkedDonors(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var donorIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Table("donors").
+		Where("user_id = ? AND erased_at IS NULL", userID).
+		Order("created_at ASC").
+		Pluck("id", &donorIDs).Error
+	return donorIDs, err
+}
+
+// ListDonorsWithDonations returns the donors that are not erased and have certifiable donations
+// during a fiscal year
+func (r *repository) ListDonorsWithDonations(ctx context.Context, fiscalYear int) ([]uuid.UUID, error) {
+	var donorIDs []uuid.UUID
+	err := r.certifiableDonations(ctx, fiscalYear).
+		Joins("JOIN donors dn ON dn.id = d.donor_id").
+		Where("dn.erased_at IS NULL").
+		Distinct("d.donor_id").
+		Pluck("d.donor_id", &donorIDs).Error
+	return donorIDs, err
+}
+
+// certifiableDonations selects the completed donations of a fiscal year with money left after refunds
+func (r *repository) certifiableDonations(ctx context.Context, fiscalYear int) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("donations d").
+		Where("d.status = 'completed' AND d.amount - d.refunded_amount > 0").
+		Where("EXTRACT(YEAR FROM d.date) = ?", fiscalYear)
+}
+
+// ListLines returns the money a donor gave to each campaign during a fiscal year, ordered by organizer
+// and campaign
+func (r *repository) ListLines(ctx context.Context, donorID uuid.UUID, fiscalYear int) ([]Line, error) {
+	var lines []Line
+	err := r.certifiableDonations(ctx, fiscalYear).
+		Select(`d.campaign_id, c.title AS campaign_title, c.organizer_id, o.name AS organizer_name,
+			d.currency, SUM(d.amount - d.refunded_amount) AS amount, COUNT(*) AS donation_count`).
+		Joins("JOIN campaigns c ON c.id = d.campaign_id").
+		Joins("JOIN organizers o ON o.id = c.organizer_id").
+		Where("d.donor_id = ?", donorID).
+		Group("d.campaign_id, c.title, c.organizer_id, o.name, d.currency").
+		Order("o.name ASC, c.title ASC, d.campaign_id ASC, d.currency ASC").
+		Scan(&lines).Error
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func (r *repository) GetCurrentCertificate(ctx context.Context, donorID uuid.UUID, fiscalYear int) (Certificate, error) {
+	var model CertificateModel
+	err := r.db.WithContext(ctx).
+		Where("donor_id = ? AND fiscal_year = ? AND superseded_at IS NULL", donorID, fiscalYear).
+		First(&model).Error
+	if err != nil {
+		return Certificate{}, err
+	}
+	return model.ToEntity(), nil
+}
+
+func (r *repository) GetCertificateByHash(ctx context.Context, hash string) (Certificate, error) {
+	var model CertificateModel
+	if err := r.db.WithContext(ctx).Where("verification_hash = ?", hash).First(&model).Error; err != nil {
+		return Certificate{}, err
+	}
+	return model.ToEntity(), nil
+}
+
+// ListCertificates returns the current certificates of the donors, most recent fiscal year first
+func (r *repository) ListCertificates(ctx context.Context, donorIDs []uuid.UUID) ([]Certificate, error) {
+	var models []CertificateModel
+	err := r.db.WithContext(ctx).
+		Where("donor_id IN ? AND superseded_at IS NULL", donorIDs).
+		Order("fiscal_year DESC, created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := make([]Certificate, len(models))
+	for i, model := range models {
+		certificates[i] = model.ToEntity()
+	}
+	return certificates, nil
+}
+
+// IssueCertificate stores a certificate, superseding the current certificate of the donor for the
+// same fiscal year
+func (r *repository) IssueCertificate(ctx context.Context, certificate Certificate) error {
+	var model CertificateModel
+	model.FromEntity(certificate)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&CertificateModel{}).
+			Where("donor_id = ? AND fiscal_year = ? AND superseded_at IS NULL", certificate.DonorID, certificate.FiscalYear).
+			Update("superseded_at", certificate.CreatedAt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&model).Error
+	})
+}
+
+// TryAdvisoryLock takes a Postgres advisory lock on a dedicated connection, see database.TryAdvisoryLock
+func (r *repository) TryAdvisoryLock(ctx context.Context, key int64) (func(), bool, error) {
+	return database.TryAdvisoryLock(ctx, r.db.WithContext(ctx), key)
+}
+
+// CreateBatch stores a batch that starts running. It must be called while holding the batch lock, so
+// batches still marked as running were interrupted and are marked as failed.
+func (r *repository) CreateBatch(ctx context.Context, batch Batch) error {
+	var model BatchModel
+	model.FromEntity(batch)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&BatchModel{}).
+			Where("status = ?", string(BatchStatusRunning)).
+			Updates(map[string]interface{}{
+				"status":      string(BatchStatusFailed),
+				"error":       "interrupted before finishing",
+				"finished_at": batch.StartedAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&model).Error
+	})
+}
+
+// FinishBatch stores the results of a batch
+func (r *repository) FinishBatch(ctx context.Context, batch Batch) error {
+	var model BatchModel
+	model.FromEntity(batch)
+
+	return r.db.WithContext(ctx).
+		Model(&BatchModel{}).
+		Where("id = ?", batch.ID).
+		Select("status", "donors", "issued", "unchanged", "failed", "error", "finished_at").
+		Updates(&model).Error
+}
+
+func (r *repository) GetBatch(ctx context.Context, id uuid.UUID) (Batch, error) {
+	var model BatchModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return Batch{}, err
+	}
+	return model.ToEntity(), nil
+}
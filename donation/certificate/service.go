@@ -0,0 +1,367 @@
+package certificate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/s3client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BatchLockKey is the Postgres advisory lock key held while the year-end batch runs,
+// so that only one batch issues certificates at a time
+const BatchLockKey int64 = 4815162344
+
+// errNoDonations is returned when a donor has nothing to certify in a fiscal year
+var errNoDonations = apierrors.NewValidationError("no completed donations in fiscal year")
+
+type Service interface {
+	IssueCertificate(ctx context.Context, donorID uuid.UUID, fiscalYear int, issuedBy *uuid.UUID) (Certificate, error)
+	IssueForUser(ctx context.Context, userID uuid.UUID, fiscalYear int) ([]Certificate, error)
+	ListCertificates(ctx context.Context, donorID uuid.UUID) ([]Certificate, error)
+	ListUserCertificates(ctx context.Context, userID uuid.UUID) ([]Certificate, error)
+	VerifyCertificate(ctx context.Context, hash string) (Verification, error)
+	StartYearEndBatch(ctx context.Context, fiscalYear int, startedBy *uuid.UUID) (Batch, error)
+	GetBatch(ctx context.Context, id uuid.UUID) (Batch, error)
+}
+
+type service struct {
+	repo         Repository
+	pdfGenerator PDFGenerator
+	s3Client     *s3client.Client
+}
+
+// NewService creates the tax certificate service. Certificates cannot be issued without an S3 client.
+func NewService(repo Repository, pdfGenerator PDFGenerator, s3Client *s3client.Client) Service {
+	return &service{
+		repo:         repo,
+		pdfGenerator: pdfGenerator,
+		s3Client:     s3Client,
+	}
+}
+
+// IssueCertificate returns the current certificate of a donor for a fiscal year, issuing a new one
+// when there is none or when the donations of the year changed since it was issued
+func (s *service) IssueCertificate(ctx context.Context, donorID uuid.UUID, fiscalYear int, issuedBy *uuid.UUID) (Certificate, error) {
+	if err := validateFiscalYear(fiscalYear); err != nil {
+		return Certificate{}, err
+	}
+
+	certificate, _, err := s.issue(ctx, donorID, fiscalYear, issuedBy)
+	return certificate, err
+}
+
+// IssueForUser issues the certificates of the donors linked to a user for a fiscal year
+func (s *service) IssueForUser(ctx context.Context, userID uuid.UUID, fiscalYear int) ([]Certificate, error) {
+	if err := validateFiscalYear(fiscalYear); err != nil {
+		return nil, err
+	}
+
+	donorIDs, err := s.repo.ListLinkedDonors(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked donors: %w", err)
+	}
+	if len(donorIDs) == 0 {
+		return nil, apierrors.NewValidationError("no donor is linked to this account")
+	}
+
+	certificates := []Certificate{}
+	for _, donorID := range donorIDs {
+		certificate, _, err := s.issue(ctx, donorID, fiscalYear, &userID)
+		if err != nil {
+			if errors.Is(err, errNoDonations) {
+				continue
+			}
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, errNoDonations
+	}
+
+	return certificates, nil
+}
+
+func (s *service) ListCertificates(ctx context.Context, donorID uuid.UUID) ([]Certificate, error) {
+	if _, err := s.getDonor(ctx, donorID); err != nil {
+		return nil, err
+	}
+
+	certificates, err := s.repo.ListCertificates(ctx, []uuid.UUID{donorID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	return certificates, nil
+}
+
+// ListUserCertificates returns the current certificates of the donors linked to a user
+func (s *service) ListUserCertificates(ctx context.Context, userID uuid.UUID) ([]Certificate, error) {
+	donorIDs, err := s.repo.ListLinkedDonors(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked donors: %w", err)
+	}
+	if len(donorIDs) == 0 {
+		return []Certificate{}, nil
+	}
+
+	certificates, err := s.repo.ListCertificates(ctx, donorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	return certificates, nil
+}
+
+// VerifyCertificate looks up a certificate by its verification hash. Superseded certificates are
+// found but reported as not valid.
+func (s *service) VerifyCertificate(ctx context.Context, hash string) (Verification, error) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if hash == "" {
+		return Verification{}, apierrors.NewFieldValidationError("hash", "verification hash is required")
+	}
+
+	certificate, err := s.repo.GetCertificateByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Verification{}, apierrors.NewNotFoundError("certificate not found")
+		}
+		return Verification{}, fmt.Errorf("failed to get certificate: %w", err)
+	}
+
+	return Verification{
+		VerificationHash: certificate.VerificationHash,
+		Valid:            certificate.IsCurrent(),
+		FiscalYear:       certificate.FiscalYear,
+		DonorName:        certificate.DonorName,
+		Totals:           certificate.Totals,
+		DonationCount:    certificate.DonationCount,
+		IssuedAt:         certificate.CreatedAt,
+		SupersededAt:     certificate.SupersededAt,
+	}, nil
+}
+
+// StartYearEndBatch starts issuing in the background the certificates of every donor with completed
+// donations during a fiscal year, and returns the running batch. Donors whose current certificate is up
+// to date are left alone, so the batch can be run again after late refunds or corrections.
+func (s *service) StartYearEndBatch(ctx context.Context, fiscalYear int, startedBy *uuid.UUID) (Batch, error) {
+	if err := validateFiscalYear(fiscalYear); err != nil {
+		return Batch{}, err
+	}
+
+	release, acquired, err := s.repo.TryAdvisoryLock(ctx, BatchLockKey)
+	if err != nil {
+		return Batch{}, fmt.Errorf("failed to lock certificate batch: %w", err)
+	}
+	if !acquired {
+		return Batch{}, apierrors.NewValidationError("a certificate batch is already running")
+	}
+
+	batch := Batch{
+		ID:         uuid.New(),
+		FiscalYear: fiscalYear,
+		Status:     BatchStatusRunning,
+		Issued:     []uuid.UUID{},
+		Failed:     map[string]string{},
+		StartedBy:  startedBy,
+		StartedAt:  time.Now(),
+	}
+	if err := s.repo.CreateBatch(ctx, batch); err != nil {
+		release()
+		return Batch{}, fmt.Errorf("failed to store certificate batch: %w", err)
+	}
+
+	// The batch outlives the request that started it
+	go func() {
+		defer release()
+		s.runBatch(context.Background(), batch)
+	}()
+
+	return batch, nil
+}
+
+func (s *service) GetBatch(ctx context.Context, id uuid.UUID) (Batch, error) {
+	batch, err := s.repo.GetBatch(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Batch{}, apierrors.NewNotFoundError("certificate batch not found")
+		}
+		return Batch{}, fmt.Errorf("failed to get certificate batch: %w", err)
+	}
+	return batch, nil
+}
+
+// runBatch issues the certificates of a batch and stores its results
+func (s *service) runBatch(ctx context.Context, batch Batch) {
+	batch.Status = BatchStatusCompleted
+
+	donorIDs, err := s.repo.ListDonorsWithDonations(ctx, batch.FiscalYear)
+	if err != nil {
+		message := fmt.Sprintf("failed to list donors: %v", err)
+		batch.Status = BatchStatusFailed
+		batch.Error = &message
+	}
+	batch.Donors = len(donorIDs)
+
+	for _, donorID := range donorIDs {
+		certificate, issued, err := s.issue(ctx, donorID, batch.FiscalYear, batch.StartedBy)
+		if err != nil {
+			batch.Failed[donorID.String()] = err.Error()
+			continue
+		}
+		if issued {
+			batch.Issued = append(batch.Issued, certificate.ID)
+		} else {
+			batch.Unchanged++
+		}
+	}
+
+	finishedAt := time.Now()
+	batch.FinishedAt = &finishedAt
+	if err := s.repo.FinishBatch(ctx, batch); err != nil {
+		log.Printf("❌ Failed to store tax certificate batch %s: %v", batch.ID.String(), err)
+	}
+
+	log.Printf("📜 Tax certificates for %d: %d issued, %d unchanged, %d failed",
+		batch.FiscalYear, len(batch.Issued), batch.Unchanged, len(batch.Failed))
+}
+
+// issue returns the current certificate of a donor for a fiscal year, and whether it had to be issued
+func (s *service) issue(ctx context.Context, donorID uuid.UUID, fiscalYear int, issuedBy *uuid.UUID) (Certificate, bool, error) {
+	donor, err := s.getDonor(ctx, donorID)
+	if err != nil {
+		return Certificate{}, false, err
+	}
+	if donor.ErasedAt != nil {
+		return Certificate{}, false, apierrors.NewValidationError("donor data was erased")
+	}
+
+	lines, err := s.repo.ListLines(ctx, donorID, fiscalYear)
+	if err != nil {
+		return Certificate{}, false, fmt.Errorf("failed to list donations: %w", err)
+	}
+	if len(lines) == 0 {
+		return Certificate{}, false, errNoDonations
+	}
+
+	donorName := strings.TrimSpace(fmt.Sprintf("%s %s", donor.FirstName, donor.LastName))
+	content := fingerprint(donorName, fiscalYear, lines)
+
+	current, err := s.repo.GetCurrentCertificate(ctx, donorID, fiscalYear)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return Certificate{}, false, fmt.Errorf("failed to get current certificate: %w", err)
+	}
+	if err == nil && content == certificateFingerprint(current) {
+		return current, false, nil
+	}
+
+	if s.s3Client == nil {
+		return Certificate{}, false, fmt.Errorf("cannot issue certificate: S3 client not configured")
+	}
+
+	certificate := Certificate{
+		ID:         uuid.New(),
+		DonorID:    donorID,
+		FiscalYear: fiscalYear,
+		DonorName:  donorName,
+		Lines:      lines,
+		Totals:     totals(lines),
+		IssuedBy:   issuedBy,
+		CreatedAt:  time.Now(),
+	}
+	for _, line := range lines {
+		certificate.DonationCount += line.DonationCount
+	}
+	hash := sha256.Sum256([]byte(certificate.ID.String() + "\n" + content))
+	certificate.VerificationHash = hex.EncodeToString(hash[:])
+
+	pdfBytes, err := s.pdfGenerator.Generate(CertificateData{
+		CertificateID:    certificate.ID,
+		FiscalYear:       certificate.FiscalYear,
+		DonorName:        certificate.DonorName,
+		Lines:            certificate.Lines,
+		Totals:           certificate.Totals,
+		DonationCount:    certificate.DonationCount,
+		VerificationHash: certificate.VerificationHash,
+		IssuedAt:         certificate.CreatedAt,
+	})
+	if err != nil {
+		return Certificate{}, false, err
+	}
+
+	uploadResp, err := s.s3Client.UploadBytes(ctx, s3client.UploadBytesRequest{
+		Data:         pdfBytes,
+		FileName:     fmt.Sprintf("certificate-%d.pdf", fiscalYear),
+		ResourceType: "donation/tax-certificates",
+		ResourceID:   donorID.String(),
+	})
+	if err != nil {
+		return Certificate{}, false, err
+	}
+	certificate.PDFURL = uploadResp.URL
+
+	if err := s.repo.IssueCertificate(ctx, certificate); err != nil {
+		return Certificate{}, false, fmt.Errorf("failed to store certificate: %w", err)
+	}
+
+	log.Printf("📜 Tax certificate %d issued for donor %s", fiscalYear, donorID.String())
+	return certificate, true, nil
+}
+
+func (s *service) getDonor(ctx context.Context, donorID uuid.UUID) (Donor, error) {
+	donor, err := s.repo.GetDonor(ctx, donorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Donor{}, apierrors.NewNotFoundError("donor not found")
+		}
+		return Donor{}, fmt.Errorf("failed to get donor: %w", err)
+	}
+	return donor, nil
+}
+
+// validateFiscalYear only accepts fiscal years that already ended
+func validateFiscalYear(fiscalYear int) error {
+	if fiscalYear < FirstFiscalYear || fiscalYear >= time.Now().Year() {
+		return apierrors.NewFieldValidationError("fiscal_year", fmt.Sprintf("must be a fiscal year that already ended, from %d", FirstFiscalYear))
+	}
+	return nil
+}
+
+// totals adds up the lines of a certificate per currency, in order of first appearance
+func totals(lines []Line) []Total {
+	result := []Total{}
+	index := map[string]int{}
+	for _, line := range lines {
+		i, ok := index[line.Currency]
+		if !ok {
+			i = len(result)
+			index[line.Currency] = i
+			result = append(result, Total{Currency: line.Currency})
+		}
+		result[i].Amount += line.Amount
+	}
+	return result
+}
+
+// fingerprint identifies the content of a certificate, to tell whether it must be issued again
+func fingerprint(donorName string, fiscalYear int, lines []Line) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%d", donorName, fiscalYear)
+	for _, line := range lines {
+		fmt.Fprintf(&b, "\n%s|%s|%s|%s|%s|%d|%d", line.CampaignID.String(), line.CampaignTitle,
+			line.OrganizerID.String(), line.OrganizerName, line.Currency, int64(line.Amount), line.DonationCount)
+	}
+	return b.String()
+}
+
+func certificateFingerprint(certificate Certificate) string {
+	return fingerprint(certificate.DonorName, certificate.FiscalYear, certificate.Lines)
+}
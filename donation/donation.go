@@ -3,6 +3,8 @@ package donation
 import (
 	"time"
 
+	"dona_tutti_api/money"
+
 	"github.com/google/uuid"
 )
 
@@ -25,6 +27,66 @@ func IsValidStatus(status DonationStatus) bool {
 	}
 }
 
+// CanTransitionTo checks if a donation status transition is valid
+func CanTransitionTo(from, to DonationStatus) bool {
+	validTransitions := map[DonationStatus][]DonationStatus{
+		DonationStatusPending:   {DonationStatusCompleted, DonationStatusFailed},
+		DonationStatusFailed:    {DonationStatusPending, DonationStatusCompleted}, // Retried payment
+		DonationStatusCompleted: {DonationStatusRefunded},
+		DonationStatusRefunded:  {}, // Terminal state
+	}
+
+	allowedTransitions, exists := validTransitions[from]
+	if !exists {
+		return false
+	}
+
+	for _, allowed := range allowedTransitions {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLocked reports whether the donation's money was received, so its amount and donor cannot change
+func (s DonationStatus) IsLocked() bool {
+	return s == DonationStatusCompleted || s == DonationStatusRefunded
+}
+
+// StatusChangeSource identifies what changed a donation status
+type StatusChangeSource string
+
+const (
+	StatusSourceCreation       StatusChangeSource = "creation"
+	StatusSourceAdmin          StatusChangeSource = "admin"
+	StatusSourcePaymentGateway StatusChangeSource = "payment_gateway"
+	StatusSourceRefund         StatusChangeSource = "refund"
+	StatusSourceReconciliation StatusChangeSource = "bank_reconciliation"
+	StatusSourceCashCollection StatusChangeSource = "cash_collection"
+	StatusSourceMatching       StatusChangeSource = "matching"
+	StatusSourceMigration      StatusChangeSource = "migration" // Donations created before the history existed
+)
+
+// StatusChange describes who or what is changing a donation status
+type StatusChange struct {
+	ChangedBy *uuid.UUID
+	Source    StatusChangeSource
+	Notes     *string
+}
+
+// StatusHistoryEntry represents a stored donation status change
+type StatusHistoryEntry struct {
+	ID         uuid.UUID          `json:"id"`
+	DonationID uuid.UUID          `json:"donation_id"`
+	FromStatus *DonationStatus    `json:"from_status,omitempty"`
+	ToStatus   DonationStatus     `json:"to_status"`
+	Source     StatusChangeSource `json:"source"`
+	ChangedBy  *uuid.UUID         `json:"changed_by,omitempty"`
+	Notes      *string            `json:"notes,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
 // PaymentMethodInfo represents payment method information in donation context
 type PaymentMethodInfo struct {
 	ID   int    `json:"id"`
@@ -49,12 +111,14 @@ type DonorInfo struct {
 }
 
 type CreateDonationRequest struct {
-	Amount          float64    `json:"amount"`
-	Message         *string    `json:"message,omitempty"`
-	IsAnonymous     bool       `json:"is_anonymous"`
-	PaymentMethodID int        `json:"payment_method_id"`
-	DonorID         *uuid.UUID `json:"donor_id,omitempty"`
-	Donor           *DonorInfo `json:"donor,omitempty"`
+	Amount          money.Amount `json:"amount"`
+	Currency        string       `json:"currency,omitempty"` // Defaults to the campaign currency
+	Message         *string      `json:"message,omitempty"`
+	IsAnonymous     bool         `json:"is_anonymous"`
+	PaymentMethodID int          `json:"payment_method_id"`
+	DonorID         *uuid.UUID   `json:"donor_id,omitempty"`
+	Donor           *DonorInfo   `json:"donor,omitempty"`
+	IdempotencyKey  *string      `json:"-"` // Set from the Idempotency-Key header
 }
 
 type UpdateDonationStatusRequest struct {
@@ -64,8 +128,14 @@ type UpdateDonationStatusRequest struct {
 type Donation struct {
 	ID            uuid.UUID          `json:"id"`
 	CampaignID    uuid.UUID          `json:"campaign_id"`
-	Amount        float64            `json:"amount"`
-	DonorID       uuid.UUID          `json:"donor_id"`
+	Amount        money.Amount       `json:"amount"` // In the campaign currency
+	Currency      string             `json:"currency"`
+	OriginalAmount money.Amount      `json:"original_amount"` // What the donor gave, in OriginalCurrency
+	OriginalCurrency string          `json:"original_currency"`
+	ExchangeRate  float64            `json:"exchange_rate"` // OriginalCurrency to Currency rate used at donation time
+	ExchangeRateID *uuid.UUID        `json:"exchange_rate_id,omitempty"`
+	RefundedAmount money.Amount      `json:"refunded_amount"`
+	DonorID       *uuid.UUID         `json:"donor_id,omitempty"` // Nil for anonymous donations made without donor information
 	Date          time.Time          `json:"date"`
 	Message       *string            `json:"message,omitempty"`
 	IsAnonymous   bool               `json:"is_anonymous"`
@@ -74,4 +144,54 @@ type Donation struct {
 	Donor         *DonorResponse     `json:"donor,omitempty"`
 	Status        DonationStatus     `json:"status"`
 	ReceiptURL    *string            `json:"receipt_url,omitempty"`
+	IdempotencyKey *string           `json:"-"`
+	MatchingPledgeID *uuid.UUID      `json:"matching_pledge_id,omitempty"`  // Set on donations made by a sponsor matching another donation
+	MatchedDonationID *uuid.UUID     `json:"matched_donation_id,omitempty"` // The donation a matched donation matches
+}
+
+// IsMatched reports whether the donation was made by a sponsor matching another donation
+func (d Donation) IsMatched() bool {
+	return d.MatchingPledgeID != nil
+}
+
+// NetAmount returns the amount kept by the campaign after refunds
+func (d Donation) NetAmount() money.Amount {
+	return d.Amount - d.RefundedAmount
+}
+
+// PublicView returns the donation without the contact data of its donor, for unauthenticated callers.
+// Anonymous donations lose their donor entirely, so they cannot be tied to the donor's other donations.
+func (d Donation) PublicView() Donation {
+	if d.IsAnonymous {
+		d.DonorID = nil
+		d.Donor = nil
+		return d
+	}
+	if d.Donor != nil {
+		donor := *d.Donor
+		donor.Email = ""
+		donor.Phone = ""
+		d.Donor = &donor
+	}
+	return d
+}
+
+type PaymentSessionStatus string
+
+const (
+	PaymentSessionStatusOpen     PaymentSessionStatus = "open"
+	PaymentSessionStatusApproved PaymentSessionStatus = "approved"
+	PaymentSessionStatusRejected PaymentSessionStatus = "rejected"
+)
+
+// PaymentSession represents a checkout started at a payment gateway for a donation
+type PaymentSession struct {
+	ID          uuid.UUID            `json:"id"`
+	DonationID  uuid.UUID            `json:"donation_id"`
+	GatewayCode string               `json:"gateway_code"`
+	ExternalID  string               `json:"external_id"`
+	CheckoutURL string               `json:"checkout_url"`
+	Status      PaymentSessionStatus `json:"status"`
+	ExpiresAt   *time.Time           `json:"expires_at,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
 }
@@ -1,8 +1,12 @@
 package donation
 
 import (
+	"errors"
 	"net/http"
 
+	"dona_tutti_api/donation/payment"
+	apierrors "dona_tutti_api/errors"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -36,6 +40,10 @@ func (h *Handler) GetDonationsByCampaign(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	for i := range donations {
+		donations[i] = donations[i].PublicView()
+	}
+
 	return c.JSON(http.StatusOK, donations)
 }
 
@@ -62,7 +70,7 @@ func (h *Handler) GetDonation(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, err.Error())
 	}
 
-	return c.JSON(http.StatusOK, donation)
+	return c.JSON(http.StatusOK, donation.PublicView())
 }
 
 // CreateDonation creates a new donation
@@ -96,21 +104,87 @@ func (h *Handler) CreateDonation(c echo.Context) error {
 	}
 
 	id, err := h.service.CreateDonationWithRequest(c.Request().Context(), campaignID, req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	donation, err := h.service.GetDonation(c.Request().Context(), id)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	return c.JSON(http.StatusCreated, donation)
+}
+
+// CreatePublicDonation lets donors give money to a campaign without an admin
+// @Summary Donate to a campaign
+// @Description Create a pending donation for an active campaign with donor info or anonymously. Donors who send their info with is_anonymous set stay linked to the donation but are not shown publicly. The donation is only credited to an existing donor with the same email or phone when that donor is verified, and the response leaves the donor out. Send an Idempotency-Key header to retry safely: a repeated request returns the original donation instead of creating a duplicate. Requests are rate limited per client.
+// @Tags donations
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "Campaign ID"
+// @Param Idempotency-Key header string false "Unique key for this donation attempt"
+// @Param donation body CreateDonationRequest true "Donation data"
+// @Success 201 {object} Donation
+// @Failure 400 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /campaigns/{campaignId}/donate [post]
+func (h *Handler) CreatePublicDonation(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	var req CreateDonationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	// Donors identify themselves with their info; only admins can attribute a donation to an existing donor_id
+	if req.DonorID != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "donor_id cannot be set on public donations")
+	}
+
+	if key := c.Request().Header.Get("Idempotency-Key"); key != "" {
+		req.IdempotencyKey = &key
+	}
+
+	id, err := h.service.CreatePublicDonation(c.Request().Context(), campaignID, req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
 	donation, err := h.service.GetDonation(c.Request().Context(), id)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	// The caller is not known to own the donor the donation was credited to, so the donor is left out
+	donation.DonorID = nil
+	donation.Donor = nil
+
 	return c.JSON(http.StatusCreated, donation)
 }
 
+// errorStatus maps a donation service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, payment.ErrInvalidSignature) {
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
 // UpdateDonation updates an existing donation
 // @Summary Update a donation
-// @Description Update an existing donation
+// @Description Update an existing donation. Completed and refunded donations cannot change their amount, donor or payment method, and status changes follow the donation status workflow.
 // @Tags donations
 // @Accept json
 // @Produce json
@@ -120,6 +194,7 @@ func (h *Handler) CreateDonation(c echo.Context) error {
 // @Success 200 {object} Donation
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /campaigns/{campaignId}/donations/{id} [put]
 func (h *Handler) UpdateDonation(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
@@ -141,16 +216,22 @@ func (h *Handler) UpdateDonation(c echo.Context) error {
 	donation.ID = id
 	donation.CampaignID = campaignID
 
-	if err := h.service.UpdateDonation(c.Request().Context(), donation); err != nil {
+	change := StatusChange{ChangedBy: getUserID(c), Source: StatusSourceAdmin}
+	if err := h.service.UpdateDonation(c.Request().Context(), donation, change); err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	updated, err := h.service.GetDonation(c.Request().Context(), id)
+	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return c.JSON(http.StatusOK, donation)
+	return c.JSON(http.StatusOK, updated)
 }
 
 // UpdateDonationStatus updates the status of a donation
 // @Summary Update donation status
-// @Description Update the status of a donation. When status is changed to 'completed', a receipt is automatically generated.
+// @Description Update the status of a donation. Allowed transitions: pending to completed or failed, failed to pending or completed. Refunds go through the refunds endpoint. When status is changed to 'completed', a receipt is automatically generated.
 // @Tags donations
 // @Accept json
 // @Produce json
@@ -184,21 +265,76 @@ func (h *Handler) UpdateDonationStatus(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	// Verify campaign ID matches
+	donation, err := h.service.GetDonation(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Donation not found")
+	}
+	if donation.CampaignID != campaignID {
+		return echo.NewHTTPError(http.StatusBadRequest, "Donation does not belong to the specified campaign")
+	}
+
 	// Update status
-	if err := h.service.UpdateDonationStatus(c.Request().Context(), id, req.Status); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	change := StatusChange{ChangedBy: getUserID(c), Source: StatusSourceAdmin}
+	if err := h.service.UpdateDonationStatus(c.Request().Context(), id, req.Status, change); err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
 	}
 
 	// Get updated donation
+	donation, err = h.service.GetDonation(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, donation)
+}
+
+// GetDonationStatusHistory returns the status changes of a donation
+// @Summary Get donation status history
+// @Description Get every status change of a donation, oldest first
+// @Tags donations
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "Campaign ID"
+// @Param id path string true "Donation ID"
+// @Success 200 {array} StatusHistoryEntry
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /campaigns/{campaignId}/donations/{id}/history [get]
+func (h *Handler) GetDonationStatusHistory(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donation ID")
+	}
+
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
 	donation, err := h.service.GetDonation(c.Request().Context(), id)
+	if err != nil || donation.CampaignID != campaignID {
+		return echo.NewHTTPError(http.StatusNotFound, "Donation not found")
+	}
+
+	history, err := h.service.GetStatusHistory(c.Request().Context(), id)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	// Verify campaign ID matches
-	if donation.CampaignID != campaignID {
-		return echo.NewHTTPError(http.StatusBadRequest, "Donation does not belong to the specified campaign")
+	return c.JSON(http.StatusOK, history)
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
 	}
 
-	return c.JSON(http.StatusOK, donation)
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
 }
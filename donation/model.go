@@ -2,6 +2,7 @@ package donation
 
 import (
 	"dona_tutti_api/donor"
+	"dona_tutti_api/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,20 +16,29 @@ type PaymentMethodModel struct {
 }
 
 type DonationModel struct {
-	ID              uuid.UUID           `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
-	CampaignID      uuid.UUID           `gorm:"column:campaign_id;type:uuid;not null"`
-	Amount          float64             `gorm:"column:amount;not null"`
-	DonorID         uuid.UUID           `gorm:"column:donor_id;type:uuid;not null"`
-	Date            time.Time           `gorm:"column:date;not null"`
-	Message         *string             `gorm:"column:message"`
-	IsAnonymous     bool                `gorm:"column:is_anonymous"`
-	PaymentMethodID int                 `gorm:"column:payment_method_id;not null"`
-	Status          DonationStatus      `gorm:"column:status;type:varchar(20);not null"`
-	ReceiptURL      *string             `gorm:"column:receipt_url;type:varchar(500)"`
-	CreatedAt       time.Time           `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt       time.Time           `gorm:"column:updated_at;autoUpdateTime"`
-	Donor           donor.DonorModel    `gorm:"foreignKey:DonorID"`
-	PaymentMethod   *PaymentMethodModel `gorm:"-"`
+	ID                uuid.UUID           `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	CampaignID        uuid.UUID           `gorm:"column:campaign_id;type:uuid;not null"`
+	Amount            money.Amount        `gorm:"column:amount;not null"`
+	Currency          string              `gorm:"column:currency;type:varchar(3);not null;default:ARS"`
+	OriginalAmount    money.Amount        `gorm:"column:original_amount;not null"`
+	OriginalCurrency  string              `gorm:"column:original_currency;type:varchar(3);not null;default:ARS"`
+	ExchangeRate      float64             `gorm:"column:exchange_rate;type:decimal(18,8);not null;default:1"`
+	ExchangeRateID    *uuid.UUID          `gorm:"column:exchange_rate_id;type:uuid"`
+	RefundedAmount    money.Amount        `gorm:"column:refunded_amount;not null;default:0"`
+	DonorID           *uuid.UUID          `gorm:"column:donor_id;type:uuid"`
+	Date              time.Time           `gorm:"column:date;not null"`
+	Message           *string             `gorm:"column:message"`
+	IsAnonymous       bool                `gorm:"column:is_anonymous"`
+	PaymentMethodID   int                 `gorm:"column:payment_method_id;not null"`
+	Status            DonationStatus      `gorm:"column:status;type:varchar(20);not null"`
+	ReceiptURL        *string             `gorm:"column:receipt_url;type:varchar(500)"`
+	IdempotencyKey    *string             `gorm:"column:idempotency_key;type:varchar(255)"`
+	MatchingPledgeID  *uuid.UUID          `gorm:"column:matching_pledge_id;type:uuid"`
+	MatchedDonationID *uuid.UUID          `gorm:"column:matched_donation_id;type:uuid"`
+	CreatedAt         time.Time           `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time           `gorm:"column:updated_at;autoUpdateTime"`
+	Donor             donor.DonorModel    `gorm:"foreignKey:DonorID"`
+	PaymentMethod     *PaymentMethodModel `gorm:"-"`
 }
 
 func (DonationModel) TableName() string {
@@ -37,16 +47,25 @@ func (DonationModel) TableName() string {
 
 func (m DonationModel) ToEntity() Donation {
 	donation := Donation{
-		ID:              m.ID,
-		CampaignID:      m.CampaignID,
-		Amount:          m.Amount,
-		DonorID:         m.DonorID,
-		Date:            m.Date,
-		Message:         m.Message,
-		IsAnonymous:     m.IsAnonymous,
-		PaymentMethodID: m.PaymentMethodID,
-		Status:          m.Status,
-		ReceiptURL:      m.ReceiptURL,
+		ID:                m.ID,
+		CampaignID:        m.CampaignID,
+		Amount:            m.Amount,
+		Currency:          m.Currency,
+		OriginalAmount:    m.OriginalAmount,
+		OriginalCurrency:  m.OriginalCurrency,
+		ExchangeRate:      m.ExchangeRate,
+		ExchangeRateID:    m.ExchangeRateID,
+		RefundedAmount:    m.RefundedAmount,
+		DonorID:           m.DonorID,
+		Date:              m.Date,
+		Message:           m.Message,
+		IsAnonymous:       m.IsAnonymous,
+		PaymentMethodID:   m.PaymentMethodID,
+		Status:            m.Status,
+		ReceiptURL:        m.ReceiptURL,
+		IdempotencyKey:    m.IdempotencyKey,
+		MatchingPledgeID:  m.MatchingPledgeID,
+		MatchedDonationID: m.MatchedDonationID,
 	}
 
 	// Convert payment method info if available
@@ -60,12 +79,13 @@ func (m DonationModel) ToEntity() Donation {
 
 	// Convert donor info if available and not anonymous
 	if !m.IsAnonymous && m.Donor.ID != uuid.Nil {
+		donorInfo := m.Donor.ToEntity()
 		donation.Donor = &DonorResponse{
-			ID:        m.Donor.ID,
-			FirstName: m.Donor.FirstName,
-			LastName:  m.Donor.LastName,
-			Email:     m.Donor.Email,
-			Phone:     m.Donor.Phone,
+			ID:        donorInfo.ID,
+			FirstName: donorInfo.FirstName,
+			LastName:  donorInfo.LastName,
+			Email:     donorInfo.Email,
+			Phone:     donorInfo.Phone,
 		}
 	}
 
@@ -76,6 +96,12 @@ func (m *DonationModel) FromEntity(entity Donation) {
 	m.ID = entity.ID
 	m.CampaignID = entity.CampaignID
 	m.Amount = entity.Amount
+	m.Currency = entity.Currency
+	m.OriginalAmount = entity.OriginalAmount
+	m.OriginalCurrency = entity.OriginalCurrency
+	m.ExchangeRate = entity.ExchangeRate
+	m.ExchangeRateID = entity.ExchangeRateID
+	m.RefundedAmount = entity.RefundedAmount
 	m.DonorID = entity.DonorID
 	m.Date = entity.Date
 	m.Message = entity.Message
@@ -83,4 +109,109 @@ func (m *DonationModel) FromEntity(entity Donation) {
 	m.PaymentMethodID = entity.PaymentMethodID
 	m.Status = entity.Status
 	m.ReceiptURL = entity.ReceiptURL
+	m.IdempotencyKey = entity.IdempotencyKey
+	m.MatchingPledgeID = entity.MatchingPledgeID
+	m.MatchedDonationID = entity.MatchedDonationID
+}
+
+type StatusHistoryModel struct {
+	ID         uuid.UUID  `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	DonationID uuid.UUID  `gorm:"column:donation_id;type:uuid;not null"`
+	FromStatus *string    `gorm:"column:from_status;type:varchar(20)"`
+	ToStatus   string     `gorm:"column:to_status;type:varchar(20);not null"`
+	Source     string     `gorm:"column:source;type:varchar(30);not null"`
+	ChangedBy  *uuid.UUID `gorm:"column:changed_by;type:uuid"`
+	Notes      *string    `gorm:"column:notes"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (StatusHistoryModel) TableName() string {
+	return "donation_status_history"
+}
+
+func (m StatusHistoryModel) ToEntity() StatusHistoryEntry {
+	entry := StatusHistoryEntry{
+		ID:         m.ID,
+		DonationID: m.DonationID,
+		ToStatus:   DonationStatus(m.ToStatus),
+		Source:     StatusChangeSource(m.Source),
+		ChangedBy:  m.ChangedBy,
+		Notes:      m.Notes,
+		CreatedAt:  m.CreatedAt,
+	}
+	if m.FromStatus != nil {
+		from := DonationStatus(*m.FromStatus)
+		entry.FromStatus = &from
+	}
+	return entry
+}
+
+func (m *StatusHistoryModel) FromEntity(entity StatusHistoryEntry) {
+	m.ID = entity.ID
+	m.DonationID = entity.DonationID
+	m.FromStatus = nil
+	if entity.FromStatus != nil {
+		from := string(*entity.FromStatus)
+		m.FromStatus = &from
+	}
+	m.ToStatus = string(entity.ToStatus)
+	m.Source = string(entity.Source)
+	m.ChangedBy = entity.ChangedBy
+	m.Notes = entity.Notes
+	m.CreatedAt = entity.CreatedAt
+}
+
+type PaymentSessionModel struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	DonationID  uuid.UUID  `gorm:"column:donation_id;type:uuid;not null"`
+	GatewayCode string     `gorm:"column:gateway_code;type:varchar(30);not null"`
+	ExternalID  string     `gorm:"column:external_id;type:varchar(255);not null"`
+	CheckoutURL string     `gorm:"column:checkout_url;not null"`
+	Status      string     `gorm:"column:status;type:varchar(20);not null"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (PaymentSessionModel) TableName() string {
+	return "payment_sessions"
+}
+
+func (m PaymentSessionModel) ToEntity() PaymentSession {
+	return PaymentSession{
+		ID:          m.ID,
+		DonationID:  m.DonationID,
+		GatewayCode: m.GatewayCode,
+		ExternalID:  m.ExternalID,
+		CheckoutURL: m.CheckoutURL,
+		Status:      PaymentSessionStatus(m.Status),
+		ExpiresAt:   m.ExpiresAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func (m *PaymentSessionModel) FromEntity(entity PaymentSession) {
+	m.ID = entity.ID
+	m.DonationID = entity.DonationID
+	m.GatewayCode = entity.GatewayCode
+	m.ExternalID = entity.ExternalID
+	m.CheckoutURL = entity.CheckoutURL
+	m.Status = string(entity.Status)
+	m.ExpiresAt = entity.ExpiresAt
+	m.CreatedAt = entity.CreatedAt
+}
+
+// PaymentWebhookEventModel records a processed gateway webhook
+type PaymentWebhookEventModel struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	GatewayCode string     `gorm:"column:gateway_code;type:varchar(30);not null"`
+	EventID     string     `gorm:"column:event_id;type:varchar(255);not null"`
+	DonationID  *uuid.UUID `gorm:"column:donation_id;type:uuid"`
+	Status      string     `gorm:"column:status;type:varchar(20);not null"`
+	Payload     string     `gorm:"column:payload;not null"`
+	ProcessedAt time.Time  `gorm:"column:processed_at;autoCreateTime"`
+}
+
+func (PaymentWebhookEventModel) TableName() string {
+	return "payment_webhook_events"
 }
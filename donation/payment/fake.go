@@ -0,0 +1,100 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FakeSignatureHeader is the header carrying the HMAC-SHA256 signature of fake gateway webhooks
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// FakeGatewayCode is the payment method code of the fake gateway. It is never a real gateway's code,
+// so enabling the simulator cannot complete payments meant for a real provider.
+const FakeGatewayCode = "fake"
+
+// FakeGateway is a local payment gateway used to exercise the checkout and webhook flow offline.
+// Webhooks are signed with HMAC-SHA256 using a shared secret, like most real providers.
+type FakeGateway struct {
+	code    string
+	secret  []byte
+	baseURL string
+}
+
+// NewFakeGateway creates the fake gateway for the fake payment method. Checkout URLs are built from baseURL.
+func NewFakeGateway(secret, baseURL string) *FakeGateway {
+	return &FakeGateway{
+		code:    FakeGatewayCode,
+		secret:  []byte(secret),
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (g *FakeGateway) Code() string {
+	return g.code
+}
+
+// CreateCheckoutSession creates a session that can be completed with SimulatePayment
+func (g *FakeGateway) CreateCheckoutSession(ctx context.Context, req CheckoutRequest) (CheckoutSession, error) {
+	externalID := "fake_" + uuid.New().String()
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	return CheckoutSession{
+		ExternalID:  externalID,
+		CheckoutURL: fmt.Sprintf("%s/api/payments/fake/sessions/%s", g.baseURL, externalID),
+		ExpiresAt:   &expiresAt,
+	}, nil
+}
+
+// ParseWebhook verifies the signature header and decodes the event
+func (g *FakeGateway) ParseWebhook(payload []byte, headers http.Header) (WebhookEvent, error) {
+	signature, err := hex.DecodeString(headers.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(signature, g.sign(payload)) {
+		return WebhookEvent{}, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return WebhookEvent{}, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	if event.EventID == "" || event.ExternalID == "" {
+		return WebhookEvent{}, fmt.Errorf("invalid webhook payload: event_id and session_id are required")
+	}
+	if event.Status != EventStatusApproved && event.Status != EventStatusRejected {
+		return WebhookEvent{}, fmt.Errorf("invalid webhook payload: unknown status %s", event.Status)
+	}
+
+	return event, nil
+}
+
+// SimulatePayment builds the signed webhook the gateway would send once the donor pays or the payment fails
+func (g *FakeGateway) SimulatePayment(externalID string, status EventStatus) ([]byte, http.Header, error) {
+	payload, err := json.Marshal(WebhookEvent{
+		EventID:    "evt_" + uuid.New().String(),
+		ExternalID: externalID,
+		Status:     status,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build webhook payload: %w", err)
+	}
+
+	headers := http.Header{}
+	headers.Set(FakeSignatureHeader, hex.EncodeToString(g.sign(payload)))
+	headers.Set("Content-Type", "application/json")
+
+	return payload, headers, nil
+}
+
+func (g *FakeGateway) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,83 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidSignature is returned when a webhook payload cannot be authenticated
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// EventStatus represents the outcome of a payment reported by a gateway
+type EventStatus string
+
+const (
+	EventStatusApproved EventStatus = "approved"
+	EventStatusRejected EventStatus = "rejected"
+)
+
+// CheckoutRequest contains the donation data needed to start a payment
+type CheckoutRequest struct {
+	DonationID  uuid.UUID
+	CampaignID  uuid.UUID
+	Amount      money.Amount
+	Currency    string
+	Description string
+}
+
+// CheckoutSession is a payment started at a gateway, where the donor completes the payment
+type CheckoutSession struct {
+	ExternalID  string
+	CheckoutURL string
+	ExpiresAt   *time.Time
+}
+
+// WebhookEvent is a verified payment notification sent by a gateway
+type WebhookEvent struct {
+	EventID    string      `json:"event_id"`
+	ExternalID string      `json:"session_id"`
+	Status     EventStatus `json:"status"`
+}
+
+// PaymentGateway defines the operations a payment provider must support.
+// Gateways are keyed by the payment_methods.code they process.
+type PaymentGateway interface {
+	Code() string
+	CreateCheckoutSession(ctx context.Context, req CheckoutRequest) (CheckoutSession, error)
+	// ParseWebhook verifies the payload signature and decodes the event
+	ParseWebhook(payload []byte, headers http.Header) (WebhookEvent, error)
+}
+
+// Registry holds the available payment gateways by payment method code
+type Registry struct {
+	gateways map[string]PaymentGateway
+}
+
+// NewRegistry creates a registry with the given gateways
+func NewRegistry(gateways ...PaymentGateway) *Registry {
+	r := &Registry{gateways: make(map[string]PaymentGateway)}
+	for _, gateway := range gateways {
+		r.Register(gateway)
+	}
+	return r
+}
+
+// Register adds a gateway, replacing any gateway with the same code
+func (r *Registry) Register(gateway PaymentGateway) {
+	r.gateways[gateway.Code()] = gateway
+}
+
+// Get returns the gateway for a payment method code
+func (r *Registry) Get(code string) (PaymentGateway, bool) {
+	if r == nil {
+		return nil, false
+	}
+	gateway, ok := r.gateways[code]
+	return gateway, ok
+}
@@ -0,0 +1,140 @@
+package donation
+
+import (
+	"io"
+	"net/http"
+
+	"dona_tutti_api/donation/payment"
+	"dona_tutti_api/middleware"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Rate limit of the public checkout endpoint, per client
+const (
+	checkoutRateLimit = 10 // requests per minute
+	checkoutBurst     = 5
+)
+
+// PaymentHandler handles online payments of donations through payment gateways
+type PaymentHandler struct {
+	service     Service
+	fakeGateway *payment.FakeGateway
+}
+
+// NewPaymentHandler creates a payment handler. fakeGateway is optional and enables the offline payment simulator.
+func NewPaymentHandler(service Service, fakeGateway *payment.FakeGateway) *PaymentHandler {
+	return &PaymentHandler{service: service, fakeGateway: fakeGateway}
+}
+
+// RegisterRoutes registers the checkout and webhook routes
+func (h *PaymentHandler) RegisterRoutes(g *echo.Group) {
+	// Public routes: donors pay their own donations, gateways authenticate webhooks with signatures
+	g.POST("/campaigns/:campaignId/donations/:id/checkout", h.CreateCheckoutSession,
+		middleware.OptionalAuth(), middleware.RateLimit(checkoutRateLimit, checkoutBurst))
+	g.POST("/payments/webhooks/:gateway", h.HandleWebhook)
+
+	// The simulator is only wired outside production, for the fake payment method
+	if h.fakeGateway != nil {
+		g.POST("/payments/fake/sessions/:sessionId", h.SimulateFakePayment)
+	}
+}
+
+// CreateCheckoutSession starts an online payment for a donation
+// @Summary Start a donation payment
+// @Description Create a checkout session at the payment gateway of the donation's payment method. The donor completes the payment at checkout_url.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "Campaign ID"
+// @Param id path string true "Donation ID"
+// @Success 201 {object} PaymentSession
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /campaigns/{campaignId}/donations/{id}/checkout [post]
+func (h *PaymentHandler) CreateCheckoutSession(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donation ID")
+	}
+
+	donation, err := h.service.GetDonation(c.Request().Context(), id)
+	if err != nil || donation.CampaignID != campaignID {
+		return echo.NewHTTPError(http.StatusNotFound, "Donation not found")
+	}
+
+	session, err := h.service.CreateCheckoutSession(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, session)
+}
+
+// HandleWebhook receives payment notifications from a gateway
+// @Summary Payment gateway webhook
+// @Description Receive a signed payment notification. Approved payments complete the donation, rejected ones fail it. Replayed events are acknowledged without changes.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param gateway path string true "Payment method code of the gateway"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /payments/webhooks/{gateway} [post]
+func (h *PaymentHandler) HandleWebhook(c echo.Context) error {
+	payload, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := h.service.HandlePaymentWebhook(c.Request().Context(), c.Param("gateway"), payload, c.Request().Header); err != nil {
+		status := errorStatus(err)
+		if status == http.StatusInternalServerError {
+			c.Logger().Errorf("payment webhook failed: %v", err)
+		}
+		return echo.NewHTTPError(status, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// SimulateFakePayment completes a fake gateway checkout by sending the signed webhook it would produce
+// @Summary Simulate a payment (fake gateway)
+// @Description Only available when the fake payment gateway is enabled. Sends a signed approved or rejected webhook for the checkout session.
+// @Tags payments
+// @Produce json
+// @Param sessionId path string true "External session ID"
+// @Param result query string false "approved (default) or rejected"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /payments/fake/sessions/{sessionId} [post]
+func (h *PaymentHandler) SimulateFakePayment(c echo.Context) error {
+	status := payment.EventStatusApproved
+	if result := c.QueryParam("result"); result != "" {
+		status = payment.EventStatus(result)
+	}
+	if status != payment.EventStatusApproved && status != payment.EventStatusRejected {
+		return echo.NewHTTPError(http.StatusBadRequest, "result must be approved or rejected")
+	}
+
+	payload, headers, err := h.fakeGateway.SimulatePayment(c.Param("sessionId"), status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	if err := h.service.HandlePaymentWebhook(c.Request().Context(), h.fakeGateway.Code(), payload, headers); err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": string(status)})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"dona_tutti_api/money"
+
 	"github.com/google/uuid"
 	"github.com/jung-kurt/gofpdf"
 )
@@ -14,10 +16,20 @@ type ReceiptData struct {
 	DonationID    uuid.UUID
 	CampaignTitle string
 	DonorName     string
-	Amount        float64
+	Amount        money.Amount // In Currency, the campaign currency
+	Currency      string
 	Date          time.Time
 	PaymentMethod string
 	IsAnonymous   bool
+
+	// Donations made in another currency
+	OriginalAmount   money.Amount
+	OriginalCurrency string
+	ExchangeRate     float64
+
+	// Refunds
+	RefundedAmount money.Amount
+	Voided         bool // The donation was fully refunded
 }
 
 // PDFGenerator defines the interface for generating PDF receipts
@@ -52,6 +64,11 @@ func (g *pdfGenerator) Generate(data ReceiptData) ([]byte, error) {
 	// Add receipt title
 	pdf.SetFont("Arial", "B", 18)
 	pdf.CellFormat(190, 10, "COMPROBANTE DE DONACION", "", 1, "C", false, 0, "")
+	if data.Voided {
+		pdf.SetTextColor(231, 76, 60) // Red color
+		pdf.CellFormat(190, 10, "ANULADO - DONACION REEMBOLSADA", "", 1, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
 	pdf.Ln(8)
 
 	// Add horizontal line
@@ -135,8 +152,38 @@ func (g *pdfGenerator) Generate(data ReceiptData) ([]byte, error) {
 	pdf.CellFormat(70, 12, "Monto Total:", "", 0, "L", true, 0, "")
 	pdf.SetFont("Arial", "B", 16)
 	pdf.SetTextColor(46, 204, 113) // Green color for amount
-	pdf.CellFormat(120, 12, fmt.Sprintf("$%.2f", data.Amount), "", 1, "L", true, 0, "")
+	pdf.CellFormat(120, 12, data.Amount.Format(data.Currency), "", 1, "L", true, 0, "")
 	pdf.SetTextColor(0, 0, 0) // Reset to black
+
+	// Original amount and the exchange rate used to convert it
+	if data.OriginalCurrency != "" && data.OriginalCurrency != data.Currency {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(70, 7, "Monto Original:")
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(120, 7, data.OriginalAmount.Format(data.OriginalCurrency))
+		pdf.Ln(7)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(70, 7, "Tipo de Cambio:")
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(120, 7, fmt.Sprintf("1 %s = %.4f %s", data.OriginalCurrency, data.ExchangeRate, data.Currency))
+		pdf.Ln(7)
+	}
+
+	// Refunded and net amounts
+	if data.RefundedAmount.IsPositive() {
+		pdf.Ln(2)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(70, 7, "Monto Reembolsado:")
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(120, 7, data.RefundedAmount.Format(data.Currency))
+		pdf.Ln(7)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(70, 7, "Monto Neto Donado:")
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(120, 7, (data.Amount - data.RefundedAmount).Format(data.Currency))
+		pdf.Ln(7)
+	}
 	pdf.Ln(10)
 
 	// Add section separator
@@ -166,7 +213,11 @@ func (g *pdfGenerator) Generate(data ReceiptData) ([]byte, error) {
 	pdf.SetTextColor(128, 128, 128) // Grey color
 	pdf.CellFormat(190, 5, "Documento generado automaticamente por Dona Tutti", "", 1, "C", false, 0, "")
 	pdf.CellFormat(190, 5, fmt.Sprintf("Fecha de generacion: %s", time.Now().Format("02/01/2006 15:04:05")), "", 1, "C", false, 0, "")
-	pdf.CellFormat(190, 5, "Este comprobante es valido como constancia de donacion", "", 1, "C", false, 0, "")
+	if data.Voided {
+		pdf.CellFormat(190, 5, "Este comprobante fue anulado y no es valido como constancia de donacion", "", 1, "C", false, 0, "")
+	} else {
+		pdf.CellFormat(190, 5, "Este comprobante es valido como constancia de donacion", "", 1, "C", false, 0, "")
+	}
 	pdf.SetTextColor(0, 0, 0)
 
 	// Generate PDF bytes
@@ -177,4 +228,3 @@ func (g *pdfGenerator) Generate(data ReceiptData) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
-
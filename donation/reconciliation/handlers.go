@@ -0,0 +1,363 @@
+package reconciliation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// MaxStatementSize is the maximum size of an uploaded statement file
+const MaxStatementSize = 5 << 20
+
+// Handler handles HTTP requests for bank statement reconciliation
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new reconciliation handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the reconciliation routes
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.POST("/campaigns/:campaignId/bank-statements", h.ImportStatement)
+	adminGroup.GET("/campaigns/:campaignId/bank-statements", h.ListStatements)
+	adminGroup.GET("/bank-statements/:id", h.GetStatement)
+	adminGroup.POST("/campaigns/:campaignId/reconciliation/match", h.MatchLines)
+	adminGroup.GET("/reconciliation/lines", h.ListLines)
+	adminGroup.POST("/reconciliation/lines/confirm", h.ConfirmMatches)
+	adminGroup.PUT("/reconciliation/lines/:id/match", h.MatchLine)
+	adminGroup.DELETE("/reconciliation/lines/:id/match", h.ClearMatch)
+	adminGroup.POST("/reconciliation/lines/:id/ignore", h.IgnoreLine)
+}
+
+// @Summary Import a bank statement
+// @Description Upload a statement of a campaign's transfer account as CSV (header with date and amount columns, optional description, payer_name, payer_reference and reference) or OFX. Credits are added to the reconciliation queue and matched to pending transfer donations; movements already imported are skipped. If any line is invalid nothing is imported and the errors are returned.
+// @Tags reconciliation
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Param file formData file true "CSV or OFX file"
+// @Param transfer_detail_id formData int true "Transfer detail ID"
+// @Param currency formData string false "Statement currency, defaults to the OFX currency or the campaign currency"
+// @Success 201 {object} ImportResult
+// @Failure 400 {object} ImportResult
+// @Failure 404 {object} errors.APIError
+// @Router /campaigns/{campaignId}/bank-statements [post]
+func (h *Handler) ImportStatement(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	transferDetailID, err := strconv.Atoi(c.FormValue("transfer_detail_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid transfer detail ID")
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "File is required")
+	}
+	if file.Size > MaxStatementSize {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File cannot be larger than %d MB", MaxStatementSize>>20))
+	}
+
+	var format StatementFormat
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".csv":
+		format = FormatCSV
+	case ".ofx", ".qfx":
+		format = FormatOFX
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Only CSV and OFX files are allowed")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open file")
+	}
+	defer src.Close()
+
+	upload := StatementUpload{
+		CampaignID:       campaignID,
+		TransferDetailID: transferDetailID,
+		FileName:         filepath.Base(file.Filename),
+		Format:           format,
+		Currency:         c.FormValue("currency"),
+		UploadedBy:       getUserID(c),
+	}
+	result, err := h.service.ImportStatement(c.Request().Context(), upload, src)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+	if len(result.Errors) > 0 {
+		return c.JSON(http.StatusBadRequest, result)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// @Summary List bank statements
+// @Description Get the statements imported for a campaign, newest first
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {array} Statement
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/{campaignId}/bank-statements [get]
+func (h *Handler) ListStatements(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	statements, err := h.service.ListStatements(c.Request().Context(), campaignID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, statements)
+}
+
+// @Summary Get bank statement by ID
+// @Description Get a statement with all its lines
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Statement ID"
+// @Success 200 {object} Statement
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /bank-statements/{id} [get]
+func (h *Handler) GetStatement(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid statement ID")
+	}
+
+	statement, err := h.service.GetStatement(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, statement)
+}
+
+// @Summary Match unmatched lines
+// @Description Run the matcher over the campaign's unmatched lines, for donations registered after their statement was imported. Returns the lines waiting for confirmation.
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {object} MatchResult
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/{campaignId}/reconciliation/match [post]
+func (h *Handler) MatchLines(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	result, err := h.service.MatchLines(c.Request().Context(), campaignID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// @Summary List the reconciliation queue
+// @Description Get statement lines, by default those unmatched or waiting for confirmation
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id query string false "Campaign ID"
+// @Param statement_id query string false "Statement ID"
+// @Param status query string false "Comma-separated line statuses (unmatched, proposed, confirmed, ignored)"
+// @Success 200 {array} Line
+// @Failure 400 {object} errors.APIError
+// @Router /reconciliation/lines [get]
+func (h *Handler) ListLines(c echo.Context) error {
+	var filter LineFilter
+	if value := c.QueryParam("campaign_id"); value != "" {
+		campaignID, err := uuid.Parse(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+		}
+		filter.CampaignID = &campaignID
+	}
+	if value := c.QueryParam("statement_id"); value != "" {
+		statementID, err := uuid.Parse(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid statement ID")
+		}
+		filter.StatementID = &statementID
+	}
+	if value := c.QueryParam("status"); value != "" {
+		for _, status := range strings.Split(value, ",") {
+			filter.Statuses = append(filter.Statuses, LineStatus(strings.TrimSpace(status)))
+		}
+	}
+
+	lines, err := h.service.ListLines(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, lines)
+}
+
+// @Summary Confirm proposed matches
+// @Description Complete the proposed donation of each line, which generates its receipt. Lines that cannot be confirmed are returned in failed without stopping the others.
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param lines body ConfirmMatchesRequest true "Lines to confirm"
+// @Success 200 {object} ConfirmResult
+// @Failure 400 {object} errors.APIError
+// @Router /reconciliation/lines/confirm [post]
+func (h *Handler) ConfirmMatches(c echo.Context) error {
+	var req ConfirmMatchesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	result, err := h.service.ConfirmMatches(c.Request().Context(), req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// @Summary Match a line manually
+// @Description Propose a donation for an open line. The donation must be a pending transfer donation of the campaign with the same amount.
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Line ID"
+// @Param match body ManualMatchRequest true "Donation to match"
+// @Success 200 {object} Line
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /reconciliation/lines/{id}/match [put]
+func (h *Handler) MatchLine(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid line ID")
+	}
+
+	var req ManualMatchRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	line, err := h.service.MatchLine(c.Request().Context(), id, req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, line)
+}
+
+// @Summary Reject a proposed match
+// @Description Remove the proposed donation of a line, which goes back to the queue
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Line ID"
+// @Success 200 {object} Line
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /reconciliation/lines/{id}/match [delete]
+func (h *Handler) ClearMatch(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid line ID")
+	}
+
+	line, err := h.service.ClearMatch(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, line)
+}
+
+// @Summary Ignore a line
+// @Description Remove a line that is not a donation, such as a bank fee, from the queue. Notes are required.
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Line ID"
+// @Param ignore body IgnoreLineRequest true "Reason"
+// @Success 200 {object} Line
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /reconciliation/lines/{id}/ignore [post]
+func (h *Handler) IgnoreLine(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid line ID")
+	}
+
+	var req IgnoreLineRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	line, err := h.service.IgnoreLine(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, line)
+}
+
+// errorStatus maps a reconciliation service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,156 @@
+package reconciliation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// Match scoring. A line and a donation can only match when the amounts are equal and the transfer
+// was posted within MatchWindowDays of the donation date; the confidence then grows with the
+// date proximity and the payer reference.
+const (
+	MatchWindowDays = 5
+
+	amountPoints          = 50.0
+	maxDatePoints         = 30.0
+	donationRefPoints     = 20.0 // The transfer description mentions the donation ID
+	fullNamePoints        = 15.0
+	lastNamePoints        = 8.0
+	ambiguityPenalty      = 10.0 // The line matches several donations
+	donationReferenceSize = 8    // Donors are asked to include the first characters of the donation ID
+)
+
+// Candidate is a pending transfer donation that a statement line can be matched to
+type Candidate struct {
+	DonationID       uuid.UUID
+	Amount           money.Amount
+	Currency         string
+	OriginalAmount   money.Amount
+	OriginalCurrency string
+	Date             time.Time
+	FirstName        string
+	LastName         string
+}
+
+// amountIn returns the amount the donor had to transfer in the given currency
+func (c Candidate) amountIn(currency string) (money.Amount, bool) {
+	switch currency {
+	case c.OriginalCurrency:
+		return c.OriginalAmount, true
+	case c.Currency:
+		return c.Amount, true
+	default:
+		return money.Zero, false
+	}
+}
+
+// proposal is a scored match between a line and a donation
+type proposal struct {
+	LineIndex  int
+	DonationID uuid.UUID
+	Confidence float64
+	Reasons    []string
+}
+
+// scoreMatch returns the confidence that a line pays a donation, and whether they can match at all
+func scoreMatch(line Line, c Candidate) (float64, []string, bool) {
+	amount, ok := c.amountIn(line.Currency)
+	if !ok || amount != line.Amount {
+		return 0, nil, false
+	}
+
+	days := math.Abs(float64(dayOf(line.PostedAt).Sub(dayOf(c.Date)).Hours() / 24))
+	if days > MatchWindowDays {
+		return 0, nil, false
+	}
+
+	confidence := amountPoints
+	reasons := []string{"same amount"}
+
+	confidence += maxDatePoints * (1 - days/(MatchWindowDays+1))
+	if days == 0 {
+		reasons = append(reasons, "same day")
+	} else {
+		reasons = append(reasons, fmt.Sprintf("%.0f days apart", days))
+	}
+
+	text := normalizeText(strings.Join([]string{
+		stringValue(line.PayerName), stringValue(line.PayerReference), stringValue(line.Description),
+	}, " "))
+	firstName, lastName := normalizeText(c.FirstName), normalizeText(c.LastName)
+	switch {
+	case strings.Contains(text, strings.ToLower(c.DonationID.String()[:donationReferenceSize])):
+		confidence += donationRefPoints
+		reasons = append(reasons, "donation reference")
+	case firstName != "" && lastName != "" && strings.Contains(text, firstName) && strings.Contains(text, lastName):
+		confidence += fullNamePoints
+		reasons = append(reasons, "payer name")
+	case lastName != "" && strings.Contains(text, lastName):
+		confidence += lastNamePoints
+		reasons = append(reasons, "payer last name")
+	}
+
+	return confidence, reasons, true
+}
+
+// proposeMatches pairs lines with donations, best scores first, so that each line and each
+// donation is used once
+func proposeMatches(lines []Line, candidates []Candidate) []proposal {
+	var proposals []proposal
+	matchesPerLine := make(map[int]int)
+	for i, line := range lines {
+		for _, c := range candidates {
+			confidence, reasons, ok := scoreMatch(line, c)
+			if !ok {
+				continue
+			}
+			matchesPerLine[i]++
+			proposals = append(proposals, proposal{LineIndex: i, DonationID: c.DonationID, Confidence: confidence, Reasons: reasons})
+		}
+	}
+
+	// Lines that could pay several donations are less certain
+	for i := range proposals {
+		if count := matchesPerLine[proposals[i].LineIndex]; count > 1 {
+			proposals[i].Confidence -= ambiguityPenalty
+			proposals[i].Reasons = append(proposals[i].Reasons, fmt.Sprintf("%d possible donations", count))
+		}
+	}
+
+	sort.SliceStable(proposals, func(i, j int) bool {
+		return proposals[i].Confidence > proposals[j].Confidence
+	})
+
+	usedLines := make(map[int]bool)
+	usedDonations := make(map[uuid.UUID]bool)
+	var selected []proposal
+	for _, p := range proposals {
+		if usedLines[p.LineIndex] || usedDonations[p.DonationID] {
+			continue
+		}
+		usedLines[p.LineIndex] = true
+		usedDonations[p.DonationID] = true
+		p.Confidence = math.Round(math.Min(p.Confidence, 100)*100) / 100
+		selected = append(selected, p)
+	}
+
+	return selected
+}
+
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// normalizeText lowercases text and removes Spanish accents so names can be compared
+func normalizeText(value string) string {
+	return accentReplacer.Replace(strings.ToLower(strings.TrimSpace(value)))
+}
+
+var accentReplacer = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n")
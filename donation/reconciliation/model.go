@@ -0,0 +1,144 @@
+package reconciliation
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// StatementModel represents the database table structure with GORM tags
+type StatementModel struct {
+	ID               uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	CampaignID       uuid.UUID  `gorm:"column:campaign_id;type:uuid;not null;index"`
+	TransferDetailID int        `gorm:"column:transfer_detail_id;not null"`
+	FileName         string     `gorm:"column:file_name;type:varchar(255);not null"`
+	Format           string     `gorm:"column:format;type:varchar(10);not null"`
+	Currency         string     `gorm:"column:currency;type:varchar(3);not null"`
+	LinesImported    int        `gorm:"column:lines_imported;not null;default:0"`
+	LinesDuplicated  int        `gorm:"column:lines_duplicated;not null;default:0"`
+	LinesSkipped     int        `gorm:"column:lines_skipped;not null;default:0"`
+	UploadedBy       *uuid.UUID `gorm:"column:uploaded_by;type:uuid"`
+	CreatedAt        time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (StatementModel) TableName() string {
+	return "bank_statements"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m StatementModel) ToEntity() Statement {
+	return Statement{
+		ID:               m.ID,
+		CampaignID:       m.CampaignID,
+		TransferDetailID: m.TransferDetailID,
+		FileName:         m.FileName,
+		Format:           StatementFormat(m.Format),
+		Currency:         m.Currency,
+		LinesImported:    m.LinesImported,
+		LinesDuplicated:  m.LinesDuplicated,
+		LinesSkipped:     m.LinesSkipped,
+		UploadedBy:       m.UploadedBy,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *StatementModel) FromEntity(entity Statement) {
+	m.ID = entity.ID
+	m.CampaignID = entity.CampaignID
+	m.TransferDetailID = entity.TransferDetailID
+	m.FileName = entity.FileName
+	m.Format = string(entity.Format)
+	m.Currency = entity.Currency
+	m.LinesImported = entity.LinesImported
+	m.LinesDuplicated = entity.LinesDuplicated
+	m.LinesSkipped = entity.LinesSkipped
+	m.UploadedBy = entity.UploadedBy
+	m.CreatedAt = entity.CreatedAt
+}
+
+// LineModel represents the bank statement lines table
+type LineModel struct {
+	ID               uuid.UUID    `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	StatementID      uuid.UUID    `gorm:"column:statement_id;type:uuid;not null;index"`
+	CampaignID       uuid.UUID    `gorm:"column:campaign_id;type:uuid;not null"`
+	TransferDetailID int          `gorm:"column:transfer_detail_id;not null"`
+	LineNumber       int          `gorm:"column:line_number;not null"`
+	PostedAt         time.Time    `gorm:"column:posted_at;not null"`
+	Amount           money.Amount `gorm:"column:amount;type:decimal(10,2);not null"`
+	Currency         string       `gorm:"column:currency;type:varchar(3);not null"`
+	PayerName        *string      `gorm:"column:payer_name;type:varchar(255)"`
+	PayerReference   *string      `gorm:"column:payer_reference;type:varchar(100)"`
+	Description      *string      `gorm:"column:description"`
+	ExternalID       *string      `gorm:"column:external_id;type:varchar(255)"`
+	Fingerprint      string       `gorm:"column:fingerprint;type:varchar(64);not null"`
+	Status           string       `gorm:"column:status;type:varchar(20);not null;default:unmatched"`
+	DonationID       *uuid.UUID   `gorm:"column:donation_id;type:uuid"`
+	Confidence       *float64     `gorm:"column:confidence;type:decimal(5,2)"`
+	MatchReason      *string      `gorm:"column:match_reason"`
+	Notes            *string      `gorm:"column:notes"`
+	ReviewedBy       *uuid.UUID   `gorm:"column:reviewed_by;type:uuid"`
+	ReviewedAt       *time.Time   `gorm:"column:reviewed_at"`
+	CreatedAt        time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt        time.Time    `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (LineModel) TableName() string {
+	return "bank_statement_lines"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m LineModel) ToEntity() Line {
+	return Line{
+		ID:               m.ID,
+		StatementID:      m.StatementID,
+		CampaignID:       m.CampaignID,
+		TransferDetailID: m.TransferDetailID,
+		LineNumber:       m.LineNumber,
+		PostedAt:         m.PostedAt,
+		Amount:           m.Amount,
+		Currency:         m.Currency,
+		PayerName:        m.PayerName,
+		PayerReference:   m.PayerReference,
+		Description:      m.Description,
+		ExternalID:       m.ExternalID,
+		Fingerprint:      m.Fingerprint,
+		Status:           LineStatus(m.Status),
+		DonationID:       m.DonationID,
+		Confidence:       m.Confidence,
+		MatchReason:      m.MatchReason,
+		Notes:            m.Notes,
+		ReviewedBy:       m.ReviewedBy,
+		ReviewedAt:       m.ReviewedAt,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *LineModel) FromEntity(entity Line) {
+	m.ID = entity.ID
+	m.StatementID = entity.StatementID
+	m.CampaignID = entity.CampaignID
+	m.TransferDetailID = entity.TransferDetailID
+	m.LineNumber = entity.LineNumber
+	m.PostedAt = entity.PostedAt
+	m.Amount = entity.Amount
+	m.Currency = entity.Currency
+	m.PayerName = entity.PayerName
+	m.PayerReference = entity.PayerReference
+	m.Description = entity.Description
+	m.ExternalID = entity.ExternalID
+	m.Fingerprint = entity.Fingerprint
+	m.Status = string(entity.Status)
+	m.DonationID = entity.DonationID
+	m.Confidence = entity.Confidence
+	m.MatchReason = entity.MatchReason
+	m.Notes = entity.Notes
+	m.ReviewedBy = entity.ReviewedBy
+	m.ReviewedAt = entity.ReviewedAt
+	m.CreatedAt = entity.CreatedAt
+}
@@ -0,0 +1,291 @@
+package reconciliation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"dona_tutti_api/money"
+)
+
+// MaxStatementLines is the maximum number of movements in an uploaded statement
+const MaxStatementLines = 10000
+
+// parsedStatement holds the movements read from a statement file
+type parsedStatement struct {
+	Lines    []Line // Credits only
+	Skipped  int    // Debits and zero amounts
+	Currency string // Declared by OFX files, empty otherwise
+	Errors   []ImportError
+}
+
+// csvColumns maps the accepted CSV header names, in English or Spanish, to statement fields
+var csvColumns = map[string]string{
+	"date":            "date",
+	"fecha":           "date",
+	"amount":          "amount",
+	"importe":         "amount",
+	"monto":           "amount",
+	"credito":         "amount",
+	"description":     "description",
+	"descripcion":     "description",
+	"concepto":        "description",
+	"payer_name":      "payer_name",
+	"nombre":          "payer_name",
+	"ordenante":       "payer_name",
+	"payer_reference": "payer_reference",
+	"cbu":             "payer_reference",
+	"cuit":            "payer_reference",
+	"reference":       "reference",
+	"referencia":      "reference",
+	"id":              "reference",
+}
+
+// parseCSV reads a statement with a header row. The date and amount columns are required;
+// description, payer_name, payer_reference and reference are optional. Columns may be separated
+// by commas or semicolons.
+func parseCSV(content []byte) (parsedStatement, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := bytes.Cut(content, []byte("\n")); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return parsedStatement{}, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		if field, ok := csvColumns[normalizeText(name)]; ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns["date"]; !ok {
+		return parsedStatement{}, fmt.Errorf("header must contain a date column")
+	}
+	if _, ok := columns["amount"]; !ok {
+		return parsedStatement{}, fmt.Errorf("header must contain an amount column")
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var result parsedStatement
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Line: line, Message: err.Error()})
+			break
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		postedAt, err := parseStatementDate(field(record, "date"))
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		amount, err := parseStatementAmount(field(record, "amount"))
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		if !amount.IsPositive() {
+			result.Skipped++
+			continue
+		}
+
+		result.Lines = append(result.Lines, Line{
+			LineNumber:     line,
+			PostedAt:       postedAt,
+			Amount:         amount,
+			PayerName:      optional(field(record, "payer_name")),
+			PayerReference: optional(field(record, "payer_reference")),
+			Description:    optional(field(record, "description")),
+			ExternalID:     optional(field(record, "reference")),
+		})
+		if len(result.Lines) > MaxStatementLines {
+			return parsedStatement{}, fmt.Errorf("statement cannot contain more than %d movements", MaxStatementLines)
+		}
+	}
+
+	return result, nil
+}
+
+var (
+	ofxTransactionPattern = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+	ofxElementPattern     = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+	ofxCurrencyPattern    = regexp.MustCompile(`(?i)<CURDEF>([^<\r\n]*)`)
+)
+
+// parseOFX reads the transactions of an OFX statement, in SGML (OFX 1.x) or XML (OFX 2.x) syntax
+func parseOFX(content []byte) (parsedStatement, error) {
+	var result parsedStatement
+	if match := ofxCurrencyPattern.FindSubmatch(content); match != nil {
+		result.Currency = strings.ToUpper(strings.TrimSpace(string(match[1])))
+	}
+
+	transactions := ofxTransactionPattern.FindAllSubmatch(content, -1)
+	if len(transactions) == 0 {
+		return parsedStatement{}, fmt.Errorf("file does not contain any OFX transaction")
+	}
+	if len(transactions) > MaxStatementLines {
+		return parsedStatement{}, fmt.Errorf("statement cannot contain more than %d movements", MaxStatementLines)
+	}
+
+	for i, transaction := range transactions {
+		line := i + 1
+		elements := make(map[string]string)
+		for _, element := range ofxElementPattern.FindAllSubmatch(transaction[1], -1) {
+			name := strings.ToUpper(string(element[1]))
+			if _, exists := elements[name]; !exists {
+				elements[name] = strings.TrimSpace(string(element[2]))
+			}
+		}
+
+		postedAt, err := parseOFXDate(elements["DTPOSTED"])
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		amount, err := money.Parse(elements["TRNAMT"])
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Line: line, Message: fmt.Sprintf("invalid amount %q", elements["TRNAMT"])})
+			continue
+		}
+		if !amount.IsPositive() {
+			result.Skipped++
+			continue
+		}
+
+		result.Lines = append(result.Lines, Line{
+			LineNumber:     line,
+			PostedAt:       postedAt,
+			Amount:         amount,
+			PayerName:      optional(elements["NAME"]),
+			PayerReference: optional(elements["ACCTID"]),
+			Description:    optional(elements["MEMO"]),
+			ExternalID:     optional(elements["FITID"]),
+		})
+	}
+
+	return result, nil
+}
+
+// parseStatementAmount parses amounts written with either decimal separator, such as
+// "1234.50", "1.234,50" or "$ 1,234.50". A lone comma is read as the decimal separator.
+func parseStatementAmount(value string) (money.Amount, error) {
+	normalized := strings.NewReplacer("$", "", " ", "").Replace(value)
+	lastComma, lastDot := strings.LastIndex(normalized, ","), strings.LastIndex(normalized, ".")
+	switch {
+	case lastComma >= 0 && lastDot >= 0 && lastComma > lastDot:
+		normalized = strings.ReplaceAll(normalized, ".", "")
+		normalized = strings.Replace(normalized, ",", ".", 1)
+	case lastComma >= 0 && lastDot >= 0:
+		normalized = strings.ReplaceAll(normalized, ",", "")
+	case lastComma >= 0:
+		normalized = strings.Replace(normalized, ",", ".", 1)
+	}
+
+	amount, err := money.Parse(normalized)
+	if err != nil {
+		return money.Zero, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+var statementDateLayouts = []string{
+	"2006-01-02",
+	"02/01/2006",
+	"02-01-2006",
+	"2006-01-02 15:04:05",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	time.RFC3339,
+}
+
+// parseStatementDate parses ISO dates and the day-first dates used by Argentine banks
+func parseStatementDate(value string) (time.Time, error) {
+	for _, layout := range statementDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or DD/MM/YYYY", value)
+}
+
+// parseOFXDate parses OFX dates such as 20261015 or 20261015120000.000[-3:ART]. The time zone is
+// ignored: matching works on whole days.
+func parseOFXDate(value string) (time.Time, error) {
+	digits := value
+	if i := strings.IndexAny(digits, ".["); i >= 0 {
+		digits = digits[:i]
+	}
+	switch len(digits) {
+	case 8:
+		return time.Parse("20060102", digits)
+	case 12:
+		return time.Parse("200601021504", digits)
+	case 14:
+		return time.Parse("20060102150405", digits)
+	}
+	return time.Time{}, fmt.Errorf("invalid DTPOSTED %q", value)
+}
+
+// setFingerprints identifies each line by its bank transaction ID or, without one, by its
+// content and position among identical lines of the file
+func setFingerprints(transferDetailID int, lines []Line) {
+	occurrences := make(map[string]int)
+	for i := range lines {
+		key := ""
+		if lines[i].ExternalID != nil {
+			key = "id|" + *lines[i].ExternalID
+		} else {
+			key = strings.Join([]string{
+				lines[i].PostedAt.Format("2006-01-02"),
+				lines[i].Amount.String(),
+				stringValue(lines[i].PayerName),
+				stringValue(lines[i].PayerReference),
+				stringValue(lines[i].Description),
+			}, "|")
+			occurrences[key]++
+			key = fmt.Sprintf("%s|%d", key, occurrences[key])
+		}
+
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%d|%s", transferDetailID, key)))
+		lines[i].Fingerprint = hex.EncodeToString(hash[:])
+	}
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,125 @@
+package reconciliation
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// StatementFormat is the file format of an uploaded bank statement
+type StatementFormat string
+
+const (
+	FormatCSV StatementFormat = "csv"
+	FormatOFX StatementFormat = "ofx"
+)
+
+// LineStatus represents the reconciliation state of a bank statement line
+type LineStatus string
+
+const (
+	LineStatusUnmatched LineStatus = "unmatched" // Waiting in the reconciliation queue
+	LineStatusProposed  LineStatus = "proposed"  // Matched to a pending donation, waiting for an admin
+	LineStatusConfirmed LineStatus = "confirmed" // The donation was completed
+	LineStatusIgnored   LineStatus = "ignored"   // Not a donation, such as a bank fee or an internal transfer
+)
+
+// IsValidLineStatus checks if a line status is valid
+func IsValidLineStatus(status LineStatus) bool {
+	switch status {
+	case LineStatusUnmatched, LineStatusProposed, LineStatusConfirmed, LineStatusIgnored:
+		return true
+	default:
+		return false
+	}
+}
+
+// Statement is a bank statement uploaded for the transfer account of a campaign
+type Statement struct {
+	ID               uuid.UUID       `json:"id"`
+	CampaignID       uuid.UUID       `json:"campaign_id"`
+	TransferDetailID int             `json:"transfer_detail_id"`
+	FileName         string          `json:"file_name"`
+	Format           StatementFormat `json:"format"`
+	Currency         string          `json:"currency"`
+	LinesImported    int             `json:"lines_imported"`
+	LinesDuplicated  int             `json:"lines_duplicated"` // Already imported by a previous statement
+	LinesSkipped     int             `json:"lines_skipped"`    // Debits and zero amounts
+	UploadedBy       *uuid.UUID      `json:"uploaded_by,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	Lines            []Line          `json:"lines,omitempty"`
+}
+
+// Line is an incoming transfer read from a bank statement
+type Line struct {
+	ID               uuid.UUID    `json:"id"`
+	StatementID      uuid.UUID    `json:"statement_id"`
+	CampaignID       uuid.UUID    `json:"campaign_id"`
+	TransferDetailID int          `json:"transfer_detail_id"`
+	LineNumber       int          `json:"line_number"`
+	PostedAt         time.Time    `json:"posted_at"`
+	Amount           money.Amount `json:"amount"`
+	Currency         string       `json:"currency"`
+	PayerName        *string      `json:"payer_name,omitempty"`
+	PayerReference   *string      `json:"payer_reference,omitempty"` // CBU, CUIT or alias of the payer
+	Description      *string      `json:"description,omitempty"`
+	ExternalID       *string      `json:"external_id,omitempty"` // Bank transaction ID
+	Status           LineStatus   `json:"status"`
+	DonationID       *uuid.UUID   `json:"donation_id,omitempty"`
+	Confidence       *float64     `json:"confidence,omitempty"` // 0-100, set when a donation is proposed
+	MatchReason      *string      `json:"match_reason,omitempty"`
+	Notes            *string      `json:"notes,omitempty"`
+	ReviewedBy       *uuid.UUID   `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time   `json:"reviewed_at,omitempty"`
+	Fingerprint      string       `json:"-"` // Identifies the bank movement across overlapping statements
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+// LineFilter represents the optional filters of the reconciliation queue
+type LineFilter struct {
+	CampaignID  *uuid.UUID
+	StatementID *uuid.UUID
+	Statuses    []LineStatus // Defaults to the open statuses, unmatched and proposed
+}
+
+// ImportError describes a line of a statement file that could not be read
+type ImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportResult summarizes a statement upload
+type ImportResult struct {
+	Statement *Statement    `json:"statement,omitempty"`
+	Proposed  int           `json:"proposed"` // Lines matched to a pending donation
+	Errors    []ImportError `json:"errors,omitempty"`
+}
+
+// MatchResult summarizes a matching run over the reconciliation queue
+type MatchResult struct {
+	Proposed int    `json:"proposed"`
+	Lines    []Line `json:"lines"`
+}
+
+// ManualMatchRequest represents an admin matching a line to a donation
+type ManualMatchRequest struct {
+	DonationID uuid.UUID `json:"donation_id" validate:"required"`
+}
+
+// ConfirmMatchesRequest represents an admin confirming proposed matches
+type ConfirmMatchesRequest struct {
+	LineIDs []uuid.UUID `json:"line_ids" validate:"required,min=1"`
+}
+
+// ConfirmResult summarizes a bulk confirmation
+type ConfirmResult struct {
+	Confirmed []uuid.UUID       `json:"confirmed"`
+	Failed    map[string]string `json:"failed,omitempty"` // Line ID to error
+}
+
+// IgnoreLineRequest represents an admin removing a line from the queue
+type IgnoreLineRequest struct {
+	Notes string `json:"notes"`
+}
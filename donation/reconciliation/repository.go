@@ -0,0 +1,262 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrLineNotOpen is returned when a line was already confirmed, ignored or changed by another admin
+var ErrLineNotOpen = errors.New("statement line is not waiting for reconciliation")
+
+type Repository interface {
+	TransferDetailBelongsToCampaign(ctx context.Context, transferDetailID int, campaignID uuid.UUID) (bool, error)
+	GetImportedFingerprints(ctx context.Context, transferDetailID int, fingerprints []string) (map[string]bool, error)
+	CreateStatement(ctx context.Context, statement Statement, lines []Line) error
+	GetStatement(ctx context.Context, id uuid.UUID) (Statement, error)
+	ListStatements(ctx context.Context, campaignID uuid.UUID) ([]Statement, error)
+	GetLine(ctx context.Context, id uuid.UUID) (Line, error)
+	ListLines(ctx context.Context, filter LineFilter) ([]Line, error)
+	ListCandidates(ctx context.Context, campaignID uuid.UUID, from, to time.Time) ([]Candidate, error)
+	GetCandidate(ctx context.Context, campaignID, donationID, lineID uuid.UUID) (Candidate, error)
+	ProposeMatch(ctx context.Context, line Line, from []LineStatus) error
+	ClearMatch(ctx context.Context, id uuid.UUID) error
+	ConfirmLine(ctx context.Context, id, donationID uuid.UUID, reviewedBy *uuid.UUID, reviewedAt time.Time) error
+	UnconfirmLine(ctx context.Context, id uuid.UUID) error
+	IgnoreLine(ctx context.Context, id uuid.UUID, notes string, reviewedBy *uuid.UUID, reviewedAt time.Time) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) TransferDetailBelongsToCampaign(ctx context.Context, transferDetailID int, campaignID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("transfer_details td").
+		Joins("JOIN campaign_payment_methods cpm ON cpm.id = td.campaign_payment_method_id").
+		Where("td.id = ? AND cpm.campaign_id = ?", transferDetailID, campaignID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetImportedFingerprints returns which of the fingerprints were already imported for the account
+func (r *repository) GetImportedFingerprints(ctx context.Context, transferDetailID int, fingerprints []string) (map[string]bool, error) {
+	imported := make(map[string]bool)
+	if len(fingerprints) == 0 {
+		return imported, nil
+	}
+
+	var existing []string
+	err := r.db.WithContext(ctx).
+		Model(&LineModel{}).
+		Where("transfer_detail_id = ? AND fingerprint IN ?", transferDetailID, fingerprints).
+		Pluck("fingerprint", &existing).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fingerprint := range existing {
+		imported[fingerprint] = true
+	}
+	return imported, nil
+}
+
+// CreateStatement stores a statement and its lines in one transaction
+func (r *repository) CreateStatement(ctx context.Context, statement Statement, lines []Line) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model StatementModel
+		model.FromEntity(statement)
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+
+		if len(lines) == 0 {
+			return nil
+		}
+		models := make([]LineModel, len(lines))
+		for i, line := range lines {
+			models[i].FromEntity(line)
+		}
+		return tx.CreateInBatches(&models, 500).Error
+	})
+}
+
+func (r *repository) GetStatement(ctx context.Context, id uuid.UUID) (Statement, error) {
+	var model StatementModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return Statement{}, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *repository) ListStatements(ctx context.Context, campaignID uuid.UUID) ([]Statement, error) {
+	var models []StatementModel
+	if err := r.db.WithContext(ctx).Where("campaign_id = ?", campaignID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	statements := make([]Statement, len(models))
+	for i, model := range models {
+		statements[i] = model.ToEntity()
+	}
+	return statements, nil
+}
+
+func (r *repository) GetLine(ctx context.Context, id uuid.UUID) (Line, error) {
+	var model LineModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return Line{}, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+// ListLines returns the lines matching the filter, oldest movements first
+func (r *repository) ListLines(ctx context.Context, filter LineFilter) ([]Line, error) {
+	query := r.db.WithContext(ctx)
+	if filter.CampaignID != nil {
+		query = query.Where("campaign_id = ?", *filter.CampaignID)
+	}
+	if filter.StatementID != nil {
+		query = query.Where("statement_id = ?", *filter.StatementID)
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where("status IN ?", statuses)
+	}
+
+	var models []LineModel
+	if err := query.Order("posted_at ASC, line_number ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return toLineEntities(models), nil
+}
+
+// candidatesQuery selects the campaign's pending transfer donations that no line proposes or confirmed yet
+func (r *repository) candidatesQuery(ctx context.Context, campaignID uuid.UUID, lineID uuid.UUID) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("donations d").
+		Select(`d.id AS donation_id, d.amount, d.currency, d.original_amount, d.original_currency, d.date,
+			COALESCE(dn.first_name, '') AS first_name, COALESCE(dn.last_name, '') AS last_name`).
+		Joins("JOIN payment_methods pm ON pm.id = d.payment_method_id").
+		Joins("LEFT JOIN donors dn ON dn.id = d.donor_id").
+		Where("d.campaign_id = ? AND d.status = ? AND pm.code = ?", campaignID, "pending", "transfer").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM bank_statement_lines l
+			WHERE l.donation_id = d.id AND l.status IN ? AND l.id <> ?
+		)`, []string{string(LineStatusProposed), string(LineStatusConfirmed)}, lineID)
+}
+
+// ListCandidates returns the donations made between from and to that lines can be matched to
+func (r *repository) ListCandidates(ctx context.Context, campaignID uuid.UUID, from, to time.Time) ([]Candidate, error) {
+	var candidates []Candidate
+	err := r.candidatesQuery(ctx, campaignID, uuid.Nil).
+		Where("d.date BETWEEN ? AND ?", from, to).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+// GetCandidate returns a donation that the line can be matched to, ignoring the line's own proposal
+func (r *repository) GetCandidate(ctx context.Context, campaignID, donationID, lineID uuid.UUID) (Candidate, error) {
+	var candidates []Candidate
+	err := r.candidatesQuery(ctx, campaignID, lineID).
+		Where("d.id = ?", donationID).
+		Scan(&candidates).Error
+	if err != nil {
+		return Candidate{}, err
+	}
+	if len(candidates) == 0 {
+		return Candidate{}, gorm.ErrRecordNotFound
+	}
+	return candidates[0], nil
+}
+
+// ProposeMatch stores the line's proposed donation if the line is still in one of the from statuses
+func (r *repository) ProposeMatch(ctx context.Context, line Line, from []LineStatus) error {
+	statuses := make([]string, len(from))
+	for i, status := range from {
+		statuses[i] = string(status)
+	}
+
+	return r.updateLine(r.db.WithContext(ctx).Where("id = ? AND status IN ?", line.ID, statuses), map[string]interface{}{
+		"status":       string(LineStatusProposed),
+		"donation_id":  line.DonationID,
+		"confidence":   line.Confidence,
+		"match_reason": line.MatchReason,
+	})
+}
+
+// ClearMatch returns a proposed line to the queue
+func (r *repository) ClearMatch(ctx context.Context, id uuid.UUID) error {
+	return r.updateLine(r.db.WithContext(ctx).Where("id = ? AND status = ?", id, string(LineStatusProposed)), map[string]interface{}{
+		"status":       string(LineStatusUnmatched),
+		"donation_id":  nil,
+		"confidence":   nil,
+		"match_reason": nil,
+	})
+}
+
+// ConfirmLine confirms a line that still proposes the given donation
+func (r *repository) ConfirmLine(ctx context.Context, id, donationID uuid.UUID, reviewedBy *uuid.UUID, reviewedAt time.Time) error {
+	query := r.db.WithContext(ctx).Where("id = ? AND status = ? AND donation_id = ?", id, string(LineStatusProposed), donationID)
+	return r.updateLine(query, map[string]interface{}{
+		"status":      string(LineStatusConfirmed),
+		"reviewed_by": reviewedBy,
+		"reviewed_at": reviewedAt,
+	})
+}
+
+// UnconfirmLine sends a confirmed line back to its proposal, when its donation could not be completed
+func (r *repository) UnconfirmLine(ctx context.Context, id uuid.UUID) error {
+	return r.updateLine(r.db.WithContext(ctx).Where("id = ? AND status = ?", id, string(LineStatusConfirmed)), map[string]interface{}{
+		"status":      string(LineStatusProposed),
+		"reviewed_by": nil,
+		"reviewed_at": nil,
+	})
+}
+
+// IgnoreLine removes an open line from the queue, dropping its proposal
+func (r *repository) IgnoreLine(ctx context.Context, id uuid.UUID, notes string, reviewedBy *uuid.UUID, reviewedAt time.Time) error {
+	open := []string{string(LineStatusUnmatched), string(LineStatusProposed)}
+	return r.updateLine(r.db.WithContext(ctx).Where("id = ? AND status IN ?", id, open), map[string]interface{}{
+		"status":       string(LineStatusIgnored),
+		"donation_id":  nil,
+		"confidence":   nil,
+		"match_reason": nil,
+		"notes":        notes,
+		"reviewed_by":  reviewedBy,
+		"reviewed_at":  reviewedAt,
+	})
+}
+
+func (r *repository) updateLine(query *gorm.DB, updates map[string]interface{}) error {
+	result := query.Model(&LineModel{}).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLineNotOpen
+	}
+	return nil
+}
+
+func toLineEntities(models []LineModel) []Line {
+	lines := make([]Line, len(models))
+	for i, model := range models {
+		lines[i] = model.ToEntity()
+	}
+	return lines
+}
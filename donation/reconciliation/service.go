@@ -0,0 +1,426 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"dona_tutti_api/currency"
+	"dona_tutti_api/donation"
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxConfirmLines is the maximum number of lines confirmed in one request
+const MaxConfirmLines = 500
+
+// DonationService defines the donation operations needed by the reconciliation service
+type DonationService interface {
+	UpdateDonationStatus(ctx context.Context, id uuid.UUID, status donation.DonationStatus, change donation.StatusChange) error
+}
+
+// CampaignService defines the campaign operations needed by the reconciliation service
+type CampaignService interface {
+	GetCampaignCurrency(ctx context.Context, campaignID uuid.UUID) (string, error)
+}
+
+// StatementUpload describes an uploaded statement file
+type StatementUpload struct {
+	CampaignID       uuid.UUID
+	TransferDetailID int
+	FileName         string
+	Format           StatementFormat
+	Currency         string // Optional, defaults to the OFX currency or the campaign currency
+	UploadedBy       *uuid.UUID
+}
+
+type Service interface {
+	ImportStatement(ctx context.Context, upload StatementUpload, file io.Reader) (ImportResult, error)
+	GetStatement(ctx context.Context, id uuid.UUID) (Statement, error)
+	ListStatements(ctx context.Context, campaignID uuid.UUID) ([]Statement, error)
+	ListLines(ctx context.Context, filter LineFilter) ([]Line, error)
+	MatchLines(ctx context.Context, campaignID uuid.UUID) (MatchResult, error)
+	MatchLine(ctx context.Context, lineID uuid.UUID, req ManualMatchRequest) (Line, error)
+	ClearMatch(ctx context.Context, lineID uuid.UUID) (Line, error)
+	IgnoreLine(ctx context.Context, lineID uuid.UUID, req IgnoreLineRequest, reviewedBy *uuid.UUID) (Line, error)
+	ConfirmMatches(ctx context.Context, req ConfirmMatchesRequest, reviewedBy *uuid.UUID) (ConfirmResult, error)
+}
+
+type service struct {
+	repo            Repository
+	donationService DonationService
+	campaignService CampaignService
+}
+
+func NewService(repo Repository, donationService DonationService, campaignService CampaignService) Service {
+	return &service{repo: repo, donationService: donationService, campaignService: campaignService}
+}
+
+// ImportStatement reads the credits of a statement for a campaign's transfer account, skips the
+// movements imported by previous statements and proposes matches for the new ones. If any line
+// cannot be read nothing is imported and the errors are returned.
+func (s *service) ImportStatement(ctx context.Context, upload StatementUpload, file io.Reader) (ImportResult, error) {
+	belongs, err := s.repo.TransferDetailBelongsToCampaign(ctx, upload.TransferDetailID, upload.CampaignID)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to check transfer detail: %w", err)
+	}
+	if !belongs {
+		return ImportResult{}, apierrors.NewNotFoundError("transfer detail not found for this campaign")
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to read statement: %w", err)
+	}
+
+	var parsed parsedStatement
+	switch upload.Format {
+	case FormatCSV:
+		parsed, err = parseCSV(content)
+	case FormatOFX:
+		parsed, err = parseOFX(content)
+	default:
+		return ImportResult{}, apierrors.NewFieldValidationError("file", "statement must be a CSV or OFX file")
+	}
+	if err != nil {
+		return ImportResult{}, apierrors.NewFieldValidationError("file", err.Error())
+	}
+	if len(parsed.Errors) > 0 {
+		return ImportResult{Errors: parsed.Errors}, nil
+	}
+
+	statementCurrency := strings.ToUpper(strings.TrimSpace(upload.Currency))
+	if statementCurrency == "" {
+		statementCurrency = parsed.Currency
+	}
+	if statementCurrency == "" {
+		statementCurrency, err = s.campaignService.GetCampaignCurrency(ctx, upload.CampaignID)
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("failed to get campaign currency: %w", err)
+		}
+	}
+	if !currency.IsSupported(statementCurrency) {
+		return ImportResult{}, apierrors.NewFieldValidationError("currency", fmt.Sprintf("unsupported currency %s", statementCurrency))
+	}
+
+	setFingerprints(upload.TransferDetailID, parsed.Lines)
+	fingerprints := make([]string, len(parsed.Lines))
+	for i, line := range parsed.Lines {
+		fingerprints[i] = line.Fingerprint
+	}
+	imported, err := s.repo.GetImportedFingerprints(ctx, upload.TransferDetailID, fingerprints)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to check imported lines: %w", err)
+	}
+
+	now := time.Now()
+	statement := Statement{
+		ID:               uuid.New(),
+		CampaignID:       upload.CampaignID,
+		TransferDetailID: upload.TransferDetailID,
+		FileName:         upload.FileName,
+		Format:           upload.Format,
+		Currency:         statementCurrency,
+		LinesSkipped:     parsed.Skipped,
+		UploadedBy:       upload.UploadedBy,
+		CreatedAt:        now,
+	}
+
+	var lines []Line
+	for _, line := range parsed.Lines {
+		if imported[line.Fingerprint] {
+			statement.LinesDuplicated++
+			continue
+		}
+		line.ID = uuid.New()
+		line.StatementID = statement.ID
+		line.CampaignID = statement.CampaignID
+		line.TransferDetailID = statement.TransferDetailID
+		line.Currency = statementCurrency
+		line.Status = LineStatusUnmatched
+		line.CreatedAt = now
+		lines = append(lines, line)
+	}
+	statement.LinesImported = len(lines)
+
+	if err := s.repo.CreateStatement(ctx, statement, lines); err != nil {
+		return ImportResult{}, fmt.Errorf("failed to create statement: %w", err)
+	}
+
+	proposed, err := s.proposeForLines(ctx, upload.CampaignID, lines)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	statement.Lines, err = s.repo.ListLines(ctx, LineFilter{StatementID: &statement.ID})
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to get statement lines: %w", err)
+	}
+
+	log.Printf("🏦 Imported statement %s for campaign %s: %d lines, %d duplicated, %d proposed",
+		statement.FileName, statement.CampaignID.String(), statement.LinesImported, statement.LinesDuplicated, proposed)
+
+	return ImportResult{Statement: &statement, Proposed: proposed}, nil
+}
+
+func (s *service) GetStatement(ctx context.Context, id uuid.UUID) (Statement, error) {
+	statement, err := s.repo.GetStatement(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Statement{}, apierrors.NewNotFoundError("statement not found")
+		}
+		return Statement{}, fmt.Errorf("failed to get statement: %w", err)
+	}
+
+	statement.Lines, err = s.repo.ListLines(ctx, LineFilter{StatementID: &statement.ID})
+	if err != nil {
+		return Statement{}, fmt.Errorf("failed to get statement lines: %w", err)
+	}
+
+	return statement, nil
+}
+
+func (s *service) ListStatements(ctx context.Context, campaignID uuid.UUID) ([]Statement, error) {
+	statements, err := s.repo.ListStatements(ctx, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statements: %w", err)
+	}
+
+	return statements, nil
+}
+
+// ListLines returns the reconciliation queue: by default the lines that are unmatched or waiting
+// for confirmation
+func (s *service) ListLines(ctx context.Context, filter LineFilter) ([]Line, error) {
+	for _, status := range filter.Statuses {
+		if !IsValidLineStatus(status) {
+			return nil, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid line status %s", status))
+		}
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []LineStatus{LineStatusUnmatched, LineStatusProposed}
+	}
+
+	lines, err := s.repo.ListLines(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// MatchLines runs the matcher again over the campaign's unmatched lines, for donations registered
+// after the statement was imported
+func (s *service) MatchLines(ctx context.Context, campaignID uuid.UUID) (MatchResult, error) {
+	lines, err := s.repo.ListLines(ctx, LineFilter{CampaignID: &campaignID, Statuses: []LineStatus{LineStatusUnmatched}})
+	if err != nil {
+		return MatchResult{}, fmt.Errorf("failed to list lines: %w", err)
+	}
+
+	proposed, err := s.proposeForLines(ctx, campaignID, lines)
+	if err != nil {
+		return MatchResult{}, err
+	}
+
+	lines, err = s.repo.ListLines(ctx, LineFilter{CampaignID: &campaignID, Statuses: []LineStatus{LineStatusProposed}})
+	if err != nil {
+		return MatchResult{}, fmt.Errorf("failed to list lines: %w", err)
+	}
+
+	return MatchResult{Proposed: proposed, Lines: lines}, nil
+}
+
+// MatchLine lets an admin pick the donation of an open line. The amounts must be equal but the
+// date window does not apply.
+func (s *service) MatchLine(ctx context.Context, lineID uuid.UUID, req ManualMatchRequest) (Line, error) {
+	if req.DonationID == uuid.Nil {
+		return Line{}, apierrors.NewFieldValidationError("donation_id", "donation ID is required")
+	}
+
+	line, err := s.getLine(ctx, lineID)
+	if err != nil {
+		return Line{}, err
+	}
+
+	candidate, err := s.repo.GetCandidate(ctx, line.CampaignID, req.DonationID, line.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Line{}, apierrors.NewFieldValidationError("donation_id",
+				"donation must be a pending transfer donation of the campaign that is not matched to another line")
+		}
+		return Line{}, fmt.Errorf("failed to get donation: %w", err)
+	}
+	amount, ok := candidate.amountIn(line.Currency)
+	if !ok || amount != line.Amount {
+		return Line{}, apierrors.NewFieldValidationError("donation_id",
+			fmt.Sprintf("donation amount does not match the line amount of %s", line.Amount.Format(line.Currency)))
+	}
+
+	confidence := 100.0
+	reason := "matched by an admin"
+	line.DonationID = &candidate.DonationID
+	line.Confidence = &confidence
+	line.MatchReason = &reason
+	if err := s.repo.ProposeMatch(ctx, line, []LineStatus{LineStatusUnmatched, LineStatusProposed}); err != nil {
+		return Line{}, s.lineUpdateError(err)
+	}
+
+	return s.getLine(ctx, lineID)
+}
+
+// ClearMatch rejects the proposed donation of a line, which goes back to the queue
+func (s *service) ClearMatch(ctx context.Context, lineID uuid.UUID) (Line, error) {
+	if _, err := s.getLine(ctx, lineID); err != nil {
+		return Line{}, err
+	}
+
+	if err := s.repo.ClearMatch(ctx, lineID); err != nil {
+		return Line{}, s.lineUpdateError(err)
+	}
+
+	return s.getLine(ctx, lineID)
+}
+
+// IgnoreLine removes a line that is not a donation, such as a bank fee, from the queue
+func (s *service) IgnoreLine(ctx context.Context, lineID uuid.UUID, req IgnoreLineRequest, reviewedBy *uuid.UUID) (Line, error) {
+	notes := strings.TrimSpace(req.Notes)
+	if notes == "" {
+		return Line{}, apierrors.NewFieldValidationError("notes", "notes are required to ignore a line")
+	}
+
+	if _, err := s.getLine(ctx, lineID); err != nil {
+		return Line{}, err
+	}
+
+	if err := s.repo.IgnoreLine(ctx, lineID, notes, reviewedBy, time.Now()); err != nil {
+		return Line{}, s.lineUpdateError(err)
+	}
+
+	return s.getLine(ctx, lineID)
+}
+
+// ConfirmMatches completes the proposed donation of each line, which generates its receipt. Lines
+// are confirmed one by one: the failures are returned without stopping the others.
+func (s *service) ConfirmMatches(ctx context.Context, req ConfirmMatchesRequest, reviewedBy *uuid.UUID) (ConfirmResult, error) {
+	if len(req.LineIDs) == 0 {
+		return ConfirmResult{}, apierrors.NewFieldValidationError("line_ids", "at least one line is required")
+	}
+	if len(req.LineIDs) > MaxConfirmLines {
+		return ConfirmResult{}, apierrors.NewFieldValidationError("line_ids",
+			fmt.Sprintf("cannot confirm more than %d lines at once", MaxConfirmLines))
+	}
+
+	result := ConfirmResult{Confirmed: []uuid.UUID{}, Failed: make(map[string]string)}
+	seen := make(map[uuid.UUID]bool)
+	for _, lineID := range req.LineIDs {
+		if seen[lineID] {
+			continue
+		}
+		seen[lineID] = true
+
+		if err := s.confirmLine(ctx, lineID, reviewedBy); err != nil {
+			result.Failed[lineID.String()] = err.Error()
+			continue
+		}
+		result.Confirmed = append(result.Confirmed, lineID)
+	}
+
+	if len(result.Confirmed) > 0 {
+		log.Printf("✅ Confirmed %d bank statement lines", len(result.Confirmed))
+	}
+
+	return result, nil
+}
+
+func (s *service) confirmLine(ctx context.Context, lineID uuid.UUID, reviewedBy *uuid.UUID) error {
+	line, err := s.getLine(ctx, lineID)
+	if err != nil {
+		return err
+	}
+	if line.Status != LineStatusProposed || line.DonationID == nil {
+		return apierrors.NewValidationError(fmt.Sprintf("only proposed lines can be confirmed, line status is %s", line.Status))
+	}
+
+	// The line is confirmed first, so that a line confirmed or changed by another admin never completes
+	// its donation. It goes back to its proposal if the donation cannot be completed.
+	if err := s.repo.ConfirmLine(ctx, lineID, *line.DonationID, reviewedBy, time.Now()); err != nil {
+		return s.lineUpdateError(err)
+	}
+
+	notes := fmt.Sprintf("bank statement line %d of statement %s", line.LineNumber, line.StatementID.String())
+	change := donation.StatusChange{ChangedBy: reviewedBy, Source: donation.StatusSourceReconciliation, Notes: &notes}
+	if err := s.donationService.UpdateDonationStatus(ctx, *line.DonationID, donation.DonationStatusCompleted, change); err != nil {
+		if unconfirmErr := s.repo.UnconfirmLine(ctx, lineID); unconfirmErr != nil {
+			fmt.Printf("Warning: failed to unconfirm line %s after its donation failed: %v\n", lineID.String(), unconfirmErr)
+		}
+		return fmt.Errorf("failed to complete donation: %w", err)
+	}
+
+	return nil
+}
+
+// proposeForLines proposes the best donation for each unmatched line, leaving the others in the queue
+func (s *service) proposeForLines(ctx context.Context, campaignID uuid.UUID, lines []Line) (int, error) {
+	if len(lines) == 0 {
+		return 0, nil
+	}
+
+	from, to := lines[0].PostedAt, lines[0].PostedAt
+	for _, line := range lines {
+		if line.PostedAt.Before(from) {
+			from = line.PostedAt
+		}
+		if line.PostedAt.After(to) {
+			to = line.PostedAt
+		}
+	}
+	window := time.Duration(MatchWindowDays+1) * 24 * time.Hour
+	candidates, err := s.repo.ListCandidates(ctx, campaignID, from.Add(-window), to.Add(window))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending donations: %w", err)
+	}
+
+	proposed := 0
+	for _, p := range proposeMatches(lines, candidates) {
+		line := lines[p.LineIndex]
+		confidence := p.Confidence
+		reason := strings.Join(p.Reasons, ", ")
+		line.DonationID = &p.DonationID
+		line.Confidence = &confidence
+		line.MatchReason = &reason
+
+		// The donation or the line may have been matched concurrently, leave the line in the queue
+		if err := s.repo.ProposeMatch(ctx, line, []LineStatus{LineStatusUnmatched}); err != nil {
+			fmt.Printf("Warning: failed to propose donation %s for line %s: %v\n", p.DonationID.String(), line.ID.String(), err)
+			continue
+		}
+		proposed++
+	}
+
+	return proposed, nil
+}
+
+func (s *service) getLine(ctx context.Context, id uuid.UUID) (Line, error) {
+	line, err := s.repo.GetLine(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Line{}, apierrors.NewNotFoundError("statement line not found")
+		}
+		return Line{}, fmt.Errorf("failed to get line: %w", err)
+	}
+
+	return line, nil
+}
+
+func (s *service) lineUpdateError(err error) error {
+	if errors.Is(err, ErrLineNotOpen) {
+		return apierrors.NewValidationError(err.Error())
+	}
+	return fmt.Errorf("failed to update line: %w", err)
+}
@@ -0,0 +1,250 @@
+package refund
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests for donation refunds
+type Handler struct {
+	service         Service
+	donationService DonationService
+}
+
+// NewHandler creates a new refunds handler
+func NewHandler(service Service, donationService DonationService) *Handler {
+	return &Handler{service: service, donationService: donationService}
+}
+
+// RegisterRoutes registers the refund routes
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.POST("/campaigns/:campaignId/donations/:id/refunds", h.RequestRefund)
+	adminGroup.GET("/campaigns/:campaignId/donations/:id/refunds", h.GetRefundsByDonation)
+	adminGroup.GET("/refunds", h.ListRefunds)
+	adminGroup.GET("/refunds/:id", h.GetRefund)
+	adminGroup.POST("/refunds/:id/approve", h.ApproveRefund)
+	adminGroup.POST("/refunds/:id/reject", h.RejectRefund)
+}
+
+// @Summary Request a refund
+// @Description Request a full or partial refund of a completed donation. Without amount the whole refundable amount is requested.
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Param id path string true "Donation ID"
+// @Param refund body CreateRefundRequest true "Refund details"
+// @Success 201 {object} Refund
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /campaigns/{campaignId}/donations/{id}/refunds [post]
+func (h *Handler) RequestRefund(c echo.Context) error {
+	donationID, err := h.campaignDonationID(c)
+	if err != nil {
+		return err
+	}
+
+	var req CreateRefundRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	refund, err := h.service.RequestRefund(c.Request().Context(), donationID, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, refund)
+}
+
+// @Summary Get refunds of a donation
+// @Description Get all refunds requested for a donation
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Param id path string true "Donation ID"
+// @Success 200 {array} Refund
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /campaigns/{campaignId}/donations/{id}/refunds [get]
+func (h *Handler) GetRefundsByDonation(c echo.Context) error {
+	donationID, err := h.campaignDonationID(c)
+	if err != nil {
+		return err
+	}
+
+	refunds, err := h.service.ListRefundsByDonation(c.Request().Context(), donationID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, refunds)
+}
+
+// @Summary List refunds
+// @Description Get all refunds, optionally filtered by status
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Refund status (requested, approved, rejected)"
+// @Success 200 {array} Refund
+// @Failure 400 {object} errors.APIError
+// @Router /refunds [get]
+func (h *Handler) ListRefunds(c echo.Context) error {
+	var status *RefundStatus
+	if value := c.QueryParam("status"); value != "" {
+		s := RefundStatus(value)
+		status = &s
+	}
+
+	refunds, err := h.service.ListRefunds(c.Request().Context(), status)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, refunds)
+}
+
+// @Summary Get refund by ID
+// @Description Get refund details
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID"
+// @Success 200 {object} Refund
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /refunds/{id} [get]
+func (h *Handler) GetRefund(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid refund ID")
+	}
+
+	refund, err := h.service.GetRefund(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, refund)
+}
+
+// @Summary Approve a refund
+// @Description Approve a requested refund. The donation's refunded amount and the campaign totals are updated and its receipt is voided or re-issued. Campaigns with a closure report require override_closure.
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID"
+// @Param review body ReviewRefundRequest false "Review details"
+// @Success 200 {object} Refund
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /refunds/{id}/approve [post]
+func (h *Handler) ApproveRefund(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid refund ID")
+	}
+
+	var req ReviewRefundRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	refund, err := h.service.ApproveRefund(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, refund)
+}
+
+// @Summary Reject a refund
+// @Description Reject a requested refund. Notes are required.
+// @Tags refunds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID"
+// @Param review body ReviewRefundRequest true "Review details"
+// @Success 200 {object} Refund
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /refunds/{id}/reject [post]
+func (h *Handler) RejectRefund(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid refund ID")
+	}
+
+	var req ReviewRefundRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	refund, err := h.service.RejectRefund(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, refund)
+}
+
+// campaignDonationID parses the donation ID and checks that it belongs to the campaign in the path
+func (h *Handler) campaignDonationID(c echo.Context) (uuid.UUID, error) {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return uuid.Nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	donationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid donation ID")
+	}
+
+	d, err := h.donationService.GetDonation(c.Request().Context(), donationID)
+	if err != nil || d.CampaignID != campaignID {
+		return uuid.Nil, echo.NewHTTPError(http.StatusNotFound, "Donation not found")
+	}
+
+	return donationID, nil
+}
+
+// errorStatus maps a refund service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,71 @@
+package refund
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// RefundModel represents the database table structure with GORM tags
+type RefundModel struct {
+	ID               uuid.UUID    `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	DonationID       uuid.UUID    `gorm:"column:donation_id;type:uuid;not null;index"`
+	CampaignID       uuid.UUID    `gorm:"column:campaign_id;type:uuid;not null;index"`
+	Amount           money.Amount `gorm:"column:amount;type:decimal(10,2);not null"`
+	IsFull           bool         `gorm:"column:is_full;not null;default:false"`
+	Reason           string       `gorm:"column:reason;not null"`
+	Status           string       `gorm:"column:status;type:varchar(20);not null;default:requested"`
+	RequestedBy      *uuid.UUID   `gorm:"column:requested_by;type:uuid"`
+	ReviewedBy       *uuid.UUID   `gorm:"column:reviewed_by;type:uuid"`
+	ReviewNotes      *string      `gorm:"column:review_notes"`
+	ClosureOverride  bool         `gorm:"column:closure_override;not null;default:false"`
+	VoidedReceiptURL *string      `gorm:"column:voided_receipt_url;type:varchar(500)"`
+	ReviewedAt       *time.Time   `gorm:"column:reviewed_at"`
+	CreatedAt        time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt        time.Time    `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (RefundModel) TableName() string {
+	return "donation_refunds"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m RefundModel) ToEntity() Refund {
+	return Refund{
+		ID:               m.ID,
+		DonationID:       m.DonationID,
+		CampaignID:       m.CampaignID,
+		Amount:           m.Amount,
+		IsFull:           m.IsFull,
+		Reason:           m.Reason,
+		Status:           RefundStatus(m.Status),
+		RequestedBy:      m.RequestedBy,
+		ReviewedBy:       m.ReviewedBy,
+		ReviewNotes:      m.ReviewNotes,
+		ClosureOverride:  m.ClosureOverride,
+		VoidedReceiptURL: m.VoidedReceiptURL,
+		ReviewedAt:       m.ReviewedAt,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *RefundModel) FromEntity(entity Refund) {
+	m.ID = entity.ID
+	m.DonationID = entity.DonationID
+	m.CampaignID = entity.CampaignID
+	m.Amount = entity.Amount
+	m.IsFull = entity.IsFull
+	m.Reason = entity.Reason
+	m.Status = string(entity.Status)
+	m.RequestedBy = entity.RequestedBy
+	m.ReviewedBy = entity.ReviewedBy
+	m.ReviewNotes = entity.ReviewNotes
+	m.ClosureOverride = entity.ClosureOverride
+	m.VoidedReceiptURL = entity.VoidedReceiptURL
+	m.ReviewedAt = entity.ReviewedAt
+	m.CreatedAt = entity.CreatedAt
+}
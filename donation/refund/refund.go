@@ -0,0 +1,58 @@
+package refund
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// RefundStatus represents the review state of a refund
+type RefundStatus string
+
+const (
+	StatusRequested RefundStatus = "requested"
+	StatusApproved  RefundStatus = "approved"
+	StatusRejected  RefundStatus = "rejected"
+)
+
+// IsValidStatus checks if a refund status is valid
+func IsValidStatus(status RefundStatus) bool {
+	switch status {
+	case StatusRequested, StatusApproved, StatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Refund represents money returned to the donor of a donation
+type Refund struct {
+	ID               uuid.UUID    `json:"id"`
+	DonationID       uuid.UUID    `json:"donation_id"`
+	CampaignID       uuid.UUID    `json:"campaign_id"`
+	Amount           money.Amount `json:"amount"`
+	IsFull           bool         `json:"is_full"` // Refunds the remaining amount of the donation
+	Reason           string       `json:"reason"`
+	Status           RefundStatus `json:"status"`
+	RequestedBy      *uuid.UUID   `json:"requested_by,omitempty"`
+	ReviewedBy       *uuid.UUID   `json:"reviewed_by,omitempty"`
+	ReviewNotes      *string      `json:"review_notes,omitempty"`
+	ClosureOverride  bool         `json:"closure_override"` // Approved after the campaign closure report
+	VoidedReceiptURL *string      `json:"voided_receipt_url,omitempty"`
+	ReviewedAt       *time.Time   `json:"reviewed_at,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+// CreateRefundRequest represents a request to refund a donation
+type CreateRefundRequest struct {
+	Amount *money.Amount `json:"amount,omitempty"` // Defaults to the full refundable amount
+	Reason string        `json:"reason"`
+}
+
+// ReviewRefundRequest represents an admin decision on a refund
+type ReviewRefundRequest struct {
+	Notes           *string `json:"notes,omitempty"`
+	OverrideClosure bool    `json:"override_closure"` // Required to approve refunds of closed campaigns
+}
@@ -0,0 +1,254 @@
+package refund
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"dona_tutti_api/donation"
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRefundExceedsDonation is returned when a refund would return more than the donation's remaining amount
+	ErrRefundExceedsDonation = errors.New("refund exceeds the refundable amount of the donation")
+	// ErrRefundNotRequested is returned when a refund was already reviewed
+	ErrRefundNotRequested = errors.New("refund was already reviewed")
+)
+
+type Repository interface {
+	GetRefund(ctx context.Context, id uuid.UUID) (Refund, error)
+	ListRefunds(ctx context.Context, status *RefundStatus) ([]Refund, error)
+	ListRefundsByDonation(ctx context.Context, donationID uuid.UUID) ([]Refund, error)
+	GetRequestedAmount(ctx context.Context, donationID uuid.UUID) (money.Amount, error)
+	CreateRefund(ctx context.Context, refund Refund) error
+	ApproveRefund(ctx context.Context, refund Refund) error
+	RejectRefund(ctx context.Context, refund Refund) error
+	HasClosureReport(ctx context.Context, campaignID uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetRefund(ctx context.Context, id uuid.UUID) (Refund, error) {
+	var model RefundModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return Refund{}, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *repository) ListRefunds(ctx context.Context, status *RefundStatus) ([]Refund, error) {
+	query := r.db.WithContext(ctx)
+	if status != nil {
+		query = query.Where("status = ?", string(*status))
+	}
+
+	var models []RefundModel
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return toEntities(models), nil
+}
+
+func (r *repository) ListRefundsByDonation(ctx context.Context, donationID uuid.UUID) ([]Refund, error) {
+	var models []RefundModel
+	if err := r.db.WithContext(ctx).Where("donation_id = ?", donationID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return toEntities(models), nil
+}
+
+// GetRequestedAmount returns the amount of the donation's refunds still waiting for review
+func (r *repository) GetRequestedAmount(ctx context.Context, donationID uuid.UUID) (money.Amount, error) {
+	var amount money.Amount
+	err := r.db.WithContext(ctx).
+		Model(&RefundModel{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("donation_id = ? AND status = ?", donationID, string(StatusRequested)).
+		Scan(&amount).Error
+	return amount, err
+}
+
+func (r *repository) CreateRefund(ctx context.Context, refund Refund) error {
+	var model RefundModel
+	model.FromEntity(refund)
+	return r.db.WithContext(ctx).Create(&model).Error
+}
+
+// ApproveRefund applies the refund to the donation and stores the review in one transaction.
+// A donation whose whole amount was returned is marked as refunded, which is recorded in its status history.
+// The sponsor donations matching it are reduced in the same transaction, see refundMatchingDonations.
+func (r *repository) ApproveRefund(ctx context.Context, refund Refund) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var statuses []string
+		err := tx.Raw(`
+			UPDATE donations
+			SET refunded_amount = refunded_amount + ?,
+				status = CASE WHEN refunded_amount + ? >= amount THEN 'refunded' ELSE status END,
+				updated_at = NOW()
+			WHERE id = ? AND status = 'completed' AND refunded_amount + ? <= amount
+			RETURNING status
+		`, refund.Amount, refund.Amount, refund.DonationID, refund.Amount).Scan(&statuses).Error
+		if err != nil {
+			return err
+		}
+		if len(statuses) == 0 {
+			return ErrRefundExceedsDonation
+		}
+
+		if statuses[0] == string(donation.DonationStatusRefunded) {
+			notes := fmt.Sprintf("refund %s", refund.ID)
+			if err := recordRefunded(tx, refund.DonationID, refund.ReviewedBy, notes); err != nil {
+				return err
+			}
+		}
+
+		if err := refundMatchingDonations(tx, refund); err != nil {
+			return err
+		}
+
+		return r.reviewRefund(tx, refund)
+	})
+}
+
+// matchingDonationRow is a sponsor donation matching a refunded donation, with the ratio of its pledge
+type matchingDonationRow struct {
+	ID               uuid.UUID
+	MatchingPledgeID uuid.UUID
+	Amount           money.Amount
+	RefundedAmount   money.Amount
+	Ratio            float64
+}
+
+// refundMatchingDonations reduces the sponsor donations matching a refunded donation to what their
+// pledges give for what the donation still keeps, and releases the difference from the pledge caps.
+// A matching donation is never raised above its original amount, which may have been limited by the cap.
+func refundMatchingDonations(tx *gorm.DB, refund Refund) error {
+	var netAmount money.Amount
+	err := tx.Raw("SELECT amount - refunded_amount FROM donations WHERE id = ?", refund.DonationID).
+		Row().Scan(&netAmount)
+	if err != nil {
+		return err
+	}
+
+	var rows []matchingDonationRow
+	err = tx.Raw(`
+		SELECT d.id, d.matching_pledge_id, d.amount, d.refunded_amount, mp.ratio
+		FROM donations d
+		JOIN matching_pledges mp ON mp.id = d.matching_pledge_id
+		WHERE d.matched_donation_id = ? AND d.status = 'completed'
+		FOR UPDATE OF d, mp
+	`, refund.DonationID).Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		kept := netAmount.MulRate(row.Ratio)
+		if kept > row.Amount {
+			kept = row.Amount
+		}
+		reduction := row.Amount - row.RefundedAmount - kept
+		if !reduction.IsPositive() {
+			continue
+		}
+
+		var statuses []string
+		err := tx.Raw(`
+			UPDATE donations
+			SET refunded_amount = refunded_amount + ?,
+				status = CASE WHEN refunded_amount + ? >= amount THEN 'refunded' ELSE status END,
+				updated_at = NOW()
+			WHERE id = ?
+			RETURNING status
+		`, reduction, reduction, row.ID).Scan(&statuses).Error
+		if err != nil {
+			return err
+		}
+		if len(statuses) > 0 && statuses[0] == string(donation.DonationStatusRefunded) {
+			notes := fmt.Sprintf("refund %s of matched donation %s", refund.ID, refund.DonationID)
+			if err := recordRefunded(tx, row.ID, refund.ReviewedBy, notes); err != nil {
+				return err
+			}
+		}
+
+		err = tx.Table("matching_pledges").
+			Where("id = ?", row.MatchingPledgeID).
+			Updates(map[string]interface{}{
+				"matched_amount": gorm.Expr("GREATEST(matched_amount - ?, 0)", reduction),
+				"updated_at":     gorm.Expr("NOW()"),
+			}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordRefunded stores the status history entry of a donation whose whole amount was returned
+func recordRefunded(tx *gorm.DB, donationID uuid.UUID, changedBy *uuid.UUID, notes string) error {
+	fromStatus := string(donation.DonationStatusCompleted)
+	return tx.Create(&donation.StatusHistoryModel{
+		ID:         uuid.New(),
+		DonationID: donationID,
+		FromStatus: &fromStatus,
+		ToStatus:   string(donation.DonationStatusRefunded),
+		Source:     string(donation.StatusSourceRefund),
+		ChangedBy:  changedBy,
+		Notes:      &notes,
+	}).Error
+}
+
+func (r *repository) RejectRefund(ctx context.Context, refund Refund) error {
+	return r.reviewRefund(r.db.WithContext(ctx), refund)
+}
+
+// reviewRefund stores the review fields of a refund that is still waiting for review
+func (r *repository) reviewRefund(tx *gorm.DB, refund Refund) error {
+	result := tx.Model(&RefundModel{}).
+		Where("id = ? AND status = ?", refund.ID, string(StatusRequested)).
+		Updates(map[string]interface{}{
+			"status":             string(refund.Status),
+			"reviewed_by":        refund.ReviewedBy,
+			"review_notes":       refund.ReviewNotes,
+			"closure_override":   refund.ClosureOverride,
+			"voided_receipt_url": refund.VoidedReceiptURL,
+			"reviewed_at":        refund.ReviewedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRefundNotRequested
+	}
+	return nil
+}
+
+func (r *repository) HasClosureReport(ctx context.Context, campaignID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("campaign_closure_reports").
+		Where("campaign_id = ?", campaignID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func toEntities(models []RefundModel) []Refund {
+	refunds := make([]Refund, len(models))
+	for i, model := range models {
+		refunds[i] = model.ToEntity()
+	}
+	return refunds
+}
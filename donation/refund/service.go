@@ -0,0 +1,221 @@
+package refund
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"dona_tutti_api/donation"
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DonationService defines the donation operations needed by the refund service
+type DonationService interface {
+	GetDonation(ctx context.Context, id uuid.UUID) (donation.Donation, error)
+	ReissueReceipt(ctx context.Context, id uuid.UUID) error
+}
+
+type Service interface {
+	RequestRefund(ctx context.Context, donationID uuid.UUID, req CreateRefundRequest, requestedBy *uuid.UUID) (Refund, error)
+	ApproveRefund(ctx context.Context, id uuid.UUID, req ReviewRefundRequest, approvedBy *uuid.UUID) (Refund, error)
+	RejectRefund(ctx context.Context, id uuid.UUID, req ReviewRefundRequest, rejectedBy *uuid.UUID) (Refund, error)
+	GetRefund(ctx context.Context, id uuid.UUID) (Refund, error)
+	ListRefunds(ctx context.Context, status *RefundStatus) ([]Refund, error)
+	ListRefundsByDonation(ctx context.Context, donationID uuid.UUID) ([]Refund, error)
+}
+
+type service struct {
+	repo            Repository
+	donationService DonationService
+}
+
+func NewService(repo Repository, donationService DonationService) Service {
+	return &service{repo: repo, donationService: donationService}
+}
+
+// RequestRefund registers a full or partial refund of a completed donation, to be reviewed by an admin
+func (s *service) RequestRefund(ctx context.Context, donationID uuid.UUID, req CreateRefundRequest, requestedBy *uuid.UUID) (Refund, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return Refund{}, apierrors.NewFieldValidationError("reason", "refund reason is required")
+	}
+
+	d, err := s.donationService.GetDonation(ctx, donationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Refund{}, apierrors.NewNotFoundError("donation not found")
+		}
+		return Refund{}, fmt.Errorf("failed to get donation: %w", err)
+	}
+	if d.Status != donation.DonationStatusCompleted {
+		return Refund{}, apierrors.NewValidationError(fmt.Sprintf("only completed donations can be refunded, donation status is %s", d.Status))
+	}
+
+	// Amounts already requested are reserved until they are reviewed
+	requested, err := s.repo.GetRequestedAmount(ctx, donationID)
+	if err != nil {
+		return Refund{}, fmt.Errorf("failed to get requested refunds: %w", err)
+	}
+	refundable := d.NetAmount() - requested
+	if !refundable.IsPositive() {
+		return Refund{}, apierrors.NewValidationError("donation has no refundable amount left")
+	}
+
+	amount := refundable
+	if req.Amount != nil {
+		amount = *req.Amount
+		if !amount.IsPositive() {
+			return Refund{}, apierrors.NewFieldValidationError("amount", "refund amount must be greater than 0")
+		}
+		if amount > refundable {
+			return Refund{}, apierrors.NewFieldValidationError("amount",
+				fmt.Sprintf("refund amount cannot exceed the refundable amount of %s", refundable))
+		}
+	}
+
+	refund := Refund{
+		ID:          uuid.New(),
+		DonationID:  d.ID,
+		CampaignID:  d.CampaignID,
+		Amount:      amount,
+		IsFull:      amount == d.NetAmount(),
+		Reason:      reason,
+		Status:      StatusRequested,
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.CreateRefund(ctx, refund); err != nil {
+		return Refund{}, fmt.Errorf("failed to create refund: %w", err)
+	}
+
+	return refund, nil
+}
+
+// ApproveRefund returns the money to the donor: the donation's refunded amount grows, which lowers the
+// campaign totals, the sponsor donations matching it shrink with it, and its receipt is voided or
+// re-issued. Refunds of campaigns with a closure report
+// need an explicit admin override.
+func (s *service) ApproveRefund(ctx context.Context, id uuid.UUID, req ReviewRefundRequest, approvedBy *uuid.UUID) (Refund, error) {
+	refund, err := s.getRequestedRefund(ctx, id)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	closed, err := s.repo.HasClosureReport(ctx, refund.CampaignID)
+	if err != nil {
+		return Refund{}, fmt.Errorf("failed to check campaign closure: %w", err)
+	}
+	if closed && !req.OverrideClosure {
+		return Refund{}, apierrors.NewFieldValidationError("override_closure",
+			"campaign has a closure report, refunds require an admin override")
+	}
+
+	d, err := s.donationService.GetDonation(ctx, refund.DonationID)
+	if err != nil {
+		return Refund{}, fmt.Errorf("failed to get donation: %w", err)
+	}
+
+	now := time.Now()
+	refund.Status = StatusApproved
+	refund.ReviewedBy = approvedBy
+	refund.ReviewNotes = trimNotes(req.Notes)
+	refund.ClosureOverride = closed
+	refund.VoidedReceiptURL = d.ReceiptURL
+	refund.ReviewedAt = &now
+
+	if err := s.repo.ApproveRefund(ctx, refund); err != nil {
+		if errors.Is(err, ErrRefundExceedsDonation) || errors.Is(err, ErrRefundNotRequested) {
+			return Refund{}, apierrors.NewValidationError(err.Error())
+		}
+		return Refund{}, fmt.Errorf("failed to approve refund: %w", err)
+	}
+
+	if closed {
+		log.Printf("⚠️  Refund %s approved after closure of campaign %s", refund.ID.String(), refund.CampaignID.String())
+	}
+
+	// The previous receipt no longer matches the donation
+	if d.ReceiptURL != nil {
+		if err := s.donationService.ReissueReceipt(ctx, refund.DonationID); err != nil {
+			fmt.Printf("Warning: failed to re-issue receipt for donation %s: %v\n", refund.DonationID, err)
+		}
+	}
+
+	return refund, nil
+}
+
+// RejectRefund closes a refund request without returning money
+func (s *service) RejectRefund(ctx context.Context, id uuid.UUID, req ReviewRefundRequest, rejectedBy *uuid.UUID) (Refund, error) {
+	notes := trimNotes(req.Notes)
+	if notes == nil {
+		return Refund{}, apierrors.NewFieldValidationError("notes", "notes are required to reject a refund")
+	}
+
+	refund, err := s.getRequestedRefund(ctx, id)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	now := time.Now()
+	refund.Status = StatusRejected
+	refund.ReviewedBy = rejectedBy
+	refund.ReviewNotes = notes
+	refund.ReviewedAt = &now
+
+	if err := s.repo.RejectRefund(ctx, refund); err != nil {
+		if errors.Is(err, ErrRefundNotRequested) {
+			return Refund{}, apierrors.NewValidationError(err.Error())
+		}
+		return Refund{}, fmt.Errorf("failed to reject refund: %w", err)
+	}
+
+	return refund, nil
+}
+
+func (s *service) GetRefund(ctx context.Context, id uuid.UUID) (Refund, error) {
+	refund, err := s.repo.GetRefund(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Refund{}, apierrors.NewNotFoundError("refund not found")
+		}
+		return Refund{}, fmt.Errorf("failed to get refund: %w", err)
+	}
+	return refund, nil
+}
+
+func (s *service) ListRefunds(ctx context.Context, status *RefundStatus) ([]Refund, error) {
+	if status != nil && !IsValidStatus(*status) {
+		return nil, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid refund status: %s", *status))
+	}
+	return s.repo.ListRefunds(ctx, status)
+}
+
+func (s *service) ListRefundsByDonation(ctx context.Context, donationID uuid.UUID) ([]Refund, error) {
+	return s.repo.ListRefundsByDonation(ctx, donationID)
+}
+
+// getRequestedRefund returns a refund that is still waiting for review
+func (s *service) getRequestedRefund(ctx context.Context, id uuid.UUID) (Refund, error) {
+	refund, err := s.GetRefund(ctx, id)
+	if err != nil {
+		return Refund{}, err
+	}
+	if refund.Status != StatusRequested {
+		return Refund{}, apierrors.NewValidationError(fmt.Sprintf("refund was already %s", refund.Status))
+	}
+	return refund, nil
+}
+
+func trimNotes(notes *string) *string {
+	if notes == nil || strings.TrimSpace(*notes) == "" {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*notes)
+	return &trimmed
+}
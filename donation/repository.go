@@ -2,18 +2,31 @@ package donation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrStatusChanged is returned when a donation status changed since it was read
+var ErrStatusChanged = errors.New("donation status was changed by another request")
+
 type DonationRepository interface {
 	GetDonation(ctx context.Context, id uuid.UUID) (Donation, error)
+	GetDonationByIdempotencyKey(ctx context.Context, key string) (Donation, error)
 	CreateDonation(ctx context.Context, donation Donation) error
 	UpdateDonation(ctx context.Context, donation Donation) error
+	UpdateDonationStatus(ctx context.Context, id uuid.UUID, from DonationStatus, entry StatusHistoryEntry) error
+	ListStatusHistory(ctx context.Context, donationID uuid.UUID) ([]StatusHistoryEntry, error)
 	UpdateReceiptURL(ctx context.Context, id uuid.UUID, receiptURL string) error
 	ListDonationsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Donation, error)
+	CreatePaymentSession(ctx context.Context, session PaymentSession) error
+	GetPaymentSessionByExternalID(ctx context.Context, gatewayCode, externalID string) (PaymentSession, error)
+	UpdatePaymentSessionStatus(ctx context.Context, id uuid.UUID, status PaymentSessionStatus) error
+	RecordWebhookEvent(ctx context.Context, event PaymentWebhookEventModel) (bool, error)
+	DeleteWebhookEvent(ctx context.Context, gatewayCode, eventID string) error
 }
 
 type donationRepository struct {
@@ -46,10 +59,38 @@ func (r *donationRepository) GetDonation(ctx context.Context, id uuid.UUID) (Don
 	return model.ToEntity(), nil
 }
 
+func (r *donationRepository) GetDonationByIdempotencyKey(ctx context.Context, key string) (Donation, error) {
+	var model DonationModel
+	if err := r.db.WithContext(ctx).
+		Where("idempotency_key = ?", key).
+		First(&model).Error; err != nil {
+		return Donation{}, fmt.Errorf("failed to get donation by idempotency key: %w", err)
+	}
+
+	return model.ToEntity(), nil
+}
+
+// CreateDonation stores a donation together with the first entry of its status history
 func (r *donationRepository) CreateDonation(ctx context.Context, donation Donation) error {
 	model := DonationModel{}
 	model.FromEntity(donation)
-	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+
+	historyModel := StatusHistoryModel{}
+	historyModel.FromEntity(StatusHistoryEntry{
+		ID:         uuid.New(),
+		DonationID: donation.ID,
+		ToStatus:   donation.Status,
+		Source:     StatusSourceCreation,
+		CreatedAt:  donation.Date,
+	})
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		return tx.Create(&historyModel).Error
+	})
+	if err != nil {
 		return fmt.Errorf("failed to create donation: %w", err)
 	}
 	return nil
@@ -58,12 +99,54 @@ func (r *donationRepository) CreateDonation(ctx context.Context, donation Donati
 func (r *donationRepository) UpdateDonation(ctx context.Context, donation Donation) error {
 	model := DonationModel{}
 	model.FromEntity(donation)
-	if err := r.db.WithContext(ctx).Save(&model).Error; err != nil {
+	// The idempotency key is set once on creation, refunds are only applied by the refund workflow
+	// and status changes go through UpdateDonationStatus so they are recorded in the history
+	if err := r.db.WithContext(ctx).Omit("idempotency_key", "refunded_amount", "status").Save(&model).Error; err != nil {
 		return fmt.Errorf("failed to update donation: %w", err)
 	}
 	return nil
 }
 
+// UpdateDonationStatus moves a donation from one status to another and records the change.
+// It fails with ErrStatusChanged when the donation is no longer in the expected status.
+func (r *donationRepository) UpdateDonationStatus(ctx context.Context, id uuid.UUID, from DonationStatus, entry StatusHistoryEntry) error {
+	historyModel := StatusHistoryModel{}
+	historyModel.FromEntity(entry)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&DonationModel{}).
+			Where("id = ? AND status = ?", id, from).
+			Update("status", entry.ToStatus)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update donation status: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrStatusChanged
+		}
+
+		if err := tx.Create(&historyModel).Error; err != nil {
+			return fmt.Errorf("failed to record donation status history: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *donationRepository) ListStatusHistory(ctx context.Context, donationID uuid.UUID) ([]StatusHistoryEntry, error) {
+	var models []StatusHistoryModel
+	if err := r.db.WithContext(ctx).
+		Where("donation_id = ?", donationID).
+		Order("created_at").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list donation status history: %w", err)
+	}
+
+	entries := make([]StatusHistoryEntry, len(models))
+	for i, model := range models {
+		entries[i] = model.ToEntity()
+	}
+	return entries, nil
+}
+
 func (r *donationRepository) UpdateReceiptURL(ctx context.Context, id uuid.UUID, receiptURL string) error {
 	if err := r.db.WithContext(ctx).
 		Model(&DonationModel{}).
@@ -129,3 +212,57 @@ func (r *donationRepository) loadPaymentMethods(ctx context.Context, models []Do
 		}
 	}
 }
+
+func (r *donationRepository) CreatePaymentSession(ctx context.Context, session PaymentSession) error {
+	model := PaymentSessionModel{}
+	model.FromEntity(session)
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to create payment session: %w", err)
+	}
+	return nil
+}
+
+func (r *donationRepository) GetPaymentSessionByExternalID(ctx context.Context, gatewayCode, externalID string) (PaymentSession, error) {
+	var model PaymentSessionModel
+	if err := r.db.WithContext(ctx).
+		Where("gateway_code = ? AND external_id = ?", gatewayCode, externalID).
+		First(&model).Error; err != nil {
+		return PaymentSession{}, fmt.Errorf("failed to get payment session: %w", err)
+	}
+	return model.ToEntity(), nil
+}
+
+func (r *donationRepository) UpdatePaymentSessionStatus(ctx context.Context, id uuid.UUID, status PaymentSessionStatus) error {
+	if err := r.db.WithContext(ctx).
+		Model(&PaymentSessionModel{}).
+		Where("id = ?", id).
+		Update("status", string(status)).Error; err != nil {
+		return fmt.Errorf("failed to update payment session status: %w", err)
+	}
+	return nil
+}
+
+// RecordWebhookEvent stores a webhook event and reports whether it was new.
+// An event already recorded for the same gateway is a replay and is not stored again.
+func (r *donationRepository) RecordWebhookEvent(ctx context.Context, event PaymentWebhookEventModel) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "gateway_code"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(&event)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record webhook event: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteWebhookEvent removes a recorded event so the gateway can deliver it again
+func (r *donationRepository) DeleteWebhookEvent(ctx context.Context, gatewayCode, eventID string) error {
+	if err := r.db.WithContext(ctx).
+		Where("gateway_code = ? AND event_id = ?", gatewayCode, eventID).
+		Delete(&PaymentWebhookEventModel{}).Error; err != nil {
+		return fmt.Errorf("failed to delete webhook event: %w", err)
+	}
+	return nil
+}
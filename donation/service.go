@@ -2,31 +2,67 @@ package donation
 
 import (
 	"context"
+	"dona_tutti_api/currency"
+	"dona_tutti_api/donation/payment"
 	"dona_tutti_api/donation/receipt"
 	"dona_tutti_api/donor"
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/money"
 	"dona_tutti_api/s3client"
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	// MaxDonationAmount is 99999999.99, the largest amount that fits in donations.amount (DECIMAL(10,2))
+	MaxDonationAmount = money.Amount(9999999999)
+	// MaxIdempotencyKeyLength is the maximum length of an Idempotency-Key header
+	MaxIdempotencyKeyLength = 255
+)
+
 type Service interface {
 	GetDonation(ctx context.Context, id uuid.UUID) (Donation, error)
+	GetOrCreateDonor(ctx context.Context, donorInfo DonorInfo) (uuid.UUID, error)
+	GetOrCreatePublicDonor(ctx context.Context, donorInfo DonorInfo) (uuid.UUID, error)
 	CreateDonation(ctx context.Context, donation Donation) (uuid.UUID, error)
 	CreateDonationWithRequest(ctx context.Context, campaignID uuid.UUID, req CreateDonationRequest) (uuid.UUID, error)
-	UpdateDonation(ctx context.Context, donation Donation) error
-	UpdateDonationStatus(ctx context.Context, id uuid.UUID, status DonationStatus) error
+	CreatePublicDonation(ctx context.Context, campaignID uuid.UUID, req CreateDonationRequest) (uuid.UUID, error)
+	UpdateDonation(ctx context.Context, donation Donation, change StatusChange) error
+	UpdateDonationStatus(ctx context.Context, id uuid.UUID, status DonationStatus, change StatusChange) error
+	GetStatusHistory(ctx context.Context, id uuid.UUID) ([]StatusHistoryEntry, error)
 	ListDonationsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Donation, error)
+	CreateCheckoutSession(ctx context.Context, donationID uuid.UUID) (PaymentSession, error)
+	HandlePaymentWebhook(ctx context.Context, gatewayCode string, payload []byte, headers http.Header) error
+	ReissueReceipt(ctx context.Context, id uuid.UUID) error
 }
 
 // CampaignService defines minimal campaign operations needed by donation service
 type CampaignService interface {
 	GetCampaignTitle(ctx context.Context, campaignID uuid.UUID) (string, error)
 	GetCampaignStatus(ctx context.Context, campaignID uuid.UUID) (string, error)
+	GetCampaignCurrency(ctx context.Context, campaignID uuid.UUID) (string, error)
+}
+
+// DonationMatcher creates the donations sponsors pledged to match a completed donation with
+type DonationMatcher interface {
+	MatchDonation(ctx context.Context, donation Donation) error
+}
+
+// DonorAccountService grants the donor role to the user account linked to a donor
+type DonorAccountService interface {
+	AssignDonorRole(ctx context.Context, donorID uuid.UUID) error
+}
+
+// CurrencyConverter converts donation amounts to the campaign currency
+type CurrencyConverter interface {
+	Convert(ctx context.Context, amount money.Amount, from, to string, at time.Time) (currency.Conversion, error)
 }
 
 type service struct {
@@ -35,15 +71,23 @@ type service struct {
 	s3Client        *s3client.Client
 	campaignService CampaignService
 	pdfGenerator    receipt.PDFGenerator
+	gateways        *payment.Registry
+	converter       CurrencyConverter
+	matcher         DonationMatcher
+	accounts        DonorAccountService
 }
 
-func NewService(repo DonationRepository, donorService donor.Service, s3Client *s3client.Client, campaignService CampaignService) Service {
+func NewService(repo DonationRepository, donorService donor.Service, s3Client *s3client.Client, campaignService CampaignService, gateways *payment.Registry, converter CurrencyConverter, matcher DonationMatcher, accounts DonorAccountService) Service {
 	return &service{
 		repo:            repo,
 		donorService:    donorService,
 		s3Client:        s3Client,
 		campaignService: campaignService,
 		pdfGenerator:    receipt.NewPDFGenerator(),
+		gateways:        gateways,
+		converter:       converter,
+		matcher:         matcher,
+		accounts:        accounts,
 	}
 }
 
@@ -59,6 +103,20 @@ func (s *service) CreateDonation(ctx context.Context, donation Donation) (uuid.U
 		donation.Status = DonationStatusPending
 	}
 
+	// Donations created without a conversion are in the campaign currency
+	if donation.Currency == "" {
+		campaignCurrency, err := s.campaignService.GetCampaignCurrency(ctx, donation.CampaignID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to get campaign currency: %w", err)
+		}
+		donation.Currency = campaignCurrency
+	}
+	if donation.OriginalCurrency == "" {
+		donation.OriginalAmount = donation.Amount
+		donation.OriginalCurrency = donation.Currency
+		donation.ExchangeRate = 1
+	}
+
 	if err := s.repo.CreateDonation(ctx, donation); err != nil {
 		return uuid.Nil, fmt.Errorf("failed to create donation: %w", err)
 	}
@@ -69,14 +127,82 @@ func (s *service) CreateDonation(ctx context.Context, donation Donation) (uuid.U
 	return donation.ID, nil
 }
 
-func (s *service) UpdateDonation(ctx context.Context, donation Donation) error {
-	return s.repo.UpdateDonation(ctx, donation)
+// UpdateDonation edits a donation. Donations whose money was received keep their amount, donor,
+// campaign and payment method; a status change follows the same rules as UpdateDonationStatus.
+func (s *service) UpdateDonation(ctx context.Context, donation Donation, change StatusChange) error {
+	current, err := s.repo.GetDonation(ctx, donation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get donation: %w", err)
+	}
+	if current.CampaignID != donation.CampaignID {
+		return apierrors.NewValidationError("donation does not belong to the specified campaign")
+	}
+
+	if current.Status.IsLocked() {
+		if donation.Amount != current.Amount {
+			return apierrors.NewFieldValidationError("amount", fmt.Sprintf("cannot change the amount of a %s donation", current.Status))
+		}
+		if !sameDonor(donation.DonorID, current.DonorID) {
+			return apierrors.NewFieldValidationError("donor_id", fmt.Sprintf("cannot change the donor of a %s donation", current.Status))
+		}
+		if donation.PaymentMethodID != current.PaymentMethodID {
+			return apierrors.NewFieldValidationError("payment_method_id", fmt.Sprintf("cannot change the payment method of a %s donation", current.Status))
+		}
+	} else {
+		if err := validateAmount(donation.Amount); err != nil {
+			return err
+		}
+		if donation.DonorID == nil && !donation.IsAnonymous {
+			return apierrors.NewFieldValidationError("donor_id", "donation without donor must be anonymous")
+		}
+		if donation.PaymentMethodID <= 0 {
+			return apierrors.NewFieldValidationError("payment_method_id", "payment method is required")
+		}
+	}
+
+	status := donation.Status
+	if status != "" && status != current.Status && !CanTransitionTo(current.Status, status) {
+		return apierrors.NewFieldValidationError("status",
+			fmt.Sprintf("cannot change donation status from %s to %s", current.Status, status))
+	}
+
+	// Amounts edited by an admin are in the campaign currency, replacing the original conversion
+	donation.Currency = current.Currency
+	if donation.Amount != current.Amount {
+		donation.OriginalAmount = donation.Amount
+		donation.OriginalCurrency = current.Currency
+		donation.ExchangeRate = 1
+		donation.ExchangeRateID = nil
+	} else {
+		donation.OriginalAmount = current.OriginalAmount
+		donation.OriginalCurrency = current.OriginalCurrency
+		donation.ExchangeRate = current.ExchangeRate
+		donation.ExchangeRateID = current.ExchangeRateID
+	}
+
+	// Server managed fields are kept from the stored donation
+	donation.Date = current.Date
+	donation.ReceiptURL = current.ReceiptURL
+	donation.Status = current.Status
+	if err := s.repo.UpdateDonation(ctx, donation); err != nil {
+		return err
+	}
+
+	if status != "" && status != current.Status {
+		return s.UpdateDonationStatus(ctx, donation.ID, status, change)
+	}
+	return nil
 }
 
-func (s *service) UpdateDonationStatus(ctx context.Context, id uuid.UUID, status DonationStatus) error {
+// UpdateDonationStatus moves a donation through its status workflow and records the change in its history
+func (s *service) UpdateDonationStatus(ctx context.Context, id uuid.UUID, status DonationStatus, change StatusChange) error {
 	// Validate status
 	if !IsValidStatus(status) {
-		return fmt.Errorf("invalid donation status: %s", status)
+		return apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid donation status: %s", status))
+	}
+	// Refunds carry an amount, reason and approver, so they are only applied by the refund workflow
+	if status == DonationStatusRefunded {
+		return apierrors.NewFieldValidationError("status", "donations are refunded through the refunds endpoint")
 	}
 
 	// Get current donation
@@ -85,16 +211,33 @@ func (s *service) UpdateDonationStatus(ctx context.Context, id uuid.UUID, status
 		return fmt.Errorf("failed to get donation: %w", err)
 	}
 
-	// Business rule: Don't allow changing from completed to pending
-	if currentDonation.Status == DonationStatusCompleted && status == DonationStatusPending {
-		return fmt.Errorf("cannot change donation status from completed to pending")
+	if currentDonation.Status == status {
+		return nil
+	}
+	if !CanTransitionTo(currentDonation.Status, status) {
+		return apierrors.NewFieldValidationError("status",
+			fmt.Sprintf("cannot change donation status from %s to %s", currentDonation.Status, status))
 	}
 
 	// Update status in database
-	currentDonation.Status = status
-	if err := s.repo.UpdateDonation(ctx, currentDonation); err != nil {
-		return fmt.Errorf("failed to update donation status: %w", err)
+	previousStatus := currentDonation.Status
+	entry := StatusHistoryEntry{
+		ID:         uuid.New(),
+		DonationID: id,
+		FromStatus: &previousStatus,
+		ToStatus:   status,
+		Source:     change.Source,
+		ChangedBy:  change.ChangedBy,
+		Notes:      change.Notes,
+		CreatedAt:  time.Now(),
 	}
+	if err := s.repo.UpdateDonationStatus(ctx, id, previousStatus, entry); err != nil {
+		if errors.Is(err, ErrStatusChanged) {
+			return apierrors.NewValidationError(err.Error())
+		}
+		return err
+	}
+	currentDonation.Status = status
 
 	// If status changed to completed and no receipt exists, generate receipt
 	if status == DonationStatusCompleted && currentDonation.ReceiptURL == nil {
@@ -102,9 +245,27 @@ func (s *service) UpdateDonationStatus(ctx context.Context, id uuid.UUID, status
 		go s.generateAndUploadReceipt(context.Background(), currentDonation)
 	}
 
+	// Matching failures never undo the completion; the donation is simply not matched
+	if status == DonationStatusCompleted && s.matcher != nil && !currentDonation.IsMatched() {
+		if err := s.matcher.MatchDonation(ctx, currentDonation); err != nil {
+			log.Printf("Error matching donation %s: %v", id.String(), err)
+		}
+	}
+
+	// The first completed donation of a donor linked to a user account makes the user a donor
+	if status == DonationStatusCompleted && s.accounts != nil && currentDonation.DonorID != nil {
+		if err := s.accounts.AssignDonorRole(ctx, *currentDonation.DonorID); err != nil {
+			log.Printf("Error assigning donor role for donation %s: %v", id.String(), err)
+		}
+	}
+
 	return nil
 }
 
+func (s *service) GetStatusHistory(ctx context.Context, id uuid.UUID) ([]StatusHistoryEntry, error) {
+	return s.repo.ListStatusHistory(ctx, id)
+}
+
 func (s *service) ListDonationsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Donation, error) {
 	return s.repo.ListDonationsByCampaign(ctx, campaignID)
 }
@@ -130,6 +291,40 @@ func (s *service) GetOrCreateDonor(ctx context.Context, donorInfo DonorInfo) (uu
 		}
 	}
 
+	return s.createDonor(ctx, donorInfo)
+}
+
+// GetOrCreatePublicDonor finds or creates the donor of donor info sent by an unauthenticated caller.
+// Anyone can send the email or phone of a stranger, so only verified donors are reused. Otherwise a
+// new donor is created, without the email when it already belongs to an unverified donor.
+func (s *service) GetOrCreatePublicDonor(ctx context.Context, donorInfo DonorInfo) (uuid.UUID, error) {
+	if donorInfo.Email != nil && *donorInfo.Email != "" {
+		existingDonor, err := s.donorService.FindDonorByEmail(ctx, *donorInfo.Email)
+		if err == nil {
+			if existingDonor.IsVerified {
+				return existingDonor.ID, nil
+			}
+			// Emails are unique, so the new donor cannot keep it
+			donorInfo.Email = nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, fmt.Errorf("error searching donor by email: %w", err)
+		}
+	}
+
+	if donorInfo.Phone != nil && *donorInfo.Phone != "" {
+		existingDonor, err := s.donorService.FindDonorByPhone(ctx, *donorInfo.Phone)
+		if err == nil && existingDonor.IsVerified {
+			return existingDonor.ID, nil
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, fmt.Errorf("error searching donor by phone: %w", err)
+		}
+	}
+
+	return s.createDonor(ctx, donorInfo)
+}
+
+func (s *service) createDonor(ctx context.Context, donorInfo DonorInfo) (uuid.UUID, error) {
 	newDonor := donor.Donor{
 		FirstName: donorInfo.Name,
 		LastName:  donorInfo.LastName,
@@ -151,62 +346,324 @@ func (s *service) GetOrCreateDonor(ctx context.Context, donorInfo DonorInfo) (uu
 	return donorID, nil
 }
 
+// validateAmount checks that a donation amount is positive and fits in the amount column.
+// Amounts with more than two decimals are already rejected when the request is decoded.
+func validateAmount(amount money.Amount) error {
+	if !amount.IsPositive() {
+		return apierrors.NewFieldValidationError("amount", "amount must be greater than 0")
+	}
+	if amount > MaxDonationAmount {
+		return apierrors.NewFieldValidationError("amount", fmt.Sprintf("amount cannot exceed %s", MaxDonationAmount))
+	}
+	return nil
+}
+
+// sameDonor reports whether two donations have the same donor, or both have none
+func sameDonor(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// validateDonationRequest checks the amount and idempotency key of a donation request
+func validateDonationRequest(req CreateDonationRequest) error {
+	if err := validateAmount(req.Amount); err != nil {
+		return err
+	}
+	if req.PaymentMethodID <= 0 {
+		return apierrors.NewFieldValidationError("payment_method_id", "payment method is required")
+	}
+	if req.IdempotencyKey != nil && len(*req.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return apierrors.NewFieldValidationError("Idempotency-Key",
+			fmt.Sprintf("idempotency key cannot exceed %d characters", MaxIdempotencyKeyLength))
+	}
+	return nil
+}
+
+// findIdempotentDonation returns the donation previously created with the request's idempotency key, if any
+func (s *service) findIdempotentDonation(ctx context.Context, campaignID uuid.UUID, req CreateDonationRequest) (*Donation, error) {
+	if req.IdempotencyKey == nil {
+		return nil, nil
+	}
+
+	existing, err := s.repo.GetDonationByIdempotencyKey(ctx, *req.IdempotencyKey)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+
+	// A key can only be replayed with the same donation
+	if existing.CampaignID != campaignID || existing.OriginalAmount != req.Amount ||
+		(req.Currency != "" && currency.Normalize(req.Currency) != existing.OriginalCurrency) {
+		return nil, apierrors.NewFieldValidationError("Idempotency-Key", "idempotency key was already used for a different donation")
+	}
+
+	return &existing, nil
+}
+
+// CreateDonationWithRequest creates a pending donation. When the request carries an idempotency key
+// that was already used, the original donation is returned instead of creating a duplicate.
 func (s *service) CreateDonationWithRequest(ctx context.Context, campaignID uuid.UUID, req CreateDonationRequest) (uuid.UUID, error) {
+	return s.createDonation(ctx, campaignID, req, s.GetOrCreateDonor)
+}
+
+// CreatePublicDonation creates a pending donation sent by an unauthenticated caller, which is only
+// credited to an existing donor when that donor is verified
+func (s *service) CreatePublicDonation(ctx context.Context, campaignID uuid.UUID, req CreateDonationRequest) (uuid.UUID, error) {
+	return s.createDonation(ctx, campaignID, req, s.GetOrCreatePublicDonor)
+}
+
+func (s *service) createDonation(ctx context.Context, campaignID uuid.UUID, req CreateDonationRequest, getOrCreateDonor func(ctx context.Context, donorInfo DonorInfo) (uuid.UUID, error)) (uuid.UUID, error) {
+	if req.IdempotencyKey != nil {
+		key := strings.TrimSpace(*req.IdempotencyKey)
+		if key == "" {
+			req.IdempotencyKey = nil
+		} else {
+			req.IdempotencyKey = &key
+		}
+	}
+
+	if err := validateDonationRequest(req); err != nil {
+		return uuid.Nil, err
+	}
+
+	// Replayed request: return the original donation
+	existing, err := s.findIdempotentDonation(ctx, campaignID, req)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if existing != nil {
+		log.Printf("🔁 Idempotent replay for donation %s", existing.ID.String())
+		return existing.ID, nil
+	}
+
 	// Check if campaign accepts donations (not completed)
 	campaignStatus, err := s.campaignService.GetCampaignStatus(ctx, campaignID)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("failed to verify campaign status: %w", err)
 	}
 	if campaignStatus == "completed" {
-		return uuid.Nil, fmt.Errorf("campaign is not accepting donations: campaign has been closed")
+		return uuid.Nil, apierrors.NewValidationError("campaign is not accepting donations: campaign has been closed")
 	}
 	if campaignStatus != "active" {
-		return uuid.Nil, fmt.Errorf("campaign is not accepting donations: campaign status is %s", campaignStatus)
+		return uuid.Nil, apierrors.NewValidationError(fmt.Sprintf("campaign is not accepting donations: campaign status is %s", campaignStatus))
 	}
 
-	var donorID uuid.UUID
+	// Convert the amount to the campaign currency with the rate in effect now
+	campaignCurrency, err := s.campaignService.GetCampaignCurrency(ctx, campaignID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get campaign currency: %w", err)
+	}
+	donationCurrency := campaignCurrency
+	if req.Currency != "" {
+		donationCurrency = currency.Normalize(req.Currency)
+	}
+	conversion, err := s.converter.Convert(ctx, req.Amount, donationCurrency, campaignCurrency, time.Now())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !conversion.Amount.IsPositive() || conversion.Amount > MaxDonationAmount {
+		return uuid.Nil, apierrors.NewFieldValidationError("amount",
+			fmt.Sprintf("amount converted to %s must be between 0.01 and %s", campaignCurrency, MaxDonationAmount))
+	}
+
+	// Known donors may still give anonymously: they stay linked to the donation but are not shown
+	// publicly. Donations without donor information have no donor at all.
+	var donorID *uuid.UUID
 
 	if req.DonorID != nil {
-		if req.IsAnonymous {
-			return uuid.Nil, fmt.Errorf("donation with donor_id cannot be anonymous")
-		}
-		donorID = *req.DonorID
+		donorID = req.DonorID
 	} else if req.Donor != nil {
-		if req.IsAnonymous {
-			return uuid.Nil, fmt.Errorf("donation with donor information cannot be anonymous")
-		}
-		donorID, err = s.GetOrCreateDonor(ctx, *req.Donor)
+		id, err := getOrCreateDonor(ctx, *req.Donor)
 		if err != nil {
 			return uuid.Nil, fmt.Errorf("failed to get or create donor: %w", err)
 		}
-	} else {
-		if !req.IsAnonymous {
-			return uuid.Nil, fmt.Errorf("anonymous donation must have is_anonymous set to true")
+		donorID = &id
+	} else if !req.IsAnonymous {
+		return uuid.Nil, apierrors.NewFieldValidationError("is_anonymous", "anonymous donation must have is_anonymous set to true")
+	}
+
+	donation := Donation{
+		CampaignID:       campaignID,
+		Amount:           conversion.Amount,
+		Currency:         conversion.Currency,
+		OriginalAmount:   conversion.OriginalAmount,
+		OriginalCurrency: conversion.OriginalCurrency,
+		ExchangeRate:     conversion.Rate,
+		ExchangeRateID:   conversion.RateID,
+		DonorID:          donorID,
+		Message:          req.Message,
+		IsAnonymous:      req.IsAnonymous,
+		PaymentMethodID:  req.PaymentMethodID,
+		Status:           DonationStatusPending,
+		IdempotencyKey:   req.IdempotencyKey,
+	}
+
+	id, err := s.CreateDonation(ctx, donation)
+	if err != nil {
+		// A concurrent request with the same key may have won the race
+		if existing, findErr := s.findIdempotentDonation(ctx, campaignID, req); findErr == nil && existing != nil {
+			return existing.ID, nil
 		}
-		// Para donaciones anónimas, crear un donor temporal con información mínima
-		anonymousDonor := donor.Donor{
-			FirstName: "Anonymous",
-			LastName:  "Donor",
-			Email:     "",
-			Phone:     "",
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+// CreateCheckoutSession starts a payment for a pending donation at the gateway of its payment method
+func (s *service) CreateCheckoutSession(ctx context.Context, donationID uuid.UUID) (PaymentSession, error) {
+	donation, err := s.repo.GetDonation(ctx, donationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PaymentSession{}, apierrors.NewNotFoundError("donation not found")
 		}
-		donorID, err = s.donorService.CreateDonor(ctx, anonymousDonor)
-		if err != nil {
-			return uuid.Nil, fmt.Errorf("failed to create anonymous donor: %w", err)
+		return PaymentSession{}, err
+	}
+
+	if donation.Status != DonationStatusPending {
+		return PaymentSession{}, apierrors.NewValidationError(fmt.Sprintf("cannot pay a donation with status %s", donation.Status))
+	}
+	if donation.PaymentMethod == nil {
+		return PaymentSession{}, apierrors.NewValidationError("donation has no payment method")
+	}
+
+	gateway, ok := s.gateways.Get(donation.PaymentMethod.Code)
+	if !ok {
+		return PaymentSession{}, apierrors.NewValidationError(
+			fmt.Sprintf("payment method %s does not support online payments", donation.PaymentMethod.Code))
+	}
+
+	campaignTitle, err := s.campaignService.GetCampaignTitle(ctx, donation.CampaignID)
+	if err != nil {
+		return PaymentSession{}, fmt.Errorf("failed to get campaign title: %w", err)
+	}
+
+	checkout, err := gateway.CreateCheckoutSession(ctx, payment.CheckoutRequest{
+		DonationID:  donation.ID,
+		CampaignID:  donation.CampaignID,
+		Amount:      donation.OriginalAmount,
+		Currency:    donation.OriginalCurrency,
+		Description: fmt.Sprintf("Donación a %s", campaignTitle),
+	})
+	if err != nil {
+		return PaymentSession{}, fmt.Errorf("failed to create checkout session: %w", err)
+	}
+
+	session := PaymentSession{
+		ID:          uuid.New(),
+		DonationID:  donation.ID,
+		GatewayCode: gateway.Code(),
+		ExternalID:  checkout.ExternalID,
+		CheckoutURL: checkout.CheckoutURL,
+		Status:      PaymentSessionStatusOpen,
+		ExpiresAt:   checkout.ExpiresAt,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.CreatePaymentSession(ctx, session); err != nil {
+		return PaymentSession{}, err
+	}
+
+	return session, nil
+}
+
+// HandlePaymentWebhook verifies a gateway notification and moves the donation to completed or failed.
+// Each event is applied once: replayed webhooks are acknowledged without changing anything.
+func (s *service) HandlePaymentWebhook(ctx context.Context, gatewayCode string, payload []byte, headers http.Header) error {
+	gateway, ok := s.gateways.Get(gatewayCode)
+	if !ok {
+		return apierrors.NewNotFoundError(fmt.Sprintf("unknown payment gateway: %s", gatewayCode))
+	}
+
+	event, err := gateway.ParseWebhook(payload, headers)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.repo.GetPaymentSessionByExternalID(ctx, gatewayCode, event.ExternalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierrors.NewNotFoundError("payment session not found")
 		}
+		return err
 	}
 
-	donation := Donation{
-		CampaignID:      campaignID,
-		Amount:          req.Amount,
-		DonorID:         donorID,
-		Message:         req.Message,
-		IsAnonymous:     req.IsAnonymous,
-		PaymentMethodID: req.PaymentMethodID,
-		Status:          DonationStatusPending,
+	recorded, err := s.repo.RecordWebhookEvent(ctx, PaymentWebhookEventModel{
+		GatewayCode: gatewayCode,
+		EventID:     event.EventID,
+		DonationID:  &session.DonationID,
+		Status:      string(event.Status),
+		Payload:     string(payload),
+	})
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		log.Printf("🔁 Webhook event %s from %s already processed", event.EventID, gatewayCode)
+		return nil
+	}
+
+	if err := s.applyPaymentEvent(ctx, session, event); err != nil {
+		// Forget the event so the gateway retry can apply it
+		if deleteErr := s.repo.DeleteWebhookEvent(ctx, gatewayCode, event.EventID); deleteErr != nil {
+			log.Printf("Error deleting webhook event %s after failure: %v", event.EventID, deleteErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// applyPaymentEvent updates the session and donation status from a payment outcome
+func (s *service) applyPaymentEvent(ctx context.Context, session PaymentSession, event payment.WebhookEvent) error {
+	sessionStatus := PaymentSessionStatusRejected
+	donationStatus := DonationStatusFailed
+	if event.Status == payment.EventStatusApproved {
+		sessionStatus = PaymentSessionStatusApproved
+		donationStatus = DonationStatusCompleted
+	}
+
+	if err := s.repo.UpdatePaymentSessionStatus(ctx, session.ID, sessionStatus); err != nil {
+		return err
+	}
+
+	donation, err := s.repo.GetDonation(ctx, session.DonationID)
+	if err != nil {
+		return err
+	}
+
+	// A late rejection never undoes a completed or refunded donation
+	if donation.Status == donationStatus {
+		return nil
+	}
+	if !CanTransitionTo(donation.Status, donationStatus) {
+		log.Printf("⚠️  Ignoring %s payment event for donation %s with status %s", event.Status, donation.ID.String(), donation.Status)
+		return nil
 	}
 
-	return s.CreateDonation(ctx, donation)
+	log.Printf("💳 Payment %s for donation %s: %s", event.ExternalID, donation.ID.String(), event.Status)
+	notes := fmt.Sprintf("%s payment %s %s", session.GatewayCode, event.ExternalID, event.Status)
+	return s.UpdateDonationStatus(ctx, donation.ID, donationStatus, StatusChange{
+		Source: StatusSourcePaymentGateway,
+		Notes:  &notes,
+	})
+}
+
+// ReissueReceipt replaces the receipt of a donation after a refund. Fully refunded donations
+// get a voided receipt, partially refunded ones a receipt showing the net amount.
+func (s *service) ReissueReceipt(ctx context.Context, id uuid.UUID) error {
+	donation, err := s.repo.GetDonation(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get donation: %w", err)
+	}
+
+	log.Printf("🎫 Re-issuing receipt for donation %s", id.String())
+	go s.generateAndUploadReceipt(context.Background(), donation)
+
+	return nil
 }
 
 // generateAndUploadReceipt generates a PDF receipt and uploads it to S3
@@ -224,14 +681,15 @@ func (s *service) generateAndUploadReceipt(ctx context.Context, donation Donatio
 		campaignTitle = "Campaña sin título"
 	}
 
-	// Get donor information
-	donorInfo, err := s.donorService.GetDonor(ctx, donation.DonorID)
-	var donorName string
-	if err != nil {
-		log.Printf("Error getting donor info for receipt generation: %v", err)
-		donorName = "Donante"
-	} else {
-		donorName = fmt.Sprintf("%s %s", donorInfo.FirstName, donorInfo.LastName)
+	// Get donor information; anonymous donations may have no donor
+	donorName := "Donante"
+	if donation.DonorID != nil {
+		donorInfo, err := s.donorService.GetDonor(ctx, *donation.DonorID)
+		if err != nil {
+			log.Printf("Error getting donor info for receipt generation: %v", err)
+		} else {
+			donorName = fmt.Sprintf("%s %s", donorInfo.FirstName, donorInfo.LastName)
+		}
 	}
 
 	// Get payment method name
@@ -242,13 +700,19 @@ func (s *service) generateAndUploadReceipt(ctx context.Context, donation Donatio
 
 	// Prepare receipt data
 	receiptData := receipt.ReceiptData{
-		DonationID:    donation.ID,
-		CampaignTitle: campaignTitle,
-		DonorName:     donorName,
-		Amount:        donation.Amount,
-		Date:          donation.Date,
-		PaymentMethod: paymentMethodName,
-		IsAnonymous:   donation.IsAnonymous,
+		DonationID:       donation.ID,
+		CampaignTitle:    campaignTitle,
+		DonorName:        donorName,
+		Amount:           donation.Amount,
+		Currency:         donation.Currency,
+		OriginalAmount:   donation.OriginalAmount,
+		OriginalCurrency: donation.OriginalCurrency,
+		ExchangeRate:     donation.ExchangeRate,
+		RefundedAmount:   donation.RefundedAmount,
+		Voided:           donation.Status == DonationStatusRefunded,
+		Date:             donation.Date,
+		PaymentMethod:    paymentMethodName,
+		IsAnonymous:      donation.IsAnonymous,
 	}
 
 	// Generate PDF
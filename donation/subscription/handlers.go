@@ -0,0 +1,287 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/middleware"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Rate limit of the public subscription endpoint, per client
+const (
+	subscribeRateLimit = 5 // requests per minute
+	subscribeBurst     = 3
+)
+
+// Handler handles HTTP requests for recurring donations
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new subscriptions handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the subscription routes
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	// Public route: donors sign up without an account
+	g.POST("/campaigns/:campaignId/subscriptions", h.CreateSubscription,
+		middleware.OptionalAuth(), middleware.RateLimit(subscribeRateLimit, subscribeBurst))
+
+	// Donor self-service with the management token, or admin access
+	manageGroup := g.Group("/subscriptions/:id", h.requireTokenOrAdmin(authMiddleware, adminMiddleware))
+	manageGroup.GET("", h.GetSubscription)
+	manageGroup.GET("/charges", h.GetCharges)
+	manageGroup.POST("/pause", h.PauseSubscription)
+	manageGroup.POST("/resume", h.ResumeSubscription)
+	manageGroup.POST("/cancel", h.CancelSubscription)
+
+	// Admin routes
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.GET("/subscriptions", h.ListSubscriptions)
+	adminGroup.GET("/campaigns/:campaignId/subscriptions", h.GetSubscriptionsByCampaign)
+}
+
+// requireTokenOrAdmin lets the request through with a valid management token for the subscription,
+// otherwise it falls back to admin authentication
+func (h *Handler) requireTokenOrAdmin(authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		adminNext := authMiddleware(adminMiddleware(next))
+
+		return func(c echo.Context) error {
+			token := c.Request().Header.Get(TokenHeader)
+			if token == "" {
+				return adminNext(c)
+			}
+
+			id, err := uuid.Parse(c.Param("id"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid subscription ID")
+			}
+
+			valid, err := h.service.VerifyToken(c.Request().Context(), id, token)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			if !valid {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid subscription token")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// @Summary Subscribe to a campaign
+// @Description Sign up for recurring donations to an active campaign. The first donation is created right away and the next ones on the same day of each period. The response includes a management token, shown only once, that the donor sends in the X-Subscription-Token header to pause, resume or cancel the subscription.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "Campaign ID"
+// @Param subscription body CreateSubscriptionRequest true "Subscription details"
+// @Success 201 {object} CreateSubscriptionResponse
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Failure 429 {object} errors.APIError
+// @Router /campaigns/{campaignId}/subscriptions [post]
+func (h *Handler) CreateSubscription(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	var req CreateSubscriptionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	response, err := h.service.CreateSubscription(c.Request().Context(), campaignID, req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// @Summary Get a subscription
+// @Description Get a subscription with the management token or as an admin
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param X-Subscription-Token header string false "Management token"
+// @Success 200 {object} Subscription
+// @Failure 401 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /subscriptions/{id} [get]
+func (h *Handler) GetSubscription(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid subscription ID")
+	}
+
+	subscription, err := h.service.GetSubscription(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, subscription)
+}
+
+// @Summary Get subscription charges
+// @Description Get the donations generated by a subscription, newest first
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param X-Subscription-Token header string false "Management token"
+// @Success 200 {array} Charge
+// @Failure 401 {object} errors.APIError
+// @Router /subscriptions/{id}/charges [get]
+func (h *Handler) GetCharges(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid subscription ID")
+	}
+
+	charges, err := h.service.ListCharges(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, charges)
+}
+
+// @Summary Pause a subscription
+// @Description Stop charging an active subscription until it is resumed
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param X-Subscription-Token header string false "Management token"
+// @Success 200 {object} Subscription
+// @Failure 400 {object} errors.APIError
+// @Failure 401 {object} errors.APIError
+// @Router /subscriptions/{id}/pause [post]
+func (h *Handler) PauseSubscription(c echo.Context) error {
+	return h.changeStatus(c, h.service.PauseSubscription)
+}
+
+// @Summary Resume a subscription
+// @Description Resume a paused subscription. Periods missed while paused are not charged; the next donation is due on the next billing day.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param X-Subscription-Token header string false "Management token"
+// @Success 200 {object} Subscription
+// @Failure 400 {object} errors.APIError
+// @Failure 401 {object} errors.APIError
+// @Router /subscriptions/{id}/resume [post]
+func (h *Handler) ResumeSubscription(c echo.Context) error {
+	return h.changeStatus(c, h.service.ResumeSubscription)
+}
+
+// @Summary Cancel a subscription
+// @Description Cancel a subscription for good. Donations already created are not affected.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param X-Subscription-Token header string false "Management token"
+// @Success 200 {object} Subscription
+// @Failure 400 {object} errors.APIError
+// @Failure 401 {object} errors.APIError
+// @Router /subscriptions/{id}/cancel [post]
+func (h *Handler) CancelSubscription(c echo.Context) error {
+	return h.changeStatus(c, h.service.CancelSubscription)
+}
+
+// @Summary List subscriptions
+// @Description Get all subscriptions, optionally filtered by status
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Subscription status (active, paused, cancelled, ended)"
+// @Success 200 {array} Subscription
+// @Failure 400 {object} errors.APIError
+// @Router /subscriptions [get]
+func (h *Handler) ListSubscriptions(c echo.Context) error {
+	var status *SubscriptionStatus
+	if value := c.QueryParam("status"); value != "" {
+		s := SubscriptionStatus(value)
+		status = &s
+	}
+
+	subscriptions, err := h.service.ListSubscriptions(c.Request().Context(), status)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, subscriptions)
+}
+
+// @Summary Get subscriptions of a campaign
+// @Description Get all subscriptions to a campaign
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Success 200 {array} Subscription
+// @Failure 400 {object} errors.APIError
+// @Router /campaigns/{campaignId}/subscriptions [get]
+func (h *Handler) GetSubscriptionsByCampaign(c echo.Context) error {
+	campaignID, err := uuid.Parse(c.Param("campaignId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	subscriptions, err := h.service.ListSubscriptionsByCampaign(c.Request().Context(), campaignID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, subscriptions)
+}
+
+// changeStatus runs a status change of the subscription in the path
+func (h *Handler) changeStatus(c echo.Context, change func(ctx context.Context, id uuid.UUID) (Subscription, error)) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid subscription ID")
+	}
+
+	subscription, err := change(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, subscription)
+}
+
+// errorStatus maps a subscription service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
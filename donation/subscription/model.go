@@ -0,0 +1,115 @@
+package subscription
+
+import (
+	"time"
+
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionModel represents the database table structure with GORM tags
+type SubscriptionModel struct {
+	ID                  uuid.UUID    `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	DonorID             uuid.UUID    `gorm:"column:donor_id;type:uuid;not null;index"`
+	CampaignID          uuid.UUID    `gorm:"column:campaign_id;type:uuid;not null;index"`
+	Amount              money.Amount `gorm:"column:amount;type:decimal(10,2);not null"`
+	Currency            string       `gorm:"column:currency;type:varchar(3);not null;default:ARS"`
+	Interval            string       `gorm:"column:interval;type:varchar(20);not null"`
+	BillingDay          int          `gorm:"column:billing_day;not null"`
+	PaymentMethodID     int          `gorm:"column:payment_method_id;not null"`
+	Status              string       `gorm:"column:status;type:varchar(20);not null;default:active"`
+	NextChargeAt        time.Time    `gorm:"column:next_charge_at;not null"`
+	LastChargedAt       *time.Time   `gorm:"column:last_charged_at"`
+	ManagementTokenHash string       `gorm:"column:management_token_hash;type:varchar(64);not null"`
+	EndedReason         *string      `gorm:"column:ended_reason"`
+	PausedAt            *time.Time   `gorm:"column:paused_at"`
+	CancelledAt         *time.Time   `gorm:"column:cancelled_at"`
+	EndedAt             *time.Time   `gorm:"column:ended_at"`
+	CreatedAt           time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt           time.Time    `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (SubscriptionModel) TableName() string {
+	return "donation_subscriptions"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m SubscriptionModel) ToEntity() Subscription {
+	return Subscription{
+		ID:              m.ID,
+		DonorID:         m.DonorID,
+		CampaignID:      m.CampaignID,
+		Amount:          m.Amount,
+		Currency:        m.Currency,
+		Interval:        Interval(m.Interval),
+		BillingDay:      m.BillingDay,
+		PaymentMethodID: m.PaymentMethodID,
+		Status:          SubscriptionStatus(m.Status),
+		NextChargeAt:    m.NextChargeAt,
+		LastChargedAt:   m.LastChargedAt,
+		EndedReason:     m.EndedReason,
+		PausedAt:        m.PausedAt,
+		CancelledAt:     m.CancelledAt,
+		EndedAt:         m.EndedAt,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *SubscriptionModel) FromEntity(entity Subscription) {
+	m.ID = entity.ID
+	m.DonorID = entity.DonorID
+	m.CampaignID = entity.CampaignID
+	m.Amount = entity.Amount
+	m.Currency = entity.Currency
+	m.Interval = string(entity.Interval)
+	m.BillingDay = entity.BillingDay
+	m.PaymentMethodID = entity.PaymentMethodID
+	m.Status = string(entity.Status)
+	m.NextChargeAt = entity.NextChargeAt
+	m.LastChargedAt = entity.LastChargedAt
+	m.EndedReason = entity.EndedReason
+	m.PausedAt = entity.PausedAt
+	m.CancelledAt = entity.CancelledAt
+	m.EndedAt = entity.EndedAt
+	m.CreatedAt = entity.CreatedAt
+}
+
+// ChargeModel represents the subscription charges table
+type ChargeModel struct {
+	ID             uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	SubscriptionID uuid.UUID  `gorm:"column:subscription_id;type:uuid;not null;index"`
+	DonationID     *uuid.UUID `gorm:"column:donation_id;type:uuid"`
+	DueAt          time.Time  `gorm:"column:due_at;not null"`
+	CheckoutURL    *string    `gorm:"column:checkout_url"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (ChargeModel) TableName() string {
+	return "subscription_charges"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m ChargeModel) ToEntity() Charge {
+	return Charge{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		DonationID:     m.DonationID,
+		DueAt:          m.DueAt,
+		CheckoutURL:    m.CheckoutURL,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *ChargeModel) FromEntity(entity Charge) {
+	m.ID = entity.ID
+	m.SubscriptionID = entity.SubscriptionID
+	m.DonationID = entity.DonationID
+	m.DueAt = entity.DueAt
+	m.CheckoutURL = entity.CheckoutURL
+	m.CreatedAt = entity.CreatedAt
+}
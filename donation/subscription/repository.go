@@ -0,0 +1,180 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrStatusChanged is returned when a subscription status changed since it was read
+var ErrStatusChanged = errors.New("subscription status was changed by another request")
+
+type Repository interface {
+	GetSubscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	GetTokenHash(ctx context.Context, id uuid.UUID) (string, error)
+	ListSubscriptions(ctx context.Context, status *SubscriptionStatus) ([]Subscription, error)
+	ListSubscriptionsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Subscription, error)
+	ListDueSubscriptions(ctx context.Context, now time.Time) ([]Subscription, error)
+	ListCharges(ctx context.Context, subscriptionID uuid.UUID) ([]Charge, error)
+	CreateSubscription(ctx context.Context, subscription Subscription, tokenHash string) error
+	UpdateStatus(ctx context.Context, subscription Subscription, from SubscriptionStatus) error
+	RecordCharge(ctx context.Context, charge Charge, nextChargeAt time.Time) error
+	EndSubscriptionsOfFinishedCampaigns(ctx context.Context, now time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetSubscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	var model SubscriptionModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return Subscription{}, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *repository) GetTokenHash(ctx context.Context, id uuid.UUID) (string, error) {
+	var model SubscriptionModel
+	if err := r.db.WithContext(ctx).Select("management_token_hash").Where("id = ?", id).First(&model).Error; err != nil {
+		return "", err
+	}
+
+	return model.ManagementTokenHash, nil
+}
+
+func (r *repository) ListSubscriptions(ctx context.Context, status *SubscriptionStatus) ([]Subscription, error) {
+	query := r.db.WithContext(ctx)
+	if status != nil {
+		query = query.Where("status = ?", string(*status))
+	}
+
+	var models []SubscriptionModel
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return toEntities(models), nil
+}
+
+func (r *repository) ListSubscriptionsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Subscription, error) {
+	var models []SubscriptionModel
+	if err := r.db.WithContext(ctx).Where("campaign_id = ?", campaignID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return toEntities(models), nil
+}
+
+// ListDueSubscriptions returns the active subscriptions whose next charge is due
+func (r *repository) ListDueSubscriptions(ctx context.Context, now time.Time) ([]Subscription, error) {
+	var models []SubscriptionModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_charge_at <= ?", string(StatusActive), now).
+		Order("next_charge_at").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return toEntities(models), nil
+}
+
+func (r *repository) ListCharges(ctx context.Context, subscriptionID uuid.UUID) ([]Charge, error) {
+	var models []ChargeModel
+	if err := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).Order("due_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	charges := make([]Charge, len(models))
+	for i, model := range models {
+		charges[i] = model.ToEntity()
+	}
+	return charges, nil
+}
+
+func (r *repository) CreateSubscription(ctx context.Context, subscription Subscription, tokenHash string) error {
+	var model SubscriptionModel
+	model.FromEntity(subscription)
+	model.ManagementTokenHash = tokenHash
+	return r.db.WithContext(ctx).Create(&model).Error
+}
+
+// UpdateStatus saves the status fields of a subscription that is still in the from status
+func (r *repository) UpdateStatus(ctx context.Context, subscription Subscription, from SubscriptionStatus) error {
+	result := r.db.WithContext(ctx).
+		Model(&SubscriptionModel{}).
+		Where("id = ? AND status = ?", subscription.ID, string(from)).
+		Updates(map[string]interface{}{
+			"status":         string(subscription.Status),
+			"next_charge_at": subscription.NextChargeAt,
+			"paused_at":      subscription.PausedAt,
+			"cancelled_at":   subscription.CancelledAt,
+			"ended_at":       subscription.EndedAt,
+			"ended_reason":   subscription.EndedReason,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStatusChanged
+	}
+	return nil
+}
+
+// RecordCharge stores the charge of a period and moves the subscription to its next charge date.
+// A period that was already charged is not stored twice.
+func (r *repository) RecordCharge(ctx context.Context, charge Charge, nextChargeAt time.Time) error {
+	var model ChargeModel
+	model.FromEntity(charge)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "due_at"}},
+			DoNothing: true,
+		}).Create(&model).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&SubscriptionModel{}).
+			Where("id = ?", charge.SubscriptionID).
+			Updates(map[string]interface{}{
+				"next_charge_at":  nextChargeAt,
+				"last_charged_at": charge.CreatedAt,
+			}).Error
+	})
+}
+
+// EndSubscriptionsOfFinishedCampaigns ends the running subscriptions of completed or archived campaigns
+func (r *repository) EndSubscriptionsOfFinishedCampaigns(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		UPDATE donation_subscriptions s
+		SET status = ?,
+			ended_at = ?,
+			ended_reason = CASE WHEN c.status = 'completed' THEN ? ELSE ? END,
+			updated_at = NOW()
+		FROM campaigns c
+		WHERE s.campaign_id = c.id
+			AND s.status IN (?, ?)
+			AND (c.status = 'completed' OR c.archived_at IS NOT NULL)
+	`, string(StatusEnded), now, EndedReasonCampaignCompleted, EndedReasonCampaignArchived,
+		string(StatusActive), string(StatusPaused))
+	return result.RowsAffected, result.Error
+}
+
+func toEntities(models []SubscriptionModel) []Subscription {
+	subscriptions := make([]Subscription, len(models))
+	for i, model := range models {
+		subscriptions[i] = model.ToEntity()
+	}
+	return subscriptions
+}
@@ -0,0 +1,42 @@
+package subscription
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"dona_tutti_api/scheduler"
+
+	"gorm.io/gorm"
+)
+
+// ChargeLockKey is the Postgres advisory lock key held while subscriptions are charged,
+// so that only one API replica creates the donations of a period
+const ChargeLockKey int64 = 4815162343
+
+// DefaultChargeInterval is how often the scheduler looks for due subscriptions
+const DefaultChargeInterval = time.Hour
+
+// NewScheduler creates the scheduler that periodically creates the donations of due subscriptions
+func NewScheduler(service Service, db *gorm.DB, interval time.Duration) *scheduler.Scheduler {
+	if interval <= 0 {
+		interval = DefaultChargeInterval
+	}
+	return scheduler.New("Subscription charges", db, ChargeLockKey, interval, func(ctx context.Context) error {
+		result, err := service.RunCharges(ctx)
+		if err != nil {
+			return err
+		}
+
+		if result.Ended > 0 {
+			log.Printf("🛑 Ended %d subscriptions of finished campaigns", result.Ended)
+		}
+		if len(result.Charged) > 0 {
+			log.Printf("✅ Created donations for %d subscriptions", len(result.Charged))
+		}
+		for subscriptionID, reason := range result.Failed {
+			log.Printf("⚠️  Could not charge subscription %s: %s", subscriptionID, reason)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,411 @@
+package subscription
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"dona_tutti_api/currency"
+	"dona_tutti_api/donation"
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DonationService defines the donation operations needed to charge subscriptions
+type DonationService interface {
+	GetOrCreatePublicDonor(ctx context.Context, donorInfo donation.DonorInfo) (uuid.UUID, error)
+	CreateDonationWithRequest(ctx context.Context, campaignID uuid.UUID, req donation.CreateDonationRequest) (uuid.UUID, error)
+	CreateCheckoutSession(ctx context.Context, donationID uuid.UUID) (donation.PaymentSession, error)
+}
+
+// CampaignService defines the campaign operations needed by the subscription service
+type CampaignService interface {
+	GetCampaignStatus(ctx context.Context, campaignID uuid.UUID) (string, error)
+	GetCampaignCurrency(ctx context.Context, campaignID uuid.UUID) (string, error)
+}
+
+type Service interface {
+	CreateSubscription(ctx context.Context, campaignID uuid.UUID, req CreateSubscriptionRequest) (CreateSubscriptionResponse, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	VerifyToken(ctx context.Context, id uuid.UUID, token string) (bool, error)
+	ListSubscriptions(ctx context.Context, status *SubscriptionStatus) ([]Subscription, error)
+	ListSubscriptionsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Subscription, error)
+	ListCharges(ctx context.Context, id uuid.UUID) ([]Charge, error)
+	PauseSubscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	ResumeSubscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	CancelSubscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	RunCharges(ctx context.Context) (*ChargeRunResult, error)
+}
+
+type service struct {
+	repo            Repository
+	donationService DonationService
+	campaignService CampaignService
+}
+
+func NewService(repo Repository, donationService DonationService, campaignService CampaignService) Service {
+	return &service{
+		repo:            repo,
+		donationService: donationService,
+		campaignService: campaignService,
+	}
+}
+
+// CreateSubscription signs a donor up for recurring donations to an active campaign.
+// The first period is charged right away.
+func (s *service) CreateSubscription(ctx context.Context, campaignID uuid.UUID, req CreateSubscriptionRequest) (CreateSubscriptionResponse, error) {
+	// Validate request
+	if !req.Amount.IsPositive() {
+		return CreateSubscriptionResponse{}, apierrors.NewFieldValidationError("amount", "amount must be greater than 0")
+	}
+	if req.Amount > donation.MaxDonationAmount {
+		return CreateSubscriptionResponse{}, apierrors.NewFieldValidationError("amount",
+			fmt.Sprintf("amount cannot exceed %s", donation.MaxDonationAmount))
+	}
+	if req.Interval == "" {
+		req.Interval = IntervalMonthly
+	}
+	if req.Interval.Months() == 0 {
+		return CreateSubscriptionResponse{}, apierrors.NewFieldValidationError("interval", fmt.Sprintf("invalid interval: %s", req.Interval))
+	}
+	if req.PaymentMethodID <= 0 {
+		return CreateSubscriptionResponse{}, apierrors.NewFieldValidationError("payment_method_id", "payment method is required")
+	}
+	if strings.TrimSpace(req.Donor.Name) == "" || strings.TrimSpace(req.Donor.LastName) == "" {
+		return CreateSubscriptionResponse{}, apierrors.NewFieldValidationError("donor", "donor name and last name are required")
+	}
+	if req.Donor.Email == nil || strings.TrimSpace(*req.Donor.Email) == "" {
+		return CreateSubscriptionResponse{}, apierrors.NewFieldValidationError("donor.email", "donor email is required for recurring donations")
+	}
+
+	status, err := s.campaignService.GetCampaignStatus(ctx, campaignID)
+	if err != nil {
+		return CreateSubscriptionResponse{}, apierrors.NewNotFoundError("campaign not found")
+	}
+	if status != "active" {
+		return CreateSubscriptionResponse{}, apierrors.NewValidationError(
+			fmt.Sprintf("campaign is not accepting donations: campaign status is %s", status))
+	}
+
+	if req.Currency == "" {
+		req.Currency, err = s.campaignService.GetCampaignCurrency(ctx, campaignID)
+		if err != nil {
+			return CreateSubscriptionResponse{}, fmt.Errorf("failed to get campaign currency: %w", err)
+		}
+	}
+	req.Currency = currency.Normalize(req.Currency)
+	if !currency.IsSupported(req.Currency) {
+		return CreateSubscriptionResponse{}, apierrors.NewFieldValidationError("currency", fmt.Sprintf("unsupported currency: %s", req.Currency))
+	}
+
+	// Subscriptions are created by unauthenticated donors, who cannot claim the donor of a stranger
+	donorID, err := s.donationService.GetOrCreatePublicDonor(ctx, req.Donor)
+	if err != nil {
+		return CreateSubscriptionResponse{}, fmt.Errorf("failed to get or create donor: %w", err)
+	}
+
+	token, tokenHash, err := newManagementToken()
+	if err != nil {
+		return CreateSubscriptionResponse{}, err
+	}
+
+	now := time.Now()
+	subscription := Subscription{
+		ID:              uuid.New(),
+		DonorID:         donorID,
+		CampaignID:      campaignID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Interval:        req.Interval,
+		BillingDay:      now.Day(),
+		PaymentMethodID: req.PaymentMethodID,
+		Status:          StatusActive,
+		NextChargeAt:    now,
+		CreatedAt:       now,
+	}
+	if err := s.repo.CreateSubscription(ctx, subscription, tokenHash); err != nil {
+		return CreateSubscriptionResponse{}, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	response := CreateSubscriptionResponse{ManagementToken: token}
+
+	// The scheduler retries the first charge if it fails now
+	charge, _, err := s.charge(ctx, subscription, now)
+	if err != nil {
+		fmt.Printf("Warning: failed to charge new subscription %s: %v\n", subscription.ID, err)
+	}
+	response.FirstCharge = charge
+
+	response.Subscription, err = s.GetSubscription(ctx, subscription.ID)
+	if err != nil {
+		return CreateSubscriptionResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (s *service) GetSubscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	subscription, err := s.repo.GetSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Subscription{}, apierrors.NewNotFoundError("subscription not found")
+		}
+		return Subscription{}, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// VerifyToken checks a donor management token against the stored hash
+func (s *service) VerifyToken(ctx context.Context, id uuid.UUID, token string) (bool, error) {
+	storedHash, err := s.repo.GetTokenHash(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(storedHash)) == 1, nil
+}
+
+func (s *service) ListSubscriptions(ctx context.Context, status *SubscriptionStatus) ([]Subscription, error) {
+	if status != nil && !IsValidStatus(*status) {
+		return nil, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid subscription status: %s", *status))
+	}
+	return s.repo.ListSubscriptions(ctx, status)
+}
+
+func (s *service) ListSubscriptionsByCampaign(ctx context.Context, campaignID uuid.UUID) ([]Subscription, error) {
+	return s.repo.ListSubscriptionsByCampaign(ctx, campaignID)
+}
+
+func (s *service) ListCharges(ctx context.Context, id uuid.UUID) ([]Charge, error) {
+	return s.repo.ListCharges(ctx, id)
+}
+
+// PauseSubscription stops charging a subscription until it is resumed
+func (s *service) PauseSubscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	return s.changeStatus(ctx, id, StatusPaused, func(subscription *Subscription, now time.Time) error {
+		subscription.PausedAt = &now
+		return nil
+	})
+}
+
+// ResumeSubscription restarts a paused subscription. Periods missed while paused are not charged:
+// the next charge is due on the next billing day.
+func (s *service) ResumeSubscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	return s.changeStatus(ctx, id, StatusActive, func(subscription *Subscription, now time.Time) error {
+		status, err := s.campaignService.GetCampaignStatus(ctx, subscription.CampaignID)
+		if err != nil {
+			return fmt.Errorf("failed to verify campaign status: %w", err)
+		}
+		if status == "completed" {
+			return apierrors.NewValidationError("campaign has been completed, the subscription cannot be resumed")
+		}
+
+		subscription.PausedAt = nil
+		if subscription.NextChargeAt.Before(now) {
+			subscription.NextChargeAt = nextBillingDate(now, subscription.BillingDay)
+		}
+		return nil
+	})
+}
+
+// CancelSubscription stops a subscription for good
+func (s *service) CancelSubscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	return s.changeStatus(ctx, id, StatusCancelled, func(subscription *Subscription, now time.Time) error {
+		subscription.CancelledAt = &now
+		return nil
+	})
+}
+
+// changeStatus validates and stores a status change, letting apply update the related fields
+func (s *service) changeStatus(ctx context.Context, id uuid.UUID, to SubscriptionStatus, apply func(subscription *Subscription, now time.Time) error) (Subscription, error) {
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	if !CanTransitionTo(subscription.Status, to) {
+		return Subscription{}, apierrors.NewFieldValidationError("status",
+			fmt.Sprintf("cannot change subscription status from %s to %s", subscription.Status, to))
+	}
+
+	from := subscription.Status
+	subscription.Status = to
+	if err := apply(&subscription, time.Now()); err != nil {
+		return Subscription{}, err
+	}
+
+	if err := s.repo.UpdateStatus(ctx, subscription, from); err != nil {
+		if errors.Is(err, ErrStatusChanged) {
+			return Subscription{}, apierrors.NewValidationError(err.Error())
+		}
+		return Subscription{}, fmt.Errorf("failed to update subscription status: %w", err)
+	}
+
+	return s.GetSubscription(ctx, id)
+}
+
+// RunCharges ends the subscriptions of finished campaigns and creates the donations of every due subscription
+func (s *service) RunCharges(ctx context.Context) (*ChargeRunResult, error) {
+	now := time.Now()
+	result := &ChargeRunResult{
+		RunAt:   now,
+		Charged: []uuid.UUID{},
+		Skipped: make(map[uuid.UUID]string),
+		Failed:  make(map[uuid.UUID]string),
+	}
+
+	ended, err := s.repo.EndSubscriptionsOfFinishedCampaigns(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end subscriptions of finished campaigns: %w", err)
+	}
+	result.Ended = ended
+
+	due, err := s.repo.ListDueSubscriptions(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due subscriptions: %w", err)
+	}
+
+	for _, subscription := range due {
+		charge, skipReason, err := s.charge(ctx, subscription, now)
+		switch {
+		case err != nil:
+			result.Failed[subscription.ID] = err.Error()
+		case charge == nil:
+			result.Skipped[subscription.ID] = skipReason
+		default:
+			result.Charged = append(result.Charged, subscription.ID)
+		}
+	}
+
+	return result, nil
+}
+
+// charge creates the pending donation of the subscription's current period and starts its payment.
+// It returns a skip reason instead of a charge when the campaign is not accepting donations.
+func (s *service) charge(ctx context.Context, subscription Subscription, now time.Time) (*Charge, string, error) {
+	status, err := s.campaignService.GetCampaignStatus(ctx, subscription.CampaignID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to verify campaign status: %w", err)
+	}
+	switch status {
+	case "active":
+	case "completed":
+		// Subscriptions stop with their campaign
+		reason := EndedReasonCampaignCompleted
+		subscription.Status = StatusEnded
+		subscription.EndedAt = &now
+		subscription.EndedReason = &reason
+		if err := s.repo.UpdateStatus(ctx, subscription, StatusActive); err != nil {
+			return nil, "", fmt.Errorf("failed to end subscription: %w", err)
+		}
+		return nil, "campaign completed, subscription ended", nil
+	default:
+		// Paused campaigns are charged once they are active again
+		return nil, fmt.Sprintf("campaign status is %s", status), nil
+	}
+
+	// The idempotency key makes retries of the same period return the same donation
+	idempotencyKey := fmt.Sprintf("subscription-%s-%d", subscription.ID, subscription.NextChargeAt.Unix())
+	message := "Donación recurrente"
+	donationID, err := s.donationService.CreateDonationWithRequest(ctx, subscription.CampaignID, donation.CreateDonationRequest{
+		Amount:          subscription.Amount,
+		Currency:        subscription.Currency,
+		Message:         &message,
+		PaymentMethodID: subscription.PaymentMethodID,
+		DonorID:         &subscription.DonorID,
+		IdempotencyKey:  &idempotencyKey,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create donation: %w", err)
+	}
+
+	charge := Charge{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		DonationID:     &donationID,
+		DueAt:          subscription.NextChargeAt,
+		CreatedAt:      now,
+	}
+
+	// Hand the donation to the payment flow. Offline payment methods have no gateway and stay
+	// pending until an admin confirms them.
+	session, err := s.donationService.CreateCheckoutSession(ctx, donationID)
+	if err == nil {
+		charge.CheckoutURL = &session.CheckoutURL
+	} else {
+		var validationErr apierrors.ValidationError
+		if !errors.As(err, &validationErr) {
+			log.Printf("⚠️  Could not start payment of subscription %s donation %s: %v", subscription.ID, donationID, err)
+		}
+	}
+
+	nextChargeAt := nextChargeAfter(subscription, now)
+	if err := s.repo.RecordCharge(ctx, charge, nextChargeAt); err != nil {
+		return nil, "", fmt.Errorf("failed to record charge: %w", err)
+	}
+
+	return &charge, "", nil
+}
+
+// nextChargeAfter returns the first due date of the subscription after now.
+// Periods missed while the scheduler was not running are skipped rather than charged at once.
+func nextChargeAfter(subscription Subscription, now time.Time) time.Time {
+	months := subscription.Interval.Months()
+	if months == 0 {
+		months = 1
+	}
+
+	next := addMonths(subscription.NextChargeAt, months, subscription.BillingDay)
+	for !next.After(now) {
+		next = addMonths(next, months, subscription.BillingDay)
+	}
+	return next
+}
+
+// nextBillingDate returns the first billing day on or after now
+func nextBillingDate(now time.Time, billingDay int) time.Time {
+	candidate := addMonths(now, 0, billingDay)
+	if candidate.Before(now) {
+		candidate = addMonths(now, 1, billingDay)
+	}
+	return candidate
+}
+
+// addMonths moves t by months, landing on billingDay or on the last day of shorter months
+func addMonths(t time.Time, months int, billingDay int) time.Time {
+	year, month, _ := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+
+	day := billingDay
+	if lastDay := first.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// newManagementToken returns a random token for the donor and the hash stored in the database
+func newManagementToken() (string, string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", fmt.Errorf("failed to generate management token: %w", err)
+	}
+	token := hex.EncodeToString(bytes)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,135 @@
+package subscription
+
+import (
+	"time"
+
+	"dona_tutti_api/donation"
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// TokenHeader is the header carrying the management token that lets donors manage their subscription
+const TokenHeader = "X-Subscription-Token"
+
+// Interval represents how often a subscription donates
+type Interval string
+
+const (
+	IntervalMonthly   Interval = "monthly"
+	IntervalQuarterly Interval = "quarterly"
+	IntervalYearly    Interval = "yearly"
+)
+
+// Months returns the length of the interval in months, or 0 if the interval is not valid
+func (i Interval) Months() int {
+	switch i {
+	case IntervalMonthly:
+		return 1
+	case IntervalQuarterly:
+		return 3
+	case IntervalYearly:
+		return 12
+	default:
+		return 0
+	}
+}
+
+// SubscriptionStatus represents the state of a subscription
+type SubscriptionStatus string
+
+const (
+	StatusActive    SubscriptionStatus = "active"
+	StatusPaused    SubscriptionStatus = "paused"
+	StatusCancelled SubscriptionStatus = "cancelled" // Cancelled by the donor or an admin
+	StatusEnded     SubscriptionStatus = "ended"     // Stopped because the campaign finished
+)
+
+// IsValidStatus checks if a subscription status is valid
+func IsValidStatus(status SubscriptionStatus) bool {
+	switch status {
+	case StatusActive, StatusPaused, StatusCancelled, StatusEnded:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo checks if a subscription status transition is valid
+func CanTransitionTo(from, to SubscriptionStatus) bool {
+	validTransitions := map[SubscriptionStatus][]SubscriptionStatus{
+		StatusActive:    {StatusPaused, StatusCancelled, StatusEnded},
+		StatusPaused:    {StatusActive, StatusCancelled, StatusEnded},
+		StatusCancelled: {}, // Terminal state
+		StatusEnded:     {}, // Terminal state
+	}
+
+	for _, allowed := range validTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Reasons a subscription ended
+const (
+	EndedReasonCampaignCompleted = "campaign_completed"
+	EndedReasonCampaignArchived  = "campaign_archived"
+)
+
+// Subscription represents a recurring donation of a donor to a campaign
+type Subscription struct {
+	ID              uuid.UUID          `json:"id"`
+	DonorID         uuid.UUID          `json:"donor_id"`
+	CampaignID      uuid.UUID          `json:"campaign_id"`
+	Amount          money.Amount       `json:"amount"`
+	Currency        string             `json:"currency"` // Converted to the campaign currency on each charge
+	Interval        Interval           `json:"interval"`
+	BillingDay      int                `json:"billing_day"` // Day of the month charges are due
+	PaymentMethodID int                `json:"payment_method_id"`
+	Status          SubscriptionStatus `json:"status"`
+	NextChargeAt    time.Time          `json:"next_charge_at"`
+	LastChargedAt   *time.Time         `json:"last_charged_at,omitempty"`
+	EndedReason     *string            `json:"ended_reason,omitempty"`
+	PausedAt        *time.Time         `json:"paused_at,omitempty"`
+	CancelledAt     *time.Time         `json:"cancelled_at,omitempty"`
+	EndedAt         *time.Time         `json:"ended_at,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// Charge is the donation generated for one subscription period
+type Charge struct {
+	ID             uuid.UUID  `json:"id"`
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	DonationID     *uuid.UUID `json:"donation_id,omitempty"`
+	DueAt          time.Time  `json:"due_at"`
+	CheckoutURL    *string    `json:"checkout_url,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// CreateSubscriptionRequest represents a donor signing up for recurring donations
+type CreateSubscriptionRequest struct {
+	Amount          money.Amount       `json:"amount"`
+	Currency        string             `json:"currency,omitempty"` // Defaults to the campaign currency
+	Interval        Interval           `json:"interval,omitempty"` // Defaults to monthly
+	PaymentMethodID int                `json:"payment_method_id"`
+	Donor           donation.DonorInfo `json:"donor"`
+}
+
+// CreateSubscriptionResponse is returned once when a subscription is created.
+// The management token is not stored in clear and cannot be retrieved again.
+type CreateSubscriptionResponse struct {
+	Subscription    Subscription `json:"subscription"`
+	ManagementToken string       `json:"management_token"`
+	FirstCharge     *Charge      `json:"first_charge,omitempty"`
+}
+
+// ChargeRunResult summarizes a scheduler pass
+type ChargeRunResult struct {
+	RunAt   time.Time            `json:"run_at"`
+	Ended   int64                `json:"ended"`
+	Charged []uuid.UUID          `json:"charged"`
+	Skipped map[uuid.UUID]string `json:"skipped"`
+	Failed  map[uuid.UUID]string `json:"failed"`
+}
@@ -0,0 +1,80 @@
+package account
+
+import (
+	"time"
+
+	"dona_tutti_api/donation"
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// Donation is a donation made by one of the donors linked to a user
+type Donation struct {
+	ID               uuid.UUID               `json:"id"`
+	CampaignID       uuid.UUID               `json:"campaign_id"`
+	CampaignTitle    string                  `json:"campaign_title"`
+	Amount           money.Amount            `json:"amount"` // In the campaign currency
+	Currency         string                  `json:"currency"`
+	OriginalAmount   money.Amount            `json:"original_amount"` // What the donor gave, in OriginalCurrency
+	OriginalCurrency string                  `json:"original_currency"`
+	RefundedAmount   money.Amount            `json:"refunded_amount"`
+	Date             time.Time               `json:"date"`
+	Message          *string                 `json:"message,omitempty"`
+	IsAnonymous      bool                    `json:"is_anonymous"`
+	Status           donation.DonationStatus `json:"status"`
+	ReceiptURL       *string                 `json:"receipt_url,omitempty"`
+}
+
+// YearTotal is the money a user gave during a year in one currency, net of refunds
+type YearTotal struct {
+	Year      int          `json:"year"`
+	Currency  string       `json:"currency"`
+	Amount    money.Amount `json:"amount"`
+	Donations int          `json:"donations"`
+}
+
+// DonationHistory is the donation history of a user with the completed totals of every year
+type DonationHistory struct {
+	Donations    []Donation  `json:"donations"`
+	TotalsByYear []YearTotal `json:"totals_by_year"`
+}
+
+// DonationFilter represents the optional filters of a donation history
+type DonationFilter struct {
+	CampaignID *uuid.UUID
+	Year       *int
+	Status     *donation.DonationStatus
+}
+
+// SupportedCampaign is a campaign a user gave completed donations to
+type SupportedCampaign struct {
+	CampaignID      uuid.UUID    `json:"campaign_id"`
+	Title           string       `json:"title"`
+	Image           string       `json:"image"`
+	Status          string       `json:"status"`
+	Currency        string       `json:"currency"`
+	TotalDonated    money.Amount `json:"total_donated"` // Net of refunds
+	DonationCount   int          `json:"donation_count"`
+	FirstDonationAt time.Time    `json:"first_donation_at"`
+	LastDonationAt  time.Time    `json:"last_donation_at"`
+}
+
+// LinkToken is sent to a donor email to prove the user requesting the link owns it
+type LinkToken struct {
+	Token     string
+	UserID    uuid.UUID
+	Email     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// LinkResult reports the donors linked to a user
+type LinkResult struct {
+	LinkedDonors int `json:"linked_donors"`
+}
+
+// ConfirmLinkRequest represents a user confirming the link with the token sent to their email
+type ConfirmLinkRequest struct {
+	Token string `json:"token" validate:"required"`
+}
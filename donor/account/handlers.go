@@ -0,0 +1,185 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"dona_tutti_api/donation"
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests for donor self-service accounts
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new donor account handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the donor account routes of the authenticated user
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc) {
+	meGroup := g.Group("/me", authMiddleware)
+	meGroup.POST("/donor-link", h.RequestDonorLink)
+	meGroup.POST("/donor-link/confirm", h.ConfirmDonorLink)
+	meGroup.GET("/donations", h.GetDonationHistory)
+	meGroup.GET("/campaigns-supported", h.ListSupportedCampaigns)
+}
+
+// @Summary Request a donor link
+// @Description Send a token to the email of the current user to link the donations made with that email to the account
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 202
+// @Failure 400 {object} errors.APIError
+// @Failure 401 {object} errors.APIError
+// @Failure 503 {object} errors.APIError
+// @Router /me/donor-link [post]
+func (h *Handler) RequestDonorLink(c echo.Context) error {
+	userID := getUserID(c)
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	if err := h.service.RequestDonorLink(c.Request().Context(), *userID); err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// @Summary Confirm a donor link
+// @Description Link the donations made with the email of the current user to the account, using the token sent to that email. The user becomes a donor if any of those donations completed.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param link body ConfirmLinkRequest true "Link token"
+// @Success 200 {object} LinkResult
+// @Failure 400 {object} errors.APIError
+// @Failure 401 {object} errors.APIError
+// @Router /me/donor-link/confirm [post]
+func (h *Handler) ConfirmDonorLink(c echo.Context) error {
+	userID := getUserID(c)
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	var req ConfirmLinkRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	result, err := h.service.ConfirmDonorLink(c.Request().Context(), *userID, req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// @Summary Get my donations
+// @Description Get the donations of the current user, most recent first, with receipt links and the completed totals of every year
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id query string false "Campaign ID"
+// @Param year query int false "Year of the donation"
+// @Param status query string false "Donation status (pending, completed, failed, refunded)"
+// @Success 200 {object} DonationHistory
+// @Failure 400 {object} errors.APIError
+// @Failure 401 {object} errors.APIError
+// @Router /me/donations [get]
+func (h *Handler) GetDonationHistory(c echo.Context) error {
+	userID := getUserID(c)
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	var filter DonationFilter
+	if value := c.QueryParam("campaign_id"); value != "" {
+		campaignID, err := uuid.Parse(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid campaign ID")
+		}
+		filter.CampaignID = &campaignID
+	}
+	if value := c.QueryParam("year"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid year")
+		}
+		filter.Year = &year
+	}
+	if value := c.QueryParam("status"); value != "" {
+		status := donation.DonationStatus(value)
+		filter.Status = &status
+	}
+
+	history, err := h.service.GetDonationHistory(c.Request().Context(), *userID, filter)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
+
+// @Summary Get the campaigns I supported
+// @Description Get the campaigns the current user gave completed donations to, with the total given to each
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} SupportedCampaign
+// @Failure 401 {object} errors.APIError
+// @Router /me/campaigns-supported [get]
+func (h *Handler) ListSupportedCampaigns(c echo.Context) error {
+	userID := getUserID(c)
+	if userID == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	campaigns, err := h.service.ListSupportedCampaigns(c.Request().Context(), *userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, campaigns)
+}
+
+// errorStatus maps a donor account service error to an HTTP status code
+func errorStatus(err error) int {
+	if errors.Is(err, ErrEmailDeliveryUnavailable) {
+		return http.StatusServiceUnavailable
+	}
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,41 @@
+package account
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LinkTokenModel represents the database table structure with GORM tags
+type LinkTokenModel struct {
+	Token     string    `gorm:"primaryKey;column:token;type:varchar(64)"`
+	UserID    uuid.UUID `gorm:"column:user_id;type:uuid;not null;index"`
+	Email     string    `gorm:"column:email;type:varchar(255);not null"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (LinkTokenModel) TableName() string {
+	return "donor_link_tokens"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m LinkTokenModel) ToEntity() LinkToken {
+	return LinkToken{
+		Token:     m.Token,
+		UserID:    m.UserID,
+		Email:     m.Email,
+		ExpiresAt: m.ExpiresAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *LinkTokenModel) FromEntity(entity LinkToken) {
+	m.Token = entity.Token
+	m.UserID = entity.UserID
+	m.Email = entity.Email
+	m.ExpiresAt = entity.ExpiresAt
+	m.CreatedAt = entity.CreatedAt
+}
@@ -0,0 +1,178 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CountUnlinkedDonors(ctx context.Context, email string) (int64, error)
+	CreateLinkToken(ctx context.Context, token LinkToken) error
+	GetLinkToken(ctx context.Context, token string) (LinkToken, error)
+	LinkDonors(ctx context.Context, userID uuid.UUID, email string) (int64, error)
+	GetDonorUserID(ctx context.Context, donorID uuid.UUID) (*uuid.UUID, error)
+	CountCompletedDonations(ctx context.Context, userID uuid.UUID) (int64, error)
+	ListDonations(ctx context.Context, userID uuid.UUID, filter DonationFilter) ([]Donation, error)
+	GetYearTotals(ctx context.Context, userID uuid.UUID, filter DonationFilter) ([]YearTotal, error)
+	ListSupportedCampaigns(ctx context.Context, userID uuid.UUID) ([]SupportedCampaign, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// CountUnlinkedDonors counts the donors with the given email that are not linked to a user yet.
+// Emails are compared ignoring case.
+func (r *repository) CountUnlinkedDonors(ctx context.Context, email string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("donors").
+		Where("LOWER(email) = LOWER(?) AND user_id IS NULL", email).
+		Count(&count).Error
+	return count, err
+}
+
+// CreateLinkToken stores a link token, replacing the previous tokens of the user
+func (r *repository) CreateLinkToken(ctx context.Context, token LinkToken) error {
+	var model LinkTokenModel
+	model.FromEntity(token)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", token.UserID).Delete(&LinkTokenModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model).Error
+	})
+}
+
+func (r *repository) GetLinkToken(ctx context.Context, token string) (LinkToken, error) {
+	var model LinkTokenModel
+	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&model).Error; err != nil {
+		return LinkToken{}, err
+	}
+	return model.ToEntity(), nil
+}
+
+// LinkDonors links the unlinked donors with the given email to a user, marks them as verified and
+// discards the link tokens of the user
+func (r *repository) LinkDonors(ctx context.Context, userID uuid.UUID, email string) (int64, error) {
+	var linked int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Table("donors").
+			Where("LOWER(email) = LOWER(?) AND user_id IS NULL", email).
+			Updates(map[string]interface{}{
+				"user_id":     userID,
+				"is_verified": true,
+				"updated_at":  time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		linked = result.RowsAffected
+
+		return tx.Where("user_id = ?", userID).Delete(&LinkTokenModel{}).Error
+	})
+	return linked, err
+}
+
+// GetDonorUserID returns the user a donor is linked to, or nil
+func (r *repository) GetDonorUserID(ctx context.Context, donorID uuid.UUID) (*uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Table("donors").
+		Where("id = ? AND user_id IS NOT NULL", donorID).
+		Pluck("user_id", &userIDs).Error
+	if err != nil || len(userIDs) == 0 {
+		return nil, err
+	}
+	return &userIDs[0], nil
+}
+
+func (r *repository) CountCompletedDonations(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("donations d").
+		Joins("JOIN donors dn ON dn.id = d.donor_id").
+		Where("dn.user_id = ? AND d.status = 'completed'", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// userDonationsQuery selects the donations of the donors linked to a user
+func (r *repository) userDonationsQuery(ctx context.Context, userID uuid.UUID, filter DonationFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Table("donations d").
+		Joins("JOIN donors dn ON dn.id = d.donor_id").
+		Where("dn.user_id = ?", userID)
+	if filter.CampaignID != nil {
+		query = query.Where("d.campaign_id = ?", *filter.CampaignID)
+	}
+	if filter.Year != nil {
+		query = query.Where("EXTRACT(YEAR FROM d.date) = ?", *filter.Year)
+	}
+	return query
+}
+
+// ListDonations returns the donations of the donors linked to a user, most recent first
+func (r *repository) ListDonations(ctx context.Context, userID uuid.UUID, filter DonationFilter) ([]Donation, error) {
+	query := r.userDonationsQuery(ctx, userID, filter).
+		Select(`d.id, d.campaign_id, c.title AS campaign_title, d.amount, d.currency,
+			d.original_amount, d.original_currency, d.refunded_amount, d.date, d.message,
+			d.is_anonymous, d.status, d.receipt_url`).
+		Joins("JOIN campaigns c ON c.id = d.campaign_id")
+	if filter.Status != nil {
+		query = query.Where("d.status = ?", string(*filter.Status))
+	}
+
+	var donations []Donation
+	if err := query.Order("d.date DESC").Scan(&donations).Error; err != nil {
+		return nil, err
+	}
+	return donations, nil
+}
+
+// GetYearTotals returns the completed donations of the donors linked to a user per year and
+// currency, net of refunds, most recent year first
+func (r *repository) GetYearTotals(ctx context.Context, userID uuid.UUID, filter DonationFilter) ([]YearTotal, error) {
+	var totals []YearTotal
+	err := r.userDonationsQuery(ctx, userID, filter).
+		Select(`CAST(EXTRACT(YEAR FROM d.date) AS INTEGER) AS year, d.currency,
+			COALESCE(SUM(d.amount - d.refunded_amount), 0) AS amount,
+			COUNT(*) AS donations`).
+		Where("d.status = 'completed'").
+		Group("year, d.currency").
+		Order("year DESC, d.currency").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// ListSupportedCampaigns returns the campaigns the donors linked to a user gave completed donations
+// to, most recently supported first
+func (r *repository) ListSupportedCampaigns(ctx context.Context, userID uuid.UUID) ([]SupportedCampaign, error) {
+	var campaigns []SupportedCampaign
+	err := r.userDonationsQuery(ctx, userID, DonationFilter{}).
+		Select(`c.id AS campaign_id, c.title, c.image, c.status, c.currency,
+			COALESCE(SUM(d.amount - d.refunded_amount), 0) AS total_donated,
+			COUNT(*) AS donation_count,
+			MIN(d.date) AS first_donation_at,
+			MAX(d.date) AS last_donation_at`).
+		Joins("JOIN campaigns c ON c.id = d.campaign_id").
+		Where("d.status = 'completed'").
+		Group("c.id").
+		Order("last_donation_at DESC").
+		Scan(&campaigns).Error
+	if err != nil {
+		return nil, err
+	}
+	return campaigns, nil
+}
@@ -0,0 +1,214 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"dona_tutti_api/donation"
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/rbac"
+	"dona_tutti_api/user"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const linkTokenExpiration = 24 * time.Hour
+
+type Service interface {
+	RequestDonorLink(ctx context.Context, userID uuid.UUID) error
+	ConfirmDonorLink(ctx context.Context, userID uuid.UUID, req ConfirmLinkRequest) (LinkResult, error)
+	GetDonationHistory(ctx context.Context, userID uuid.UUID, filter DonationFilter) (DonationHistory, error)
+	ListSupportedCampaigns(ctx context.Context, userID uuid.UUID) ([]SupportedCampaign, error)
+	AssignDonorRole(ctx context.Context, donorID uuid.UUID) error
+}
+
+// UserService defines the user operations needed by donor accounts
+type UserService interface {
+	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+}
+
+// Mailer defines the email delivery needed to send link tokens
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// ErrEmailDeliveryUnavailable is returned when link tokens cannot be sent because no mailer is configured
+var ErrEmailDeliveryUnavailable = errors.New("email delivery is not configured")
+
+// RoleService defines the role operations needed to grant the donor role
+type RoleService interface {
+	HasRole(ctx context.Context, userID uuid.UUID, roleName string) (bool, error)
+	AssignUserRole(ctx context.Context, userID uuid.UUID, roleName string) (*rbac.Role, error)
+}
+
+type service struct {
+	repo        Repository
+	userService UserService
+	roleService RoleService
+	mailer      Mailer
+}
+
+// NewService creates a donor account service. Without a mailer, donor links cannot be requested.
+func NewService(repo Repository, userService UserService, roleService RoleService, mailer Mailer) Service {
+	return &service{
+		repo:        repo,
+		userService: userService,
+		roleService: roleService,
+		mailer:      mailer,
+	}
+}
+
+// RequestDonorLink sends a link token to the email of a user when donors with that email are not
+// linked to any user yet
+func (s *service) RequestDonorLink(ctx context.Context, userID uuid.UUID) error {
+	if s.mailer == nil {
+		return ErrEmailDeliveryUnavailable
+	}
+
+	account, err := s.userService.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	count, err := s.repo.CountUnlinkedDonors(ctx, account.Email)
+	if err != nil {
+		return fmt.Errorf("failed to search donors: %w", err)
+	}
+	if count == 0 {
+		return apierrors.NewValidationError("no donations were made with the email of this account")
+	}
+
+	token, err := generateLinkToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate link token: %w", err)
+	}
+
+	now := time.Now()
+	err = s.repo.CreateLinkToken(ctx, LinkToken{
+		Token:     token,
+		UserID:    userID,
+		Email:     account.Email,
+		ExpiresAt: now.Add(linkTokenExpiration),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create link token: %w", err)
+	}
+
+	// The token is a credential, so it is only sent to the email and never logged
+	body := fmt.Sprintf("Para vincular a tu cuenta las donaciones hechas con este email, usá este código:\n\n%s\n\nEl código vence en %d horas. Si no lo pediste, ignorá este mensaje.\n",
+		token, int(linkTokenExpiration.Hours()))
+	if err := s.mailer.Send(ctx, account.Email, "Vinculá tus donaciones", body); err != nil {
+		return fmt.Errorf("failed to send link token: %w", err)
+	}
+	log.Printf("🔗 Donor link token sent to user %s", userID.String())
+
+	return nil
+}
+
+// ConfirmDonorLink links the donors with the email the token was sent to, and grants the donor role
+// when they have completed donations
+func (s *service) ConfirmDonorLink(ctx context.Context, userID uuid.UUID, req ConfirmLinkRequest) (LinkResult, error) {
+	tokenValue := strings.TrimSpace(req.Token)
+	if tokenValue == "" {
+		return LinkResult{}, apierrors.NewFieldValidationError("token", "token is required")
+	}
+
+	token, err := s.repo.GetLinkToken(ctx, tokenValue)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return LinkResult{}, apierrors.NewValidationError("invalid or expired link token")
+		}
+		return LinkResult{}, fmt.Errorf("failed to get link token: %w", err)
+	}
+	if token.UserID != userID || time.Now().After(token.ExpiresAt) {
+		return LinkResult{}, apierrors.NewValidationError("invalid or expired link token")
+	}
+
+	linked, err := s.repo.LinkDonors(ctx, userID, token.Email)
+	if err != nil {
+		return LinkResult{}, fmt.Errorf("failed to link donors: %w", err)
+	}
+	log.Printf("🔗 Linked %d donors with email %s to user %s", linked, token.Email, userID.String())
+
+	completed, err := s.repo.CountCompletedDonations(ctx, userID)
+	if err != nil {
+		return LinkResult{}, fmt.Errorf("failed to count donations: %w", err)
+	}
+	if completed > 0 {
+		if err := s.promoteToDonor(ctx, userID); err != nil {
+			fmt.Printf("Warning: failed to assign donor role to user %s: %v\n", userID.String(), err)
+		}
+	}
+
+	return LinkResult{LinkedDonors: int(linked)}, nil
+}
+
+func (s *service) GetDonationHistory(ctx context.Context, userID uuid.UUID, filter DonationFilter) (DonationHistory, error) {
+	if filter.Status != nil && !donation.IsValidStatus(*filter.Status) {
+		return DonationHistory{}, apierrors.NewFieldValidationError("status", fmt.Sprintf("invalid donation status: %s", *filter.Status))
+	}
+
+	donations, err := s.repo.ListDonations(ctx, userID, filter)
+	if err != nil {
+		return DonationHistory{}, fmt.Errorf("failed to list donations: %w", err)
+	}
+	totals, err := s.repo.GetYearTotals(ctx, userID, filter)
+	if err != nil {
+		return DonationHistory{}, fmt.Errorf("failed to get donation totals: %w", err)
+	}
+
+	return DonationHistory{Donations: donations, TotalsByYear: totals}, nil
+}
+
+func (s *service) ListSupportedCampaigns(ctx context.Context, userID uuid.UUID) ([]SupportedCampaign, error) {
+	campaigns, err := s.repo.ListSupportedCampaigns(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supported campaigns: %w", err)
+	}
+	return campaigns, nil
+}
+
+// AssignDonorRole grants the donor role to the user a donor is linked to, once a donation completes
+func (s *service) AssignDonorRole(ctx context.Context, donorID uuid.UUID) error {
+	userID, err := s.repo.GetDonorUserID(ctx, donorID)
+	if err != nil {
+		return fmt.Errorf("failed to get donor user: %w", err)
+	}
+	if userID == nil {
+		return nil
+	}
+	return s.promoteToDonor(ctx, *userID)
+}
+
+// promoteToDonor assigns the donor role to guests. Users with any other role keep it.
+func (s *service) promoteToDonor(ctx context.Context, userID uuid.UUID) error {
+	isGuest, err := s.roleService.HasRole(ctx, userID, rbac.RoleGuest)
+	if err != nil {
+		return err
+	}
+	if !isGuest {
+		return nil
+	}
+
+	if _, err := s.roleService.AssignUserRole(ctx, userID, rbac.RoleDonor); err != nil {
+		return err
+	}
+	log.Printf("🎖️  User %s is now a donor", userID.String())
+	return nil
+}
+
+func generateLinkToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,62 @@
+package dedup
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons a pair of donors is reported as a duplicate candidate
+const (
+	ReasonSameEmail   = "same_email"
+	ReasonSamePhone   = "same_phone"
+	ReasonSimilarName = "similar_name"
+)
+
+// CandidateDonor is a donor compared when looking for duplicates
+type CandidateDonor struct {
+	ID            uuid.UUID  `json:"id"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	Email         string     `json:"email,omitempty"`
+	Phone         string     `json:"phone,omitempty"`
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	DonationCount int        `json:"donation_count"`
+}
+
+// Candidate is a pair of donors that are probably the same person
+type Candidate struct {
+	Donor     CandidateDonor `json:"donor"`
+	Duplicate CandidateDonor `json:"duplicate"`
+	Score     float64        `json:"score"` // From 0 to 1, 1 when both have the same email
+	Reasons   []string       `json:"reasons"`
+}
+
+// Merge is the audit record of a donor merged into another one
+type Merge struct {
+	ID                 uuid.UUID  `json:"id"`
+	TargetDonorID      uuid.UUID  `json:"target_donor_id"`
+	SourceDonorID      uuid.UUID  `json:"source_donor_id"`
+	SourceFirstName    string     `json:"source_first_name"`
+	SourceLastName     string     `json:"source_last_name"`
+	SourceEmail        string     `json:"source_email"`
+	SourcePhone        string     `json:"source_phone,omitempty"`
+	DonationsMoved     int        `json:"donations_moved"`
+	SubscriptionsMoved int        `json:"subscriptions_moved"`
+	PledgesMoved       int        `json:"pledges_moved"`
+	Reason             string     `json:"reason"`
+	MergedBy           *uuid.UUID `json:"merged_by,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// MergeRequest represents an admin merging duplicate donors into the donor that is kept
+type MergeRequest struct {
+	SourceDonorIDs []uuid.UUID `json:"source_donor_ids" validate:"required"`
+	Reason         string      `json:"reason" validate:"required"`
+}
+
+// NormalizeResult summarizes a run of the normalization of stored donors
+type NormalizeResult struct {
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"` // Invalid phones or emails already used by another donor
+}
@@ -0,0 +1,168 @@
+package dedup
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests for donor deduplication
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new donor deduplication handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the donor deduplication routes. They are only available to admins.
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.GET("/donors/duplicates", h.FindDuplicates)
+	adminGroup.POST("/donors/normalize", h.NormalizeDonors)
+	adminGroup.POST("/donors/:id/merge", h.MergeDonors)
+	adminGroup.GET("/donors/:id/merges", h.ListMerges)
+}
+
+// @Summary Find duplicate donors
+// @Description Get the pairs of donors that are probably the same person, best scores first. The same email scores 1, the same phone 0.9 and a similar name alone up to 0.8.
+// @Tags donors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param min_score query number false "Lowest score returned (default 0.6)"
+// @Param limit query int false "Maximum number of pairs (default 100)"
+// @Success 200 {array} Candidate
+// @Failure 400 {object} errors.APIError
+// @Router /donors/duplicates [get]
+func (h *Handler) FindDuplicates(c echo.Context) error {
+	minScore := DefaultMinScore
+	if value := c.QueryParam("min_score"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid min_score")
+		}
+		minScore = parsed
+	}
+	limit := DefaultCandidateLimit
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid limit")
+		}
+		limit = parsed
+	}
+
+	candidates, err := h.service.FindDuplicates(c.Request().Context(), minScore, limit)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, candidates)
+}
+
+// @Summary Merge donors
+// @Description Merge duplicate donors into this donor. Their donations, subscriptions and matching pledges move to this donor, a merge is recorded for each of them and they are deleted.
+// @Tags donors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID of the donor that is kept"
+// @Param merge body MergeRequest true "Donors to merge"
+// @Success 200 {array} Merge
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /donors/{id}/merge [post]
+func (h *Handler) MergeDonors(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donor ID")
+	}
+
+	var req MergeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	merges, err := h.service.MergeDonors(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, merges)
+}
+
+// @Summary List donor merges
+// @Description Get the merges a donor took part in, as the donor kept or as a merged donor
+// @Tags donors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Donor ID"
+// @Success 200 {array} Merge
+// @Failure 400 {object} errors.APIError
+// @Router /donors/{id}/merges [get]
+func (h *Handler) ListMerges(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donor ID")
+	}
+
+	merges, err := h.service.ListMerges(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, merges)
+}
+
+// @Summary Normalize stored donors
+// @Description Rewrite the emails (lowercased) and phones (E.164) of the donors stored before they were normalized. Donors with an invalid phone or an email used by another donor are skipped.
+// @Tags donors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} NormalizeResult
+// @Failure 400 {object} errors.APIError
+// @Router /donors/normalize [post]
+func (h *Handler) NormalizeDonors(c echo.Context) error {
+	result, err := h.service.NormalizeDonors(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// errorStatus maps a donor deduplication service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,65 @@
+package dedup
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MergeModel represents the database table structure with GORM tags
+type MergeModel struct {
+	ID                 uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	TargetDonorID      uuid.UUID  `gorm:"column:target_donor_id;type:uuid;not null;index"`
+	SourceDonorID      uuid.UUID  `gorm:"column:source_donor_id;type:uuid;not null;index"`
+	SourceFirstName    string     `gorm:"column:source_first_name;not null"`
+	SourceLastName     string     `gorm:"column:source_last_name;not null"`
+	SourceEmail        string     `gorm:"column:source_email;not null"`
+	SourcePhone        string     `gorm:"column:source_phone"`
+	DonationsMoved     int        `gorm:"column:donations_moved;not null;default:0"`
+	SubscriptionsMoved int        `gorm:"column:subscriptions_moved;not null;default:0"`
+	PledgesMoved       int        `gorm:"column:pledges_moved;not null;default:0"`
+	Reason             string     `gorm:"column:reason;not null"`
+	MergedBy           *uuid.UUID `gorm:"column:merged_by;type:uuid"`
+	CreatedAt          time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (MergeModel) TableName() string {
+	return "donor_merges"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m MergeModel) ToEntity() Merge {
+	return Merge{
+		ID:                 m.ID,
+		TargetDonorID:      m.TargetDonorID,
+		SourceDonorID:      m.SourceDonorID,
+		SourceFirstName:    m.SourceFirstName,
+		SourceLastName:     m.SourceLastName,
+		SourceEmail:        m.SourceEmail,
+		SourcePhone:        m.SourcePhone,
+		DonationsMoved:     m.DonationsMoved,
+		SubscriptionsMoved: m.SubscriptionsMoved,
+		PledgesMoved:       m.PledgesMoved,
+		Reason:             m.Reason,
+		MergedBy:           m.MergedBy,
+		CreatedAt:          m.CreatedAt,
+	}
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *MergeModel) FromEntity(entity Merge) {
+	m.ID = entity.ID
+	m.TargetDonorID = entity.TargetDonorID
+	m.SourceDonorID = entity.SourceDonorID
+	m.SourceFirstName = entity.SourceFirstName
+	m.SourceLastName = entity.SourceLastName
+	m.SourceEmail = entity.SourceEmail
+	m.SourcePhone = entity.SourcePhone
+	m.DonationsMoved = entity.DonationsMoved
+	m.SubscriptionsMoved = entity.SubscriptionsMoved
+	m.PledgesMoved = entity.PledgesMoved
+	m.Reason = entity.Reason
+	m.MergedBy = entity.MergedBy
+	m.CreatedAt = entity.CreatedAt
+}
@@ -0,0 +1,212 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrLinkedToOtherUser is returned when the merged donors are linked to different user accounts
+	ErrLinkedToOtherUser = errors.New("donors are linked to different user accounts")
+	// ErrEmailInUse is returned when a normalized email is already used by another donor
+	ErrEmailInUse = errors.New("email is already used by another donor")
+)
+
+type Repository interface {
+	ListCandidateDonors(ctx context.Context) ([]CandidateDonor, error)
+	MergeDonors(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, reason string, mergedBy *uuid.UUID) ([]Merge, error)
+	ListMerges(ctx context.Context, donorID uuid.UUID) ([]Merge, error)
+	UpdateContact(ctx context.Context, donorID uuid.UUID, email, phone string) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// ListCandidateDonors returns every donor with its number of donations. Erased donors have no
+// data left to compare and are left out.
+func (r *repository) ListCandidateDonors(ctx context.Context) ([]CandidateDonor, error) {
+	var donors []CandidateDonor
+	err := r.db.WithContext(ctx).
+		Table("donors dn").
+		Select("dn.id, dn.first_name, dn.last_name, COALESCE(dn.email, '') AS email, COALESCE(dn.phone, '') AS phone, dn.user_id, COUNT(d.id) AS donation_count").
+		Joins("LEFT JOIN donations d ON d.donor_id = dn.id").
+		Where("dn.erased_at IS NULL").
+		Group("dn.id").
+		Order("dn.created_at ASC").
+		Scan(&donors).Error
+	if err != nil {
+		return nil, err
+	}
+	return donors, nil
+}
+
+// MergeDonors moves the donations, subscriptions and matching pledges of the source donors to the
+// target donor, records a merge for each source and deletes the sources. The target keeps its own
+// data and takes the email, phone and user account of a source when it has none.
+func (r *repository) MergeDonors(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, reason string, mergedBy *uuid.UUID) ([]Merge, error) {
+	var merges []Merge
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var donors []CandidateDonor
+		err := tx.Raw(`SELECT id, first_name, last_name, COALESCE(email, '') AS email, COALESCE(phone, '') AS phone, user_id FROM donors WHERE id IN ? AND erased_at IS NULL FOR UPDATE`,
+			append([]uuid.UUID{targetID}, sourceIDs...)).
+			Scan(&donors).Error
+		if err != nil {
+			return err
+		}
+		if len(donors) != len(sourceIDs)+1 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var target CandidateDonor
+		for _, d := range donors {
+			if d.ID == targetID {
+				target = d
+			}
+		}
+
+		updates := map[string]interface{}{"updated_at": time.Now()}
+		userID := target.UserID
+		email, phone := target.Email, target.Phone
+		for _, source := range donors {
+			if source.ID == targetID {
+				continue
+			}
+			if source.UserID != nil {
+				if userID != nil && *userID != *source.UserID {
+					return ErrLinkedToOtherUser
+				}
+				userID = source.UserID
+			}
+			if email == "" && source.Email != "" {
+				email = source.Email
+			}
+			if phone == "" && source.Phone != "" {
+				phone = source.Phone
+			}
+
+			merge, err := mergeDonor(tx, targetID, source)
+			if err != nil {
+				return err
+			}
+			merge.Reason = reason
+			merge.MergedBy = mergedBy
+
+			var model MergeModel
+			model.FromEntity(merge)
+			if err := tx.Create(&model).Error; err != nil {
+				return err
+			}
+			merges = append(merges, model.ToEntity())
+		}
+
+		if userID != nil && target.UserID == nil {
+			updates["user_id"] = *userID
+		}
+		// Sources are deleted by now, so their email is free
+		if email != target.Email {
+			updates["email"] = nullableEmail(email)
+		}
+		if phone != target.Phone {
+			updates["phone"] = phone
+		}
+		return tx.Table("donors").Where("id = ?", targetID).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merges, nil
+}
+
+// mergeDonor re-points everything a source donor owns to the target and deletes the source
+func mergeDonor(tx *gorm.DB, targetID uuid.UUID, source CandidateDonor) (Merge, error) {
+	merge := Merge{
+		ID:              uuid.New(),
+		TargetDonorID:   targetID,
+		SourceDonorID:   source.ID,
+		SourceFirstName: source.FirstName,
+		SourceLastName:  source.LastName,
+		SourceEmail:     source.Email,
+		SourcePhone:     source.Phone,
+		CreatedAt:       time.Now(),
+	}
+
+	result := tx.Table("donations").Where("donor_id = ?", source.ID).Update("donor_id", targetID)
+	if result.Error != nil {
+		return Merge{}, result.Error
+	}
+	merge.DonationsMoved = int(result.RowsAffected)
+
+	result = tx.Table("donation_subscriptions").Where("donor_id = ?", source.ID).Update("donor_id", targetID)
+	if result.Error != nil {
+		return Merge{}, result.Error
+	}
+	merge.SubscriptionsMoved = int(result.RowsAffected)
+
+	result = tx.Table("matching_pledges").Where("sponsor_donor_id = ?", source.ID).Update("sponsor_donor_id", targetID)
+	if result.Error != nil {
+		return Merge{}, result.Error
+	}
+	merge.PledgesMoved = int(result.RowsAffected)
+
+	if err := tx.Exec("DELETE FROM donors WHERE id = ?", source.ID).Error; err != nil {
+		return Merge{}, err
+	}
+	return merge, nil
+}
+
+// ListMerges returns the merges a donor took part in, most recent first
+func (r *repository) ListMerges(ctx context.Context, donorID uuid.UUID) ([]Merge, error) {
+	var models []MergeModel
+	err := r.db.WithContext(ctx).
+		Where("target_donor_id = ? OR source_donor_id = ?", donorID, donorID).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	merges := make([]Merge, len(models))
+	for i, model := range models {
+		merges[i] = model.ToEntity()
+	}
+	return merges, nil
+}
+
+// UpdateContact stores the email and phone of a donor unless another donor already uses the email
+func (r *repository) UpdateContact(ctx context.Context, donorID uuid.UUID, email, phone string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if email != "" {
+			var count int64
+			err := tx.Table("donors").Where("id <> ? AND LOWER(email) = ?", donorID, email).Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrEmailInUse
+			}
+		}
+
+		return tx.Table("donors").Where("id = ?", donorID).Updates(map[string]interface{}{
+			"email":      nullableEmail(email),
+			"phone":      phone,
+			"updated_at": time.Now(),
+		}).Error
+	})
+}
+
+// nullableEmail returns nil for a missing email, which is stored as NULL
+func nullableEmail(email string) *string {
+	if email == "" {
+		return nil
+	}
+	return &email
+}
@@ -0,0 +1,180 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"dona_tutti_api/donor"
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultMinScore is the lowest score of the duplicate candidates returned by default
+	DefaultMinScore = 0.6
+	// DefaultCandidateLimit is the number of duplicate candidates returned by default
+	DefaultCandidateLimit = 100
+	// MaxMergeSources is the maximum number of donors merged at once
+	MaxMergeSources = 20
+)
+
+type Service interface {
+	FindDuplicates(ctx context.Context, minScore float64, limit int) ([]Candidate, error)
+	MergeDonors(ctx context.Context, targetID uuid.UUID, req MergeRequest, mergedBy *uuid.UUID) ([]Merge, error)
+	ListMerges(ctx context.Context, donorID uuid.UUID) ([]Merge, error)
+	NormalizeDonors(ctx context.Context) (NormalizeResult, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// FindDuplicates returns the pairs of donors that are probably the same person, best scores first.
+// Donors are only compared with the donors sharing their email, their phone or the start of their name.
+func (s *service) FindDuplicates(ctx context.Context, minScore float64, limit int) ([]Candidate, error) {
+	if minScore <= 0 || minScore > 1 {
+		return nil, apierrors.NewFieldValidationError("min_score", "min_score must be greater than 0 and at most 1")
+	}
+	if limit <= 0 {
+		return nil, apierrors.NewFieldValidationError("limit", "limit must be greater than 0")
+	}
+
+	donors, err := s.repo.ListCandidateDonors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list donors: %w", err)
+	}
+
+	blocks := make(map[string][]int)
+	for i, d := range donors {
+		for _, key := range blockingKeys(d) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	compared := make(map[[2]int]bool)
+	candidates := []Candidate{}
+	for _, block := range blocks {
+		for a := 0; a < len(block); a++ {
+			for b := a + 1; b < len(block); b++ {
+				pair := [2]int{block[a], block[b]}
+				if compared[pair] {
+					continue
+				}
+				compared[pair] = true
+
+				pairScore, reasons := score(donors[pair[0]], donors[pair[1]])
+				if pairScore < minScore {
+					continue
+				}
+				candidates = append(candidates, Candidate{
+					Donor:     donors[pair[0]],
+					Duplicate: donors[pair[1]],
+					Score:     pairScore,
+					Reasons:   reasons,
+				})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Donor.ID.String() < candidates[j].Donor.ID.String()
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// MergeDonors merges duplicate donors into the donor that is kept, recording a merge for each of them
+func (s *service) MergeDonors(ctx context.Context, targetID uuid.UUID, req MergeRequest, mergedBy *uuid.UUID) ([]Merge, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, apierrors.NewFieldValidationError("reason", "reason is required")
+	}
+	if len(req.SourceDonorIDs) == 0 {
+		return nil, apierrors.NewFieldValidationError("source_donor_ids", "at least one donor to merge is required")
+	}
+	if len(req.SourceDonorIDs) > MaxMergeSources {
+		return nil, apierrors.NewFieldValidationError("source_donor_ids", fmt.Sprintf("at most %d donors can be merged at once", MaxMergeSources))
+	}
+	seen := make(map[uuid.UUID]bool, len(req.SourceDonorIDs))
+	for _, sourceID := range req.SourceDonorIDs {
+		if sourceID == targetID {
+			return nil, apierrors.NewFieldValidationError("source_donor_ids", "a donor cannot be merged into itself")
+		}
+		if seen[sourceID] {
+			return nil, apierrors.NewFieldValidationError("source_donor_ids", fmt.Sprintf("donor %s is repeated", sourceID.String()))
+		}
+		seen[sourceID] = true
+	}
+
+	merges, err := s.repo.MergeDonors(ctx, targetID, req.SourceDonorIDs, reason, mergedBy)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewNotFoundError("donor not found")
+		}
+		if errors.Is(err, ErrLinkedToOtherUser) {
+			return nil, apierrors.NewValidationError(err.Error())
+		}
+		return nil, fmt.Errorf("failed to merge donors: %w", err)
+	}
+
+	log.Printf("🔀 Merged %d donors into donor %s", len(merges), targetID.String())
+	return merges, nil
+}
+
+func (s *service) ListMerges(ctx context.Context, donorID uuid.UUID) ([]Merge, error) {
+	merges, err := s.repo.ListMerges(ctx, donorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list donor merges: %w", err)
+	}
+	return merges, nil
+}
+
+// NormalizeDonors rewrites the email and phone of the donors stored before they were normalized on
+// write. Donors with an invalid phone, or whose email is used by another donor, are skipped and
+// left to be fixed or merged.
+func (s *service) NormalizeDonors(ctx context.Context) (NormalizeResult, error) {
+	donors, err := s.repo.ListCandidateDonors(ctx)
+	if err != nil {
+		return NormalizeResult{}, fmt.Errorf("failed to list donors: %w", err)
+	}
+
+	var result NormalizeResult
+	for _, d := range donors {
+		email := donor.NormalizeEmail(d.Email)
+		phone, err := donor.NormalizePhone(d.Phone)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		if email == d.Email && phone == d.Phone {
+			continue
+		}
+
+		if err := s.repo.UpdateContact(ctx, d.ID, email, phone); err != nil {
+			if errors.Is(err, ErrEmailInUse) {
+				result.Skipped++
+				continue
+			}
+			return result, fmt.Errorf("failed to normalize donor %s: %w", d.ID.String(), err)
+		}
+		result.Updated++
+	}
+
+	log.Printf("🧹 Normalized %d donors, skipped %d", result.Updated, result.Skipped)
+	return result, nil
+}
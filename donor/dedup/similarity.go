@@ -0,0 +1,115 @@
+package dedup
+
+import (
+	"strings"
+
+	"dona_tutti_api/donor"
+)
+
+// SimilarNameThreshold is the name similarity from which two names are considered the same
+const SimilarNameThreshold = 0.85
+
+var accents = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u", "ç", "c",
+)
+
+// nameKey returns a full name lowercased, without accents and with single spaces
+func nameKey(firstName, lastName string) string {
+	name := accents.Replace(strings.ToLower(firstName + " " + lastName))
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// normalizedPhone returns a phone in E.164 format, or the phone as stored if it is invalid
+func normalizedPhone(phone string) string {
+	normalized, err := donor.NormalizePhone(phone)
+	if err != nil {
+		return phone
+	}
+	return normalized
+}
+
+// score compares two donors. The same email scores 1 and the same phone 0.9; a similar name alone
+// scores up to 0.8 and adds 0.1 to a contact match.
+func score(a, b CandidateDonor) (float64, []string) {
+	var contact float64
+	var reasons []string
+
+	if email := donor.NormalizeEmail(a.Email); email != "" && email == donor.NormalizeEmail(b.Email) {
+		contact = 1
+		reasons = append(reasons, ReasonSameEmail)
+	}
+	if phone := normalizedPhone(a.Phone); phone != "" && phone == normalizedPhone(b.Phone) {
+		if contact < 0.9 {
+			contact = 0.9
+		}
+		reasons = append(reasons, ReasonSamePhone)
+	}
+
+	similarity := nameSimilarity(nameKey(a.FirstName, a.LastName), nameKey(b.FirstName, b.LastName))
+	if similarity < SimilarNameThreshold {
+		return contact, reasons
+	}
+	reasons = append(reasons, ReasonSimilarName)
+
+	if contact == 0 {
+		return similarity * 0.8, reasons
+	}
+	if contact+0.1 > 1 {
+		return 1, reasons
+	}
+	return contact + 0.1, reasons
+}
+
+// nameSimilarity returns 1 minus the edit distance between two names relative to the longest one
+func nameSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// blockingKeys returns the keys of the groups a donor is compared within, so donors are not compared
+// with every other donor: its email, its phone and the start of its name
+func blockingKeys(d CandidateDonor) []string {
+	var keys []string
+	if email := donor.NormalizeEmail(d.Email); email != "" {
+		keys = append(keys, "email:"+email)
+	}
+	if phone := normalizedPhone(d.Phone); phone != "" {
+		keys = append(keys, "phone:"+phone)
+	}
+
+	first := []rune(nameKey(d.FirstName, ""))
+	last := []rune(nameKey(d.LastName, ""))
+	if len(first) > 0 && len(last) >= 3 {
+		keys = append(keys, "name:"+string(first[0])+string(last[:3]))
+	}
+	return keys
+}
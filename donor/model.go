@@ -12,7 +12,7 @@ type DonorModel struct {
 	LastName   string    `gorm:"column:last_name;not null"`
 	IsVerified bool      `gorm:"column:is_verified;default:false"`
 	Phone      string    `gorm:"column:phone"`
-	Email      string    `gorm:"column:email;unique;not null"`
+	Email      *string   `gorm:"column:email;unique"` // NULL when the donor gave no email
 	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt  time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
@@ -22,14 +22,17 @@ func (DonorModel) TableName() string {
 }
 
 func (m DonorModel) ToEntity() Donor {
-	return Donor{
+	donor := Donor{
 		ID:         m.ID,
 		FirstName:  m.FirstName,
 		LastName:   m.LastName,
 		IsVerified: m.IsVerified,
 		Phone:      m.Phone,
-		Email:      m.Email,
 	}
+	if m.Email != nil {
+		donor.Email = *m.Email
+	}
+	return donor
 }
 
 func (m *DonorModel) FromEntity(entity Donor) {
@@ -38,5 +41,9 @@ func (m *DonorModel) FromEntity(entity Donor) {
 	m.LastName = entity.LastName
 	m.IsVerified = entity.IsVerified
 	m.Phone = entity.Phone
-	m.Email = entity.Email
+	m.Email = nil
+	if entity.Email != "" {
+		email := entity.Email
+		m.Email = &email
+	}
 }
@@ -0,0 +1,130 @@
+package donor
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultPhoneCountryCode is the country calling code of phones written without one (Argentina)
+const DefaultPhoneCountryCode = argentinaCountryCode
+
+const argentinaCountryCode = "54"
+
+// argentinaThreeDigitAreaCodes are the area codes of three digits. Buenos Aires (11) is the only one
+// of two digits and all the others have four.
+var argentinaThreeDigitAreaCodes = map[string]bool{
+	"220": true, "221": true, "223": true, "230": true, "236": true, "237": true, "249": true,
+	"260": true, "261": true, "263": true, "264": true, "266": true, "280": true, "291": true,
+	"294": true, "297": true, "299": true, "336": true, "341": true, "342": true, "343": true,
+	"345": true, "348": true, "351": true, "353": true, "358": true, "362": true, "364": true,
+	"370": true, "376": true, "379": true, "380": true, "381": true, "383": true, "385": true,
+	"387": true, "388": true,
+}
+
+// ErrInvalidPhone is returned when a phone cannot be written in E.164 format
+var ErrInvalidPhone = errors.New("phone must have between 8 and 15 digits including the country code, and Argentine phones 10 digits after it")
+
+// NormalizeEmail trims and lowercases an email so the same address is always stored the same way
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone writes a phone in E.164 format (+<country code><number>). Spaces, dashes, dots and
+// parentheses are ignored; an international 00 prefix is read as +, and phones without a country
+// code get DefaultPhoneCountryCode after dropping their leading trunk zeros. Argentine phones follow
+// the national rules, so that local and international forms of a number are stored the same way.
+func NormalizePhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return "", nil
+	}
+
+	international := strings.HasPrefix(phone, "+")
+	if international {
+		phone = phone[1:]
+	}
+
+	var digits strings.Builder
+	for _, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		number = number[2:]
+		international = true
+	}
+	// No Argentine area code starts with 5, so a local phone starting with 54 already has the country code
+	if !international && strings.HasPrefix(number, argentinaCountryCode) {
+		international = true
+	}
+	if !international {
+		number = DefaultPhoneCountryCode + strings.TrimLeft(number, "0")
+	}
+	if strings.HasPrefix(number, argentinaCountryCode) {
+		return normalizeArgentinePhone(strings.TrimPrefix(number, argentinaCountryCode))
+	}
+	if len(number) < 8 || len(number) > 15 || strings.HasPrefix(number, "0") {
+		return "", ErrInvalidPhone
+	}
+
+	return "+" + number, nil
+}
+
+// normalizeArgentinePhone writes an Argentine phone, given without its country code, in E.164
+// format. The national number is the area code and the subscriber number, 10 digits in total.
+// Mobiles are dialed locally with 15 after the area code and internationally with 9 before it:
+// both forms become +549<national number>.
+func normalizeArgentinePhone(number string) (string, error) {
+	mobile := false
+	if strings.HasPrefix(number, "9") {
+		number = number[1:]
+		mobile = true
+	}
+	number = strings.TrimLeft(number, "0")
+
+	if len(number) == 12 {
+		areaCodeLength := argentineAreaCodeLength(number)
+		if number[areaCodeLength:areaCodeLength+2] == "15" {
+			number = number[:areaCodeLength] + number[areaCodeLength+2:]
+			mobile = true
+		}
+	}
+	if len(number) != 10 {
+		return "", ErrInvalidPhone
+	}
+
+	if mobile {
+		return "+" + argentinaCountryCode + "9" + number, nil
+	}
+	return "+" + argentinaCountryCode + number, nil
+}
+
+// argentineAreaCodeLength returns the number of digits of the area code a national number starts with
+func argentineAreaCodeLength(number string) int {
+	switch {
+	case strings.HasPrefix(number, "11"):
+		return 2
+	case argentinaThreeDigitAreaCodes[number[:3]]:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// Normalize returns the donor with its email and phone in their normalized form
+func (d Donor) Normalize() (Donor, error) {
+	d.Email = NormalizeEmail(d.Email)
+	phone, err := NormalizePhone(d.Phone)
+	if err != nil {
+		return Donor{}, err
+	}
+	d.Phone = phone
+	return d, nil
+}
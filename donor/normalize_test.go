@@ -0,0 +1,79 @@
+package donor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+		err   error
+	}{
+		{"", "", nil},
+		{"   ", "", nil},
+
+		// Buenos Aires mobile, local and international forms
+		{"011 15-1234-5678", "+5491112345678", nil},
+		{"11 15 1234 5678", "+5491112345678", nil},
+		{"(011) 15 1234-5678", "+5491112345678", nil},
+		{"+54 9 11 1234-5678", "+5491112345678", nil},
+		{"+5491112345678", "+5491112345678", nil},
+		{"0054 9 11 1234 5678", "+5491112345678", nil},
+		{"5491112345678", "+5491112345678", nil},
+		{"+54 11 15 1234 5678", "+5491112345678", nil},
+		{"+54 9 11 15 1234 5678", "+5491112345678", nil},
+
+		// Mobiles with three and four digit area codes
+		{"0351 15 123 4567", "+5493511234567", nil},
+		{"+54 9 351 123-4567", "+5493511234567", nil},
+		{"02954 15 12-3456", "+5492954123456", nil},
+		{"+54 9 2954 12-3456", "+5492954123456", nil},
+
+		// Landlines
+		{"011 4321-5678", "+541143215678", nil},
+		{"11 4321 5678", "+541143215678", nil},
+		{"+54 11 4321-5678", "+541143215678", nil},
+		{"0351 423-4567", "+543514234567", nil},
+		{"+54 351 423 4567", "+543514234567", nil},
+
+		// Other countries
+		{"+1 (415) 555-0100", "+14155550100", nil},
+		{"0034 612 345 678", "+34612345678", nil},
+
+		{"1234-5678", "", ErrInvalidPhone},
+		{"+54 11 1234", "", ErrInvalidPhone},
+		{"+1 234", "", ErrInvalidPhone},
+		{"+0 1234 5678", "", ErrInvalidPhone},
+		{"11 1234 5678 ext 2", "", ErrInvalidPhone},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.phone)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("NormalizePhone(%q) error = %v, want %v", tt.phone, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{"", ""},
+		{"ana@example.com", "ana@example.com"},
+		{"  Ana.Perez@Example.COM ", "ana.perez@example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeEmail(tt.email); got != tt.want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+		}
+	}
+}
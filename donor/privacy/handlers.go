@@ -0,0 +1,155 @@
+package privacy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	apierrors "dona_tutti_api/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests for donor data exports and erasures
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new donor privacy handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers the donor privacy routes. They are only available to admins.
+func (h *Handler) RegisterRoutes(g *echo.Group, authMiddleware echo.MiddlewareFunc, adminMiddleware echo.MiddlewareFunc) {
+	authGroup := g.Group("", authMiddleware)
+	adminGroup := authGroup.Group("", adminMiddleware)
+	adminGroup.GET("/donors/:id/export", h.ExportDonor)
+	adminGroup.POST("/donors/:id/erase", h.EraseDonor)
+	adminGroup.GET("/donors/:id/privacy-requests", h.ListRequests)
+}
+
+// @Summary Export donor data
+// @Description Get everything held about a donor: personal data, donations, messages, receipts, recurring donations and merged duplicates. The zip format bundles the data as export.json with the receipt PDFs. Every export is logged.
+// @Tags donors
+// @Accept json
+// @Produce json,application/zip
+// @Security BearerAuth
+// @Param id path string true "Donor ID"
+// @Param format query string false "Export format" Enums(json, zip) default(json)
+// @Success 200 {object} Export
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /donors/{id}/export [get]
+func (h *Handler) ExportDonor(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donor ID")
+	}
+
+	format := ExportFormatJSON
+	if value := c.QueryParam("format"); value != "" {
+		format = ExportFormat(value)
+	}
+	if !format.IsValid() {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid format: must be json or zip")
+	}
+
+	if format == ExportFormatZIP {
+		data, err := h.service.ExportDonorZIP(c.Request().Context(), id, getUserID(c))
+		if err != nil {
+			return echo.NewHTTPError(errorStatus(err), err.Error())
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="donor-%s.zip"`, id.String()))
+		return c.Blob(http.StatusOK, "application/zip", data)
+	}
+
+	export, err := h.service.ExportDonor(c.Request().Context(), id, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, export)
+}
+
+// @Summary Erase donor data
+// @Description Anonymize the personal data of a donor on their request. The donor keeps its donations and their amounts for campaign accounting and closure reports; its name, contact data, messages, receipts and tax certificate PDFs are removed and its recurring donations are cancelled. The erasure is logged.
+// @Tags donors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Donor ID"
+// @Param erasure body ErasureRequest true "Erasure reason"
+// @Success 200 {object} ErasureResult
+// @Failure 400 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /donors/{id}/erase [post]
+func (h *Handler) EraseDonor(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donor ID")
+	}
+
+	var req ErasureRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+	}
+
+	result, err := h.service.EraseDonor(c.Request().Context(), id, req, getUserID(c))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// @Summary List donor privacy requests
+// @Description Get the data exports and erasures done for a donor, with who did them and when
+// @Tags donors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Donor ID"
+// @Success 200 {array} Request
+// @Failure 400 {object} errors.APIError
+// @Router /donors/{id}/privacy-requests [get]
+func (h *Handler) ListRequests(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid donor ID")
+	}
+
+	requests, err := h.service.ListRequests(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, requests)
+}
+
+// errorStatus maps a donor privacy service error to an HTTP status code
+func errorStatus(err error) int {
+	var validationErr apierrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	var notFoundErr apierrors.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func getUserID(c echo.Context) *uuid.UUID {
+	userIDValue, ok := c.Get("user_id").(string)
+	if !ok {
+		return nil
+	}
+
+	userID, err := uuid.Parse(userIDValue)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
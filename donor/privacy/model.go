@@ -0,0 +1,55 @@
+package privacy
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestModel represents the database table structure with GORM tags
+type RequestModel struct {
+	ID          uuid.UUID  `gorm:"primaryKey;column:id;type:uuid;default:uuid_generate_v4()"`
+	DonorID     uuid.UUID  `gorm:"column:donor_id;type:uuid;not null;index"`
+	Type        string     `gorm:"column:type;type:varchar(20);not null"`
+	Format      *string    `gorm:"column:format;type:varchar(10)"`
+	Reason      *string    `gorm:"column:reason"`
+	PerformedBy *uuid.UUID `gorm:"column:performed_by;type:uuid"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (RequestModel) TableName() string {
+	return "donor_privacy_requests"
+}
+
+// ToEntity converts a database model to a domain entity
+func (m RequestModel) ToEntity() Request {
+	request := Request{
+		ID:          m.ID,
+		DonorID:     m.DonorID,
+		Type:        RequestType(m.Type),
+		Reason:      m.Reason,
+		PerformedBy: m.PerformedBy,
+		CreatedAt:   m.CreatedAt,
+	}
+	if m.Format != nil {
+		format := ExportFormat(*m.Format)
+		request.Format = &format
+	}
+	return request
+}
+
+// FromEntity converts a domain entity to a database model
+func (m *RequestModel) FromEntity(entity Request) {
+	m.ID = entity.ID
+	m.DonorID = entity.DonorID
+	m.Type = string(entity.Type)
+	m.Format = nil
+	if entity.Format != nil {
+		format := string(*entity.Format)
+		m.Format = &format
+	}
+	m.Reason = entity.Reason
+	m.PerformedBy = entity.PerformedBy
+	m.CreatedAt = entity.CreatedAt
+}
@@ -0,0 +1,132 @@
+package privacy
+
+import (
+	"time"
+
+	"dona_tutti_api/donation"
+	"dona_tutti_api/donor/dedup"
+	"dona_tutti_api/money"
+
+	"github.com/google/uuid"
+)
+
+// RequestType is what a donor asked for about their data
+type RequestType string
+
+const (
+	RequestTypeExport  RequestType = "export"
+	RequestTypeErasure RequestType = "erasure"
+)
+
+// ExportFormat is the format a donor data export is delivered in
+type ExportFormat string
+
+const (
+	ExportFormatJSON ExportFormat = "json"
+	ExportFormatZIP  ExportFormat = "zip" // The JSON export with the receipt PDFs
+)
+
+// IsValid checks if the export format is supported
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatJSON || f == ExportFormatZIP
+}
+
+// ErasedFirstName and ErasedLastName replace the name of an erased donor
+const (
+	ErasedFirstName = "Erased"
+	ErasedLastName  = "Donor"
+)
+
+// Request is the log entry of an export or erasure done for a donor
+type Request struct {
+	ID          uuid.UUID     `json:"id"`
+	DonorID     uuid.UUID     `json:"donor_id"`
+	Type        RequestType   `json:"type"`
+	Format      *ExportFormat `json:"format,omitempty"` // Set on exports
+	Reason      *string       `json:"reason,omitempty"`
+	PerformedBy *uuid.UUID    `json:"performed_by,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// DonorData is the personal data held about a donor
+type DonorData struct {
+	ID         uuid.UUID  `json:"id"`
+	FirstName  string     `json:"first_name"`
+	LastName   string     `json:"last_name"`
+	Email      string     `json:"email,omitempty"`
+	Phone      string     `json:"phone,omitempty"`
+	IsVerified bool       `json:"is_verified"`
+	UserID     *uuid.UUID `json:"user_id,omitempty"`
+	ErasedAt   *time.Time `json:"erased_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// DonationData is a donation made by the donor
+type DonationData struct {
+	ID                uuid.UUID               `json:"id"`
+	CampaignID        uuid.UUID               `json:"campaign_id"`
+	CampaignTitle     string                  `json:"campaign_title"`
+	Amount            money.Amount            `json:"amount"` // In the campaign currency
+	Currency          string                  `json:"currency"`
+	OriginalAmount    money.Amount            `json:"original_amount"` // What the donor gave, in OriginalCurrency
+	OriginalCurrency  string                  `json:"original_currency"`
+	RefundedAmount    money.Amount            `json:"refunded_amount"`
+	Date              time.Time               `json:"date"`
+	IsAnonymous       bool                    `json:"is_anonymous"`
+	PaymentMethodName string                  `json:"payment_method_name"`
+	Status            donation.DonationStatus `json:"status"`
+	ReceiptURL        *string                 `json:"receipt_url,omitempty"`
+	Message           *string                 `json:"-"` // Exported in Export.Messages
+}
+
+// Message is a message the donor left with a donation
+type Message struct {
+	DonationID    uuid.UUID `json:"donation_id"`
+	CampaignTitle string    `json:"campaign_title"`
+	Message       string    `json:"message"`
+	Date          time.Time `json:"date"`
+}
+
+// Receipt is the receipt of a donation. File is its path inside a ZIP export.
+type Receipt struct {
+	DonationID uuid.UUID `json:"donation_id"`
+	URL        string    `json:"url"`
+	File       string    `json:"file,omitempty"`
+}
+
+// SubscriptionData is a recurring donation of the donor
+type SubscriptionData struct {
+	ID            uuid.UUID    `json:"id"`
+	CampaignID    uuid.UUID    `json:"campaign_id"`
+	CampaignTitle string       `json:"campaign_title"`
+	Amount        money.Amount `json:"amount"`
+	Currency      string       `json:"currency"`
+	Interval      string       `json:"interval"`
+	Status        string       `json:"status"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// Export is everything held about a donor
+type Export struct {
+	GeneratedAt   time.Time          `json:"generated_at"`
+	Donor         DonorData          `json:"donor"`
+	Donations     []DonationData     `json:"donations"`
+	Messages      []Message          `json:"messages"`
+	Receipts      []Receipt          `json:"receipts"`
+	Subscriptions []SubscriptionData `json:"subscriptions"`
+	Merges        []dedup.Merge      `json:"merges"` // Duplicate records merged into the donor, with their data
+}
+
+// ErasureRequest represents an admin erasing the personal data of a donor
+type ErasureRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// ErasureResult reports what an erasure cleared
+type ErasureResult struct {
+	Request                Request `json:"request"`
+	DonationsAnonymized    int     `json:"donations_anonymized"`
+	SubscriptionsCancelled int     `json:"subscriptions_cancelled"`
+	ReceiptsDeleted        int     `json:"receipts_deleted"` // Including tax certificate PDFs
+}
@@ -0,0 +1,227 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrAlreadyErased is returned when erasing a donor whose data was already erased
+var ErrAlreadyErased = errors.New("donor data was already erased")
+
+type Repository interface {
+	GetDonor(ctx context.Context, donorID uuid.UUID) (DonorData, error)
+	ListDonations(ctx context.Context, donorID uuid.UUID) ([]DonationData, error)
+	ListSubscriptions(ctx context.Context, donorID uuid.UUID) ([]SubscriptionData, error)
+	CreateRequest(ctx context.Context, request Request) error
+	ListRequests(ctx context.Context, donorID uuid.UUID) ([]Request, error)
+	EraseDonor(ctx context.Context, request Request) (ErasureResult, []string, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetDonor(ctx context.Context, donorID uuid.UUID) (DonorData, error) {
+	var donors []DonorData
+	err := r.db.WithContext(ctx).
+		Table("donors").
+		Select(`id, first_name, last_name, COALESCE(email, '') AS email, COALESCE(phone, '') AS phone,
+			COALESCE(is_verified, false) AS is_verified, user_id, erased_at, created_at, updated_at`).
+		Where("id = ?", donorID).
+		Scan(&donors).Error
+	if err != nil {
+		return DonorData{}, err
+	}
+	if len(donors) == 0 {
+		return DonorData{}, gorm.ErrRecordNotFound
+	}
+	return donors[0], nil
+}
+
+// ListDonations returns the donations of a donor, most recent first
+func (r *repository) ListDonations(ctx context.Context, donorID uuid.UUID) ([]DonationData, error) {
+	var donations []DonationData
+	err := r.db.WithContext(ctx).
+		Table("donations d").
+		Select(`d.id, d.campaign_id, c.title AS campaign_title, d.amount, d.currency,
+			d.original_amount, d.original_currency, d.refunded_amount, d.date,
+			COALESCE(d.is_anonymous, false) AS is_anonymous, COALESCE(pm.name, '') AS payment_method_name,
+			d.status, d.receipt_url, d.message`).
+		Joins("JOIN campaigns c ON c.id = d.campaign_id").
+		Joins("LEFT JOIN payment_methods pm ON pm.id = d.payment_method_id").
+		Where("d.donor_id = ?", donorID).
+		Order("d.date DESC").
+		Scan(&donations).Error
+	if err != nil {
+		return nil, err
+	}
+	return donations, nil
+}
+
+// ListSubscriptions returns the recurring donations of a donor, most recent first
+func (r *repository) ListSubscriptions(ctx context.Context, donorID uuid.UUID) ([]SubscriptionData, error) {
+	var subscriptions []SubscriptionData
+	err := r.db.WithContext(ctx).
+		Table("donation_subscriptions s").
+		Select(`s.id, s.campaign_id, c.title AS campaign_title, s.amount, s.currency,
+			s.interval, s.status, s.created_at`).
+		Joins("JOIN campaigns c ON c.id = s.campaign_id").
+		Where("s.donor_id = ?", donorID).
+		Order("s.created_at DESC").
+		Scan(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *repository) CreateRequest(ctx context.Context, request Request) error {
+	var model RequestModel
+	model.FromEntity(request)
+	return r.db.WithContext(ctx).Create(&model).Error
+}
+
+// ListRequests returns the exports and erasures done for a donor, most recent first
+func (r *repository) ListRequests(ctx context.Context, donorID uuid.UUID) ([]Request, error) {
+	var models []RequestModel
+	err := r.db.WithContext(ctx).
+		Where("donor_id = ?", donorID).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]Request, len(models))
+	for i, model := range models {
+		requests[i] = model.ToEntity()
+	}
+	return requests, nil
+}
+
+// EraseDonor clears the personal data of a donor and logs the erasure. The donor row and the amounts
+// of its donations are kept for campaign accounting; names, contact data, messages, the payer data of
+// reconciled transfers, the merged duplicates and the name on tax certificates are cleared, and recurring
+// donations are cancelled. It returns the receipt and tax certificate URLs that were detached so the
+// files can be deleted.
+func (r *repository) EraseDonor(ctx context.Context, request Request) (ErasureResult, []string, error) {
+	result := ErasureResult{}
+	var receiptURLs []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var donors []struct {
+			ID       uuid.UUID
+			ErasedAt *time.Time
+		}
+		err := tx.Raw("SELECT id, erased_at FROM donors WHERE id = ? FOR UPDATE", request.DonorID).Scan(&donors).Error
+		if err != nil {
+			return err
+		}
+		if len(donors) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if donors[0].ErasedAt != nil {
+			return ErrAlreadyErased
+		}
+
+		now := time.Now()
+		err = tx.Table("donations").
+			Where("donor_id = ? AND receipt_url IS NOT NULL", request.DonorID).
+			Pluck("receipt_url", &receiptURLs).Error
+		if err != nil {
+			return err
+		}
+
+		var certificateURLs []string
+		err = tx.Table("tax_certificates").
+			Where("donor_id = ? AND pdf_url <> ''", request.DonorID).
+			Pluck("pdf_url", &certificateURLs).Error
+		if err != nil {
+			return err
+		}
+		receiptURLs = append(receiptURLs, certificateURLs...)
+
+		err = tx.Table("tax_certificates").Where("donor_id = ?", request.DonorID).Updates(map[string]interface{}{
+			"donor_name": ErasedFirstName + " " + ErasedLastName,
+			"pdf_url":    "",
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`UPDATE bank_statement_lines
+			SET payer_name = NULL, payer_reference = NULL, description = NULL
+			WHERE donation_id IN (SELECT id FROM donations WHERE donor_id = ?)`, request.DonorID).Error
+		if err != nil {
+			return err
+		}
+
+		donations := tx.Table("donations").Where("donor_id = ?", request.DonorID).Updates(map[string]interface{}{
+			"message":      nil,
+			"is_anonymous": true,
+			"receipt_url":  nil,
+			"updated_at":   now,
+		})
+		if donations.Error != nil {
+			return donations.Error
+		}
+		result.DonationsAnonymized = int(donations.RowsAffected)
+
+		subscriptions := tx.Table("donation_subscriptions").
+			Where("donor_id = ? AND status IN ?", request.DonorID, []string{"active", "paused"}).
+			Updates(map[string]interface{}{
+				"status":       "cancelled",
+				"cancelled_at": now,
+				"updated_at":   now,
+			})
+		if subscriptions.Error != nil {
+			return subscriptions.Error
+		}
+		result.SubscriptionsCancelled = int(subscriptions.RowsAffected)
+
+		err = tx.Table("donor_merges").
+			Where("target_donor_id = ? OR source_donor_id = ?", request.DonorID, request.DonorID).
+			Updates(map[string]interface{}{
+				"source_first_name": ErasedFirstName,
+				"source_last_name":  ErasedLastName,
+				"source_email":      "",
+				"source_phone":      nil,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Table("donors").Where("id = ?", request.DonorID).Updates(map[string]interface{}{
+			"first_name":  ErasedFirstName,
+			"last_name":   ErasedLastName,
+			"email":       nil,
+			"phone":       nil,
+			"is_verified": false,
+			"user_id":     nil,
+			"erased_at":   now,
+			"updated_at":  now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		var model RequestModel
+		model.FromEntity(request)
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		result.Request = model.ToEntity()
+		return nil
+	})
+	if err != nil {
+		return ErasureResult{}, nil, err
+	}
+	return result, receiptURLs, nil
+}
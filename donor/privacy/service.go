@@ -0,0 +1,224 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"dona_tutti_api/donor/dedup"
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/s3client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	ExportDonor(ctx context.Context, donorID uuid.UUID, performedBy *uuid.UUID) (Export, error)
+	ExportDonorZIP(ctx context.Context, donorID uuid.UUID, performedBy *uuid.UUID) ([]byte, error)
+	EraseDonor(ctx context.Context, donorID uuid.UUID, req ErasureRequest, performedBy *uuid.UUID) (ErasureResult, error)
+	ListRequests(ctx context.Context, donorID uuid.UUID) ([]Request, error)
+}
+
+// MergeService defines the donor merge operations needed to export merged duplicates
+type MergeService interface {
+	ListMerges(ctx context.Context, donorID uuid.UUID) ([]dedup.Merge, error)
+}
+
+type service struct {
+	repo         Repository
+	mergeService MergeService
+	s3Client     *s3client.Client
+}
+
+// NewService creates the donor privacy service. Without an S3 client, ZIP exports carry the receipt
+// URLs only and erased receipts are not deleted from storage.
+func NewService(repo Repository, mergeService MergeService, s3Client *s3client.Client) Service {
+	return &service{
+		repo:         repo,
+		mergeService: mergeService,
+		s3Client:     s3Client,
+	}
+}
+
+// ExportDonor returns everything held about a donor and logs the export
+func (s *service) ExportDonor(ctx context.Context, donorID uuid.UUID, performedBy *uuid.UUID) (Export, error) {
+	export, err := s.buildExport(ctx, donorID)
+	if err != nil {
+		return Export{}, err
+	}
+	if err := s.logExport(ctx, donorID, ExportFormatJSON, performedBy); err != nil {
+		return Export{}, err
+	}
+	return export, nil
+}
+
+// ExportDonorZIP returns a ZIP with the export as export.json and the receipt PDFs under receipts/,
+// and logs the export. Receipts that cannot be downloaded are only listed by URL.
+func (s *service) ExportDonorZIP(ctx context.Context, donorID uuid.UUID, performedBy *uuid.UUID) ([]byte, error) {
+	export, err := s.buildExport(ctx, donorID)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+	archive := zip.NewWriter(buffer)
+	if s.s3Client != nil {
+		for i, receipt := range export.Receipts {
+			data, err := s.s3Client.Download(ctx, receipt.URL)
+			if err != nil {
+				fmt.Printf("Warning: failed to download receipt of donation %s: %v\n", receipt.DonationID.String(), err)
+				continue
+			}
+
+			name := fmt.Sprintf("receipts/%s.pdf", receipt.DonationID.String())
+			file, err := archive.Create(name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add receipt to export: %w", err)
+			}
+			if _, err := file.Write(data); err != nil {
+				return nil, fmt.Errorf("failed to add receipt to export: %w", err)
+			}
+			export.Receipts[i].File = name
+		}
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export: %w", err)
+	}
+	file, err := archive.Create("export.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to add data to export: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to add data to export: %w", err)
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	if err := s.logExport(ctx, donorID, ExportFormatZIP, performedBy); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (s *service) buildExport(ctx context.Context, donorID uuid.UUID) (Export, error) {
+	donorData, err := s.repo.GetDonor(ctx, donorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Export{}, apierrors.NewNotFoundError("donor not found")
+		}
+		return Export{}, fmt.Errorf("failed to get donor: %w", err)
+	}
+
+	donations, err := s.repo.ListDonations(ctx, donorID)
+	if err != nil {
+		return Export{}, fmt.Errorf("failed to list donations: %w", err)
+	}
+	subscriptions, err := s.repo.ListSubscriptions(ctx, donorID)
+	if err != nil {
+		return Export{}, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	merges, err := s.mergeService.ListMerges(ctx, donorID)
+	if err != nil {
+		return Export{}, err
+	}
+
+	export := Export{
+		GeneratedAt:   time.Now(),
+		Donor:         donorData,
+		Donations:     donations,
+		Messages:      []Message{},
+		Receipts:      []Receipt{},
+		Subscriptions: subscriptions,
+		Merges:        merges,
+	}
+	for _, d := range donations {
+		if d.Message != nil && strings.TrimSpace(*d.Message) != "" {
+			export.Messages = append(export.Messages, Message{
+				DonationID:    d.ID,
+				CampaignTitle: d.CampaignTitle,
+				Message:       *d.Message,
+				Date:          d.Date,
+			})
+		}
+		if d.ReceiptURL != nil {
+			export.Receipts = append(export.Receipts, Receipt{DonationID: d.ID, URL: *d.ReceiptURL})
+		}
+	}
+	return export, nil
+}
+
+func (s *service) logExport(ctx context.Context, donorID uuid.UUID, format ExportFormat, performedBy *uuid.UUID) error {
+	err := s.repo.CreateRequest(ctx, Request{
+		ID:          uuid.New(),
+		DonorID:     donorID,
+		Type:        RequestTypeExport,
+		Format:      &format,
+		PerformedBy: performedBy,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log donor data export: %w", err)
+	}
+
+	log.Printf("📤 Exported data of donor %s as %s", donorID.String(), format)
+	return nil
+}
+
+// EraseDonor anonymizes the personal data of a donor, keeping its donation amounts for campaign
+// accounting and closure reports, and deletes the receipts that carried the donor name
+func (s *service) EraseDonor(ctx context.Context, donorID uuid.UUID, req ErasureRequest, performedBy *uuid.UUID) (ErasureResult, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return ErasureResult{}, apierrors.NewFieldValidationError("reason", "reason is required")
+	}
+
+	result, receiptURLs, err := s.repo.EraseDonor(ctx, Request{
+		ID:          uuid.New(),
+		DonorID:     donorID,
+		Type:        RequestTypeErasure,
+		Reason:      &reason,
+		PerformedBy: performedBy,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErasureResult{}, apierrors.NewNotFoundError("donor not found")
+		}
+		if errors.Is(err, ErrAlreadyErased) {
+			return ErasureResult{}, apierrors.NewValidationError(err.Error())
+		}
+		return ErasureResult{}, fmt.Errorf("failed to erase donor data: %w", err)
+	}
+
+	// The data is already erased; receipts left in storage are only reported
+	if s.s3Client != nil {
+		for _, url := range receiptURLs {
+			if err := s.s3Client.Delete(ctx, url); err != nil {
+				fmt.Printf("Warning: failed to delete receipt %s of erased donor %s: %v\n", url, donorID.String(), err)
+				continue
+			}
+			result.ReceiptsDeleted++
+		}
+	}
+
+	log.Printf("🧽 Erased data of donor %s: %d donations anonymized", donorID.String(), result.DonationsAnonymized)
+	return result, nil
+}
+
+func (s *service) ListRequests(ctx context.Context, donorID uuid.UUID) ([]Request, error) {
+	requests, err := s.repo.ListRequests(ctx, donorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list donor privacy requests: %w", err)
+	}
+	return requests, nil
+}
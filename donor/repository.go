@@ -65,7 +65,7 @@ func (r *donorRepository) ListDonors(ctx context.Context) ([]Donor, error) {
 
 func (r *donorRepository) FindDonorByEmail(ctx context.Context, email string) (Donor, error) {
 	var model DonorModel
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&model).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("LOWER(email) = ?", email).First(&model).Error; err != nil {
 		return Donor{}, fmt.Errorf("failed to find donor by email: %w", err)
 	}
 	return model.ToEntity(), nil
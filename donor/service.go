@@ -2,9 +2,13 @@ package donor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	apierrors "dona_tutti_api/errors"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Service interface {
@@ -29,6 +33,11 @@ func (s *service) GetDonor(ctx context.Context, id uuid.UUID) (Donor, error) {
 }
 
 func (s *service) CreateDonor(ctx context.Context, donor Donor) (uuid.UUID, error) {
+	donor, err := normalizeDonor(donor)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
 	donor.ID = uuid.New()
 	if err := s.repo.CreateDonor(ctx, donor); err != nil {
 		return uuid.Nil, fmt.Errorf("failed to create donor: %w", err)
@@ -37,6 +46,10 @@ func (s *service) CreateDonor(ctx context.Context, donor Donor) (uuid.UUID, erro
 }
 
 func (s *service) UpdateDonor(ctx context.Context, donor Donor) error {
+	donor, err := normalizeDonor(donor)
+	if err != nil {
+		return err
+	}
 	return s.repo.UpdateDonor(ctx, donor)
 }
 
@@ -45,9 +58,26 @@ func (s *service) ListDonors(ctx context.Context) ([]Donor, error) {
 }
 
 func (s *service) FindDonorByEmail(ctx context.Context, email string) (Donor, error) {
-	return s.repo.FindDonorByEmail(ctx, email)
+	return s.repo.FindDonorByEmail(ctx, NormalizeEmail(email))
 }
 
 func (s *service) FindDonorByPhone(ctx context.Context, phone string) (Donor, error) {
-	return s.repo.FindDonorByPhone(ctx, phone)
+	normalized, err := NormalizePhone(phone)
+	if err != nil {
+		return Donor{}, apierrors.NewFieldValidationError("phone", err.Error())
+	}
+	if normalized == "" {
+		return Donor{}, fmt.Errorf("failed to find donor by phone: %w", gorm.ErrRecordNotFound)
+	}
+	return s.repo.FindDonorByPhone(ctx, normalized)
+}
+
+// normalizeDonor stores emails lowercased and phones in E.164 format, so the same donor is found
+// however they typed them
+func normalizeDonor(donor Donor) (Donor, error) {
+	normalized, err := donor.Normalize()
+	if errors.Is(err, ErrInvalidPhone) {
+		return Donor{}, apierrors.NewFieldValidationError("phone", err.Error())
+	}
+	return normalized, err
 }
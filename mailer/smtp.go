@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Config holds the SMTP server used to send emails
+type Config struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// Mailer sends plain text emails through an SMTP server
+type Mailer struct {
+	config Config
+}
+
+// New creates a mailer for the given SMTP server
+func New(config Config) (*Mailer, error) {
+	if config.Host == "" || config.Port == "" {
+		return nil, fmt.Errorf("SMTP host and port are required")
+	}
+	if config.From == "" {
+		return nil, fmt.Errorf("SMTP sender address is required")
+	}
+	return &Mailer{config: config}, nil
+}
+
+// Send sends a plain text email to a single recipient
+func (m *Mailer) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("invalid email header")
+	}
+
+	message := strings.Join([]string{
+		"From: " + m.config.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	// net/smtp does not take a context, so only a cancelled request is checked before sending
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", to, err)
+	}
+	return nil
+}
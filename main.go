@@ -5,14 +5,27 @@ import (
 	"database/sql"
 	"dona_tutti_api/campaign"
 	"dona_tutti_api/campaign/activity"
+	"dona_tutti_api/campaign/alerts"
 	"dona_tutti_api/campaign/closure"
 	"dona_tutti_api/campaign/contract"
+	"dona_tutti_api/campaign/matching"
 	"dona_tutti_api/campaign/receipts"
 	"dona_tutti_api/campaigncategory"
+	"dona_tutti_api/currency"
 	"dona_tutti_api/database"
 	"dona_tutti_api/docs"
 	"dona_tutti_api/donation"
+	"dona_tutti_api/donation/cash"
+	"dona_tutti_api/donation/certificate"
+	"dona_tutti_api/donation/payment"
+	"dona_tutti_api/donation/reconciliation"
+	"dona_tutti_api/donation/refund"
+	"dona_tutti_api/donation/subscription"
 	"dona_tutti_api/donor"
+	"dona_tutti_api/donor/account"
+	"dona_tutti_api/donor/dedup"
+	"dona_tutti_api/donor/privacy"
+	"dona_tutti_api/mailer"
 	appMiddleware "dona_tutti_api/middleware"
 	"dona_tutti_api/migrations"
 	"dona_tutti_api/organizer"
@@ -100,6 +113,9 @@ func main() {
 	// Configure validator
 	e.Validator = &CustomValidator{validator: validator.New()}
 
+	// Client IPs come from the connection unless the request went through a trusted proxy
+	e.IPExtractor = appMiddleware.IPExtractor(os.Getenv("TRUSTED_PROXIES"))
+
 	// Middleware
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Format:           "[${time_rfc3339}] ${status} ${method} ${uri} - ${latency_human}\n",
@@ -160,7 +176,54 @@ func main() {
 
 	// Initialize Donation service (requires campaignService and s3Client for receipt generation)
 	donationRepo := donation.NewDonationRepository(db)
-	donationService := donation.NewService(donationRepo, donorService, s3Client, campaignService)
+	paymentGateways := payment.NewRegistry()
+	var fakePaymentGateway *payment.FakeGateway
+	if os.Getenv("FAKE_PAYMENT_GATEWAY_ENABLED") == "true" && os.Getenv("ENVIRONMENT") == "production" {
+		// Anyone can complete a fake payment, so the simulator never runs in production
+		log.Printf("⚠️  Fake payment gateway disabled: it is not available in production")
+	} else if os.Getenv("FAKE_PAYMENT_GATEWAY_ENABLED") == "true" && os.Getenv("FAKE_PAYMENT_GATEWAY_SECRET") == "" {
+		log.Printf("⚠️  Fake payment gateway disabled: FAKE_PAYMENT_GATEWAY_SECRET is required to sign webhooks")
+	} else if os.Getenv("FAKE_PAYMENT_GATEWAY_ENABLED") == "true" {
+		baseURL := os.Getenv("API_BASE_URL")
+		if baseURL == "" {
+			baseURL = "http://localhost:9999"
+		}
+		fakePaymentGateway = payment.NewFakeGateway(os.Getenv("FAKE_PAYMENT_GATEWAY_SECRET"), baseURL)
+		paymentGateways.Register(fakePaymentGateway)
+		log.Printf("💳 Fake payment gateway enabled for payment method %s", payment.FakeGatewayCode)
+	}
+	currencyRepo := currency.NewRepository(db)
+	currencyService := currency.NewService(currencyRepo)
+	// Initialize RBAC service
+	rbacRepo := rbac.NewRepository(db)
+	rbacService := rbac.NewService(rbacRepo)
+
+	// Donor link tokens are sent by email, so linking is unavailable without an SMTP server
+	var donorLinkMailer account.Mailer
+	if os.Getenv("SMTP_HOST") != "" {
+		smtpMailer, err := mailer.New(mailer.Config{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		})
+		if err != nil {
+			log.Fatalf("Invalid SMTP configuration: %v", err)
+		}
+		donorLinkMailer = smtpMailer
+	} else {
+		log.Printf("⚠️  SMTP_HOST not set, donor accounts cannot request donor links")
+	}
+
+	// Donors linked to a user account get the donor role when a donation completes
+	donorAccountRepo := account.NewRepository(db)
+	donorAccountService := account.NewService(donorAccountRepo, userService, rbacService, donorLinkMailer)
+
+	// Sponsors matching donations are applied when a donation completes
+	matchingRepo := matching.NewRepository(db)
+	matchingService := matching.NewService(matchingRepo, campaignService, organizerService, donorService)
+	donationService := donation.NewService(donationRepo, donorService, s3Client, campaignService, paymentGateways, currencyService, matchingService, donorAccountService)
 
 	// Initialize Contract service
 	var contractService contract.Service
@@ -179,10 +242,6 @@ func main() {
 		contractService = nil
 	}
 
-	// Initialize RBAC service
-	rbacRepo := rbac.NewRepository(db)
-	rbacService := rbac.NewService(rbacRepo)
-
 	// Register routes
 	user.RegisterRoutes(api, userService)
 	campaign.RegisterRoutes(api, campaignService, activityService, receiptsService, donationService, s3Client, rbacService)
@@ -223,11 +282,97 @@ func main() {
 		// Register closure routes
 		closureHandler := closure.NewHandler(closureService)
 		rbacMiddleware := appMiddleware.NewRBACMiddleware(rbacService)
-		closureHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), rbacMiddleware.RequireRole("admin"))
+		campaignOwnerMiddleware := rbacMiddleware.RequireOwnershipWithConfig(appMiddleware.OwnershipConfig{
+			Resource:        rbac.ResourceCampaigns,
+			ResourceIDParam: "id",
+		})
+		closureHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), rbacMiddleware.RequireRole("admin"),
+			rbacMiddleware.Combine(rbacMiddleware.RequireRole("admin"), campaignOwnerMiddleware))
+
+		// Start automatic closure scheduler
+		if os.Getenv("AUTO_CLOSURE_ENABLED") != "false" {
+			interval, err := time.ParseDuration(os.Getenv("AUTO_CLOSURE_INTERVAL"))
+			if err != nil {
+				interval = closure.DefaultAutoClosureInterval
+			}
+			go closure.NewScheduler(closureService, db, interval).Start(context.Background())
+		}
 	} else {
 		log.Printf("⚠️  Closure Service Disabled: S3 client is required")
 	}
 
+	// Register exchange rate routes
+	currencyHandler := currency.NewHandler(currencyService)
+	currencyHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Register payment routes
+	donation.NewPaymentHandler(donationService, fakePaymentGateway).RegisterRoutes(api)
+
+	// Register refund routes
+	refundRepo := refund.NewRepository(db)
+	refundService := refund.NewService(refundRepo, donationService)
+	refundHandler := refund.NewHandler(refundService, donationService)
+	refundHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Register bank reconciliation routes
+	reconciliationRepo := reconciliation.NewRepository(db)
+	reconciliationService := reconciliation.NewService(reconciliationRepo, donationService, campaignService)
+	reconciliationHandler := reconciliation.NewHandler(reconciliationService)
+	reconciliationHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Register subscription routes
+	subscriptionRepo := subscription.NewRepository(db)
+	subscriptionService := subscription.NewService(subscriptionRepo, donationService, campaignService)
+	subscriptionHandler := subscription.NewHandler(subscriptionService)
+	subscriptionHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Start recurring donation scheduler
+	if os.Getenv("SUBSCRIPTIONS_SCHEDULER_ENABLED") != "false" {
+		interval, err := time.ParseDuration(os.Getenv("SUBSCRIPTIONS_SCHEDULER_INTERVAL"))
+		if err != nil {
+			interval = subscription.DefaultChargeInterval
+		}
+		go subscription.NewScheduler(subscriptionService, db, interval).Start(context.Background())
+	}
+
+	// Register alert routes
+	alertsRepo := alerts.NewRepository(db)
+	alertsService := alerts.NewService(alertsRepo)
+	alertsHandler := alerts.NewHandler(alertsService)
+	alertsHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Register matching pledge routes
+	matchingHandler := matching.NewHandler(matchingService)
+	matchingHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Register donor self-service routes
+	donorAccountHandler := account.NewHandler(donorAccountService)
+	donorAccountHandler.RegisterRoutes(api, appMiddleware.RequireAuth())
+
+	// Register donor deduplication routes
+	donorDedupRepo := dedup.NewRepository(db)
+	donorDedupService := dedup.NewService(donorDedupRepo)
+	donorDedupHandler := dedup.NewHandler(donorDedupService)
+	donorDedupHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Register donor data export and erasure routes
+	donorPrivacyRepo := privacy.NewRepository(db)
+	donorPrivacyService := privacy.NewService(donorPrivacyRepo, donorDedupService, s3Client)
+	donorPrivacyHandler := privacy.NewHandler(donorPrivacyService)
+	donorPrivacyHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Register annual tax certificate routes
+	certificateRepo := certificate.NewRepository(db)
+	certificateService := certificate.NewService(certificateRepo, certificate.NewPDFGenerator(), s3Client)
+	certificateHandler := certificate.NewHandler(certificateService)
+	certificateHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin"))
+
+	// Register cash collection routes, available to admins and collectors
+	cashRepo := cash.NewRepository(db)
+	cashService := cash.NewService(cashRepo, donationService, campaignService, alertsService)
+	cashHandler := cash.NewHandler(cashService)
+	cashHandler.RegisterRoutes(api, appMiddleware.RequireAuth(), appMiddleware.NewRBACMiddleware(rbacService).RequireRole("admin", "collector"))
+
 	// Start server
 	port := os.Getenv("API_PORT")
 	if port == "" {
@@ -324,6 +469,7 @@ func (a *closureCampaignServiceAdapter) GetCampaignForClosure(ctx context.Contex
 		ID:          c.ID,
 		Title:       c.Title,
 		Goal:        c.Goal,
+		Currency:    c.Currency,
 		OrganizerID: c.OrganizerID,
 		Status:      c.Status,
 		StartDate:   c.StartDate,
@@ -27,49 +27,80 @@ func RequireAuth() echo.MiddlewareFunc {
 				return echo.NewHTTPError(http.StatusUnauthorized, "missing authorization header")
 			}
 
-			// Check if the header has the Bearer prefix
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "invalid authorization header format")
+			claims, err := parseAuthHeader(authHeader)
+			if err != nil {
+				return err
 			}
 
-			tokenString := parts[1]
-
-			// Parse and validate the token
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				// Validate signing method
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, echo.NewHTTPError(http.StatusUnauthorized, "invalid token signing method")
-				}
-				return []byte(jwtSecret), nil
-			})
+			setClaims(c, claims)
+			return next(c)
+		}
+	}
+}
 
-			if err != nil {
-				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+// OptionalAuth middleware stores the user info when a valid JWT token is present,
+// and lets anonymous requests through otherwise
+func OptionalAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
+			if authHeader == "" {
+				return next(c)
 			}
 
-			if !token.Valid {
-				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+			if claims, err := parseAuthHeader(authHeader); err == nil {
+				setClaims(c, claims)
 			}
+			return next(c)
+		}
+	}
+}
 
-			// Extract claims
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
-				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token claims")
-			}
+// parseAuthHeader validates a Bearer authorization header and returns the token claims
+func parseAuthHeader(authHeader string) (jwt.MapClaims, error) {
+	// Check if the header has the Bearer prefix
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "invalid authorization header format")
+	}
 
-			// Store user info in context
-			c.Set("user_id", claims["sub"])
-			
-			// Store additional claims for RBAC
-			if roleID, ok := claims["role_id"]; ok {
-				c.Set("role_id", roleID)
-			}
-			if roleName, ok := claims["role"]; ok {
-				c.Set("role", roleName)
-			}
+	tokenString := parts[1]
 
-			return next(c)
+	// Parse and validate the token
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		// Validate signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, echo.NewHTTPError(http.StatusUnauthorized, "invalid token signing method")
 		}
+		return []byte(jwtSecret), nil
+	})
+
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+	}
+
+	if !token.Valid {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+	}
+
+	// Extract claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "invalid token claims")
+	}
+
+	return claims, nil
+}
+
+// setClaims stores the user info from the token claims in the request context
+func setClaims(c echo.Context, claims jwt.MapClaims) {
+	c.Set("user_id", claims["sub"])
+
+	// Store additional claims for RBAC
+	if roleID, ok := claims["role_id"]; ok {
+		c.Set("role_id", roleID)
+	}
+	if roleName, ok := claims["role"]; ok {
+		c.Set("role", roleName)
 	}
 }
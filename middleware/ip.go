@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"log"
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor returns how Echo finds the client IP used by rate limits and report dedup.
+// trustedProxies is a comma-separated list of the CIDR ranges of the proxies in front of the API.
+// Without trusted proxies, the IP of the connection is used and forwarding headers are ignored,
+// since any client can set them. With trusted proxies, X-Forwarded-For is only followed through them.
+func IPExtractor(trustedProxies string) echo.IPExtractor {
+	var options []echo.TrustOption
+	for _, value := range strings.Split(trustedProxies, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		_, ipRange, err := net.ParseCIDR(value)
+		if err != nil {
+			log.Printf("⚠️  Ignoring invalid trusted proxy range %q: %v", value, err)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	if len(options) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	// Only the configured ranges are trusted, not every private or loopback address
+	options = append(options, echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false))
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+// RateLimit middleware limits requests per client to requestsPerMinute, allowing short bursts.
+// Authenticated users are identified by their user ID, anonymous clients by their IP address.
+func RateLimit(requestsPerMinute int, burst int) echo.MiddlewareFunc {
+	store := echoMiddleware.NewRateLimiterMemoryStoreWithConfig(echoMiddleware.RateLimiterMemoryStoreConfig{
+		Rate:      rate.Limit(float64(requestsPerMinute) / 60),
+		Burst:     burst,
+		ExpiresIn: 10 * time.Minute,
+	})
+
+	return echoMiddleware.RateLimiterWithConfig(echoMiddleware.RateLimiterConfig{
+		Store: store,
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			if userID, ok := c.Get("user_id").(string); ok && userID != "" {
+				return "user:" + userID, nil
+			}
+			return "ip:" + c.RealIP(), nil
+		},
+		ErrorHandler: func(c echo.Context, err error) error {
+			return echo.NewHTTPError(http.StatusForbidden, "Unable to identify client")
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			return echo.NewHTTPError(http.StatusTooManyRequests, "Too many requests, please try again later")
+		},
+	})
+}
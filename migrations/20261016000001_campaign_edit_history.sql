@@ -0,0 +1,22 @@
+-- +goose Up
+-- Create campaign_edits table to keep an audit trail of campaign changes
+CREATE TABLE IF NOT EXISTS campaign_edits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    edited_by UUID REFERENCES users(id),
+    campaign_status VARCHAR(50) NOT NULL,
+    field VARCHAR(100) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_edits_campaign_id ON campaign_edits(campaign_id);
+CREATE INDEX IF NOT EXISTS idx_campaign_edits_created_at ON campaign_edits(created_at);
+
+COMMENT ON TABLE campaign_edits IS 'Field-level history of campaign edits, used by the closure audit';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_campaign_edits_created_at;
+DROP INDEX IF EXISTS idx_campaign_edits_campaign_id;
+DROP TABLE IF EXISTS campaign_edits;
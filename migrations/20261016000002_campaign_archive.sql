@@ -0,0 +1,13 @@
+-- +goose Up
+-- Add soft-delete (archive) support to campaigns
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS archived_by UUID REFERENCES users(id);
+
+CREATE INDEX IF NOT EXISTS idx_campaigns_archived_at ON campaigns(archived_at);
+
+COMMENT ON COLUMN campaigns.archived_at IS 'When set, the campaign is archived and hidden from listings';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_campaigns_archived_at;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS archived_by;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Accent-insensitive Spanish full-text search for campaigns
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- +goose StatementBegin
+-- Spanish text search configuration that strips accents before stemming
+DO $es_unaccent$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'es_unaccent') THEN
+        CREATE TEXT SEARCH CONFIGURATION es_unaccent (COPY = spanish);
+        ALTER TEXT SEARCH CONFIGURATION es_unaccent
+            ALTER MAPPING FOR hword, hword_part, word WITH unaccent, spanish_stem;
+    END IF;
+END $es_unaccent$;
+-- +goose StatementEnd
+
+-- Generated search vector, weighted by field relevance
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('es_unaccent'::regconfig, COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('es_unaccent'::regconfig, COALESCE(beneficiary_name, '')), 'B') ||
+        setweight(to_tsvector('es_unaccent'::regconfig, COALESCE(description, '')), 'C') ||
+        setweight(to_tsvector('es_unaccent'::regconfig, COALESCE(current_situation, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_campaigns_search_vector ON campaigns USING GIN(search_vector);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_campaigns_search_vector;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS search_vector;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS es_unaccent;
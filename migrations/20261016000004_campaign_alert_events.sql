@@ -0,0 +1,20 @@
+-- +goose Up
+-- Status history and notes for campaign alerts
+CREATE TABLE IF NOT EXISTS campaign_alert_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    alert_id UUID NOT NULL REFERENCES campaign_alerts(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    notes TEXT,
+    changed_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_alert_events_alert_id ON campaign_alert_events(alert_id);
+
+COMMENT ON TABLE campaign_alerts IS 'Alerts reported against campaigns, reviewed by admins';
+COMMENT ON TABLE campaign_alert_events IS 'Status changes and admin notes for campaign alerts';
+
+-- +goose Down
+DROP TABLE IF EXISTS campaign_alert_events;
+COMMENT ON TABLE campaign_alerts IS 'Placeholder table for future alert system implementation';
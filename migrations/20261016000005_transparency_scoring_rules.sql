@@ -0,0 +1,63 @@
+-- +goose Up
+-- Versioned transparency scoring rules
+CREATE TABLE IF NOT EXISTS transparency_scoring_rules (
+    version SERIAL PRIMARY KEY,
+    rules JSONB NOT NULL,
+    notes TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT false,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Only one version can be active at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transparency_scoring_rules_active
+    ON transparency_scoring_rules(is_active) WHERE is_active;
+
+-- Version 1 reproduces the original hard-coded scoring
+INSERT INTO transparency_scoring_rules (version, rules, notes, is_active) VALUES (1, '{
+    "documentation_max": 30,
+    "activity_max": 25,
+    "activity_extra_ratio_weight": 0.2,
+    "activity_ratio_cap": 1.5,
+    "activity_period_days": 30,
+    "goal_progress_max": 20,
+    "timeliness_tiers": [
+        {"max_days": 7, "points": 15},
+        {"max_days": 14, "points": 12},
+        {"max_days": 30, "points": 8}
+    ],
+    "timeliness_default": 5,
+    "alert_penalty": 2,
+    "alerts_deduction_max": 10,
+    "contract_bonus": 3,
+    "donors_bonus_threshold": 10,
+    "donors_bonus": 2,
+    "expense_ratio_threshold": 0.8,
+    "expense_bonus": 3,
+    "early_closure_bonus": 2
+}', 'Initial scoring rules', true)
+ON CONFLICT (version) DO NOTHING;
+
+SELECT setval('transparency_scoring_rules_version_seq', (SELECT MAX(version) FROM transparency_scoring_rules));
+
+-- Rules version used for each closure report
+ALTER TABLE campaign_closure_reports ADD COLUMN IF NOT EXISTS rules_version INTEGER NOT NULL DEFAULT 1 REFERENCES transparency_scoring_rules(version);
+
+-- Re-scores of closed campaigns under other rules versions; original reports are never overwritten
+CREATE TABLE IF NOT EXISTS transparency_rescores (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    closure_report_id UUID NOT NULL REFERENCES campaign_closure_reports(id) ON DELETE CASCADE,
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    rules_version INTEGER NOT NULL REFERENCES transparency_scoring_rules(version),
+    transparency_score DECIMAL(5,2) NOT NULL CHECK (transparency_score >= 0 AND transparency_score <= 100),
+    transparency_breakdown JSONB NOT NULL,
+    rescored_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transparency_rescores_campaign_id ON transparency_rescores(campaign_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS transparency_rescores;
+ALTER TABLE campaign_closure_reports DROP COLUMN IF EXISTS rules_version;
+DROP TABLE IF EXISTS transparency_scoring_rules;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Idempotency keys let donors safely retry a donation request without creating duplicates
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_donations_idempotency_key
+    ON donations(idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_donations_idempotency_key;
+ALTER TABLE donations DROP COLUMN IF EXISTS idempotency_key;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- Checkout sessions started at a payment gateway for a donation
+CREATE TABLE IF NOT EXISTS payment_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    donation_id UUID NOT NULL REFERENCES donations(id) ON DELETE CASCADE,
+    gateway_code VARCHAR(30) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    checkout_url TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'approved', 'rejected')),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(gateway_code, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_sessions_donation_id ON payment_sessions(donation_id);
+
+-- Processed webhook events, so replayed notifications are applied only once
+CREATE TABLE IF NOT EXISTS payment_webhook_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    gateway_code VARCHAR(30) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    donation_id UUID REFERENCES donations(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL,
+    payload TEXT NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(gateway_code, event_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS payment_webhook_events;
+DROP TABLE IF EXISTS payment_sessions;
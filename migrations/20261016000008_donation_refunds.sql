@@ -0,0 +1,41 @@
+-- +goose Up
+-- Amount of the donation returned to the donor; campaign totals count amount - refunded_amount
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS refunded_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_donations_refunded_amount') THEN
+        ALTER TABLE donations ADD CONSTRAINT chk_donations_refunded_amount
+            CHECK (refunded_amount >= 0 AND refunded_amount <= amount);
+    END IF;
+END $$;
+-- +goose StatementEnd
+
+-- Refunds are requested, then approved or rejected by an admin
+CREATE TABLE IF NOT EXISTS donation_refunds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    donation_id UUID NOT NULL REFERENCES donations(id) ON DELETE CASCADE,
+    campaign_id UUID NOT NULL REFERENCES campaigns(id),
+    amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+    is_full BOOLEAN NOT NULL DEFAULT false,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested' CHECK (status IN ('requested', 'approved', 'rejected')),
+    requested_by UUID REFERENCES users(id),
+    reviewed_by UUID REFERENCES users(id),
+    review_notes TEXT,
+    closure_override BOOLEAN NOT NULL DEFAULT false,
+    voided_receipt_url VARCHAR(500),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_donation_refunds_donation_id ON donation_refunds(donation_id);
+CREATE INDEX IF NOT EXISTS idx_donation_refunds_campaign_id ON donation_refunds(campaign_id);
+CREATE INDEX IF NOT EXISTS idx_donation_refunds_status ON donation_refunds(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS donation_refunds;
+ALTER TABLE donations DROP CONSTRAINT IF EXISTS chk_donations_refunded_amount;
+ALTER TABLE donations DROP COLUMN IF EXISTS refunded_amount;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Every donation status change, including the initial status
+CREATE TABLE IF NOT EXISTS donation_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    donation_id UUID NOT NULL REFERENCES donations(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    source VARCHAR(30) NOT NULL,
+    changed_by UUID REFERENCES users(id),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_donation_status_history_donation_id ON donation_status_history(donation_id, created_at);
+
+-- Donations created before the history existed get their current status as first entry
+INSERT INTO donation_status_history (donation_id, from_status, to_status, source, created_at)
+SELECT d.id, NULL, d.status::text, 'migration', COALESCE(d.updated_at, d.created_at, NOW())
+FROM donations d
+WHERE NOT EXISTS (SELECT 1 FROM donation_status_history h WHERE h.donation_id = d.id);
+
+-- +goose Down
+DROP TABLE IF EXISTS donation_status_history;
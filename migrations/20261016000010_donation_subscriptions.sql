@@ -0,0 +1,40 @@
+-- +goose Up
+-- Recurring donations: a scheduler creates a pending donation every period
+CREATE TABLE IF NOT EXISTS donation_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    donor_id UUID NOT NULL REFERENCES donors(id),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id),
+    amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+    interval VARCHAR(20) NOT NULL CHECK (interval IN ('monthly', 'quarterly', 'yearly')),
+    billing_day SMALLINT NOT NULL CHECK (billing_day BETWEEN 1 AND 31),
+    payment_method_id INTEGER NOT NULL REFERENCES payment_methods(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'cancelled', 'ended')),
+    next_charge_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_charged_at TIMESTAMP WITH TIME ZONE,
+    management_token_hash VARCHAR(64) NOT NULL,
+    ended_reason TEXT,
+    paused_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_donation_subscriptions_due ON donation_subscriptions(status, next_charge_at);
+CREATE INDEX IF NOT EXISTS idx_donation_subscriptions_campaign_id ON donation_subscriptions(campaign_id);
+CREATE INDEX IF NOT EXISTS idx_donation_subscriptions_donor_id ON donation_subscriptions(donor_id);
+
+-- One donation per subscription period
+CREATE TABLE IF NOT EXISTS subscription_charges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES donation_subscriptions(id) ON DELETE CASCADE,
+    donation_id UUID REFERENCES donations(id) ON DELETE SET NULL,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    checkout_url TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(subscription_id, due_at)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS subscription_charges;
+DROP TABLE IF EXISTS donation_subscriptions;
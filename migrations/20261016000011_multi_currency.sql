@@ -0,0 +1,52 @@
+-- +goose Up
+-- Campaigns raise money in a single currency; existing campaigns were all in pesos
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'ARS';
+
+-- Exchange rates: 1 unit of from_currency is worth rate units of to_currency from effective_at on
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_currency VARCHAR(3) NOT NULL,
+    to_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(18,8) NOT NULL CHECK (rate > 0),
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'import')),
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_currency <> to_currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_pair ON exchange_rates(from_currency, to_currency, effective_at DESC);
+
+-- Donations keep the amount the donor gave and the rate used to convert it to the campaign currency.
+-- amount and refunded_amount are always in the campaign currency.
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'ARS';
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS original_amount DECIMAL(10,2);
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS original_currency VARCHAR(3) NOT NULL DEFAULT 'ARS';
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(18,8) NOT NULL DEFAULT 1;
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS exchange_rate_id UUID REFERENCES exchange_rates(id);
+
+UPDATE donations d
+SET currency = c.currency,
+    original_amount = d.amount,
+    original_currency = c.currency
+FROM campaigns c
+WHERE c.id = d.campaign_id AND d.original_amount IS NULL;
+
+ALTER TABLE donations ALTER COLUMN original_amount SET NOT NULL;
+
+-- Subscriptions charge in the currency the donor chose
+ALTER TABLE donation_subscriptions ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'ARS';
+
+-- Closure totals are reported in the campaign currency
+ALTER TABLE campaign_closure_reports ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'ARS';
+
+-- +goose Down
+ALTER TABLE campaign_closure_reports DROP COLUMN IF EXISTS currency;
+ALTER TABLE donation_subscriptions DROP COLUMN IF EXISTS currency;
+ALTER TABLE donations DROP COLUMN IF EXISTS exchange_rate_id;
+ALTER TABLE donations DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE donations DROP COLUMN IF EXISTS original_currency;
+ALTER TABLE donations DROP COLUMN IF EXISTS original_amount;
+ALTER TABLE donations DROP COLUMN IF EXISTS currency;
+DROP TABLE IF EXISTS exchange_rates;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,57 @@
+-- +goose Up
+-- Bank statements uploaded by admins for a campaign's transfer account
+CREATE TABLE IF NOT EXISTS bank_statements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id),
+    transfer_detail_id INTEGER NOT NULL REFERENCES transfer_details(id),
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('csv', 'ofx')),
+    currency VARCHAR(3) NOT NULL,
+    lines_imported INTEGER NOT NULL DEFAULT 0,
+    lines_duplicated INTEGER NOT NULL DEFAULT 0,
+    lines_skipped INTEGER NOT NULL DEFAULT 0,
+    uploaded_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statements_campaign_id ON bank_statements(campaign_id);
+
+-- Incoming transfers of a statement. Lines wait in the reconciliation queue until they are
+-- confirmed against a pending donation or ignored.
+CREATE TABLE IF NOT EXISTS bank_statement_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    statement_id UUID NOT NULL REFERENCES bank_statements(id) ON DELETE CASCADE,
+    campaign_id UUID NOT NULL REFERENCES campaigns(id),
+    transfer_detail_id INTEGER NOT NULL REFERENCES transfer_details(id),
+    line_number INTEGER NOT NULL,
+    posted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    payer_name VARCHAR(255),
+    payer_reference VARCHAR(100),
+    description TEXT,
+    external_id VARCHAR(255),
+    fingerprint VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'unmatched' CHECK (status IN ('unmatched', 'proposed', 'confirmed', 'ignored')),
+    donation_id UUID REFERENCES donations(id) ON DELETE SET NULL,
+    confidence DECIMAL(5,2),
+    match_reason TEXT,
+    notes TEXT,
+    reviewed_by UUID REFERENCES users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    -- The same bank movement is imported once, even if statements overlap
+    UNIQUE(transfer_detail_id, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_statement_id ON bank_statement_lines(statement_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_queue ON bank_statement_lines(campaign_id, status);
+
+-- A donation is matched by one line at most
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_statement_lines_donation_id ON bank_statement_lines(donation_id)
+    WHERE status IN ('proposed', 'confirmed');
+
+-- +goose Down
+DROP TABLE IF EXISTS bank_statement_lines;
+DROP TABLE IF EXISTS bank_statements;
@@ -0,0 +1,60 @@
+-- +goose Up
+-- Collectors register the cash donations dropped off at a campaign's cash locations
+INSERT INTO roles (id, name, description) VALUES
+    ('44444444-4444-4444-4444-444444444444', 'collector', 'Collector who registers cash donations at cash locations')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT '44444444-4444-4444-4444-444444444444', id FROM permissions
+WHERE name IN (
+    'campaigns:read',
+    'donations:create',
+    'donations:read',
+    'donors:create',
+    'donors:read'
+)
+ON CONFLICT DO NOTHING;
+
+-- Cash collected at a location during one day. The collector submits the batch with the total
+-- handed over and a second person confirms it, which completes its donations.
+CREATE TABLE IF NOT EXISTS cash_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id),
+    cash_location_id INTEGER NOT NULL REFERENCES cash_locations(id),
+    collection_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'submitted', 'confirmed')),
+    declared_total DECIMAL(10,2),
+    counted_total DECIMAL(10,2),
+    submitted_by UUID REFERENCES users(id),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    confirmed_by UUID REFERENCES users(id),
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(cash_location_id, collection_date),
+    -- The confirmation is the second signature of the batch
+    CHECK (confirmed_by IS NULL OR confirmed_by <> submitted_by)
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_batches_campaign_id ON cash_batches(campaign_id);
+CREATE INDEX IF NOT EXISTS idx_cash_batches_status ON cash_batches(status);
+
+-- Donations logged by a collector, with the batch they were collected in
+CREATE TABLE IF NOT EXISTS cash_donations (
+    donation_id UUID PRIMARY KEY REFERENCES donations(id) ON DELETE CASCADE,
+    batch_id UUID NOT NULL REFERENCES cash_batches(id),
+    collected_by UUID REFERENCES users(id),
+    collected_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    voided_at TIMESTAMP WITH TIME ZONE,
+    void_reason TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_donations_batch_id ON cash_donations(batch_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS cash_donations;
+DROP TABLE IF EXISTS cash_batches;
+DELETE FROM role_permissions WHERE role_id = '44444444-4444-4444-4444-444444444444';
+UPDATE users SET role_id = '33333333-3333-3333-3333-333333333333' WHERE role_id = '44444444-4444-4444-4444-444444444444';
+DELETE FROM roles WHERE id = '44444444-4444-4444-4444-444444444444';
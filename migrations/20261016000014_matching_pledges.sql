@@ -0,0 +1,51 @@
+-- +goose Up
+-- Matched donations are paid by the sponsor, not through a campaign payment method
+INSERT INTO payment_methods (code, name, is_active) VALUES
+    ('matching', 'Aporte de contrapartida', true)
+ON CONFLICT (code) DO NOTHING;
+
+-- Sponsors match the donations of a campaign at a ratio, up to a cap, during a time window.
+-- ratio 1 doubles every donation; cap and matched_amount are in the campaign currency.
+CREATE TABLE IF NOT EXISTS matching_pledges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id),
+    sponsor_organizer_id UUID NOT NULL REFERENCES organizers(id),
+    sponsor_donor_id UUID NOT NULL REFERENCES donors(id),
+    ratio DECIMAL(6,2) NOT NULL CHECK (ratio > 0),
+    cap DECIMAL(10,2) NOT NULL CHECK (cap > 0),
+    matched_amount DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (matched_amount >= 0 AND matched_amount <= cap),
+    currency VARCHAR(3) NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'cancelled')),
+    created_by UUID REFERENCES users(id),
+    cancelled_by UUID REFERENCES users(id),
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_matching_pledges_campaign_id ON matching_pledges(campaign_id, status);
+
+-- Matched donations point to the pledge that paid them and to the donation they match
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS matching_pledge_id UUID REFERENCES matching_pledges(id);
+ALTER TABLE donations ADD COLUMN IF NOT EXISTS matched_donation_id UUID REFERENCES donations(id);
+
+-- A pledge matches each donation once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_donations_matching ON donations(matching_pledge_id, matched_donation_id)
+    WHERE matching_pledge_id IS NOT NULL;
+
+-- Closure reports split the money raised from donors and from sponsors
+ALTER TABLE campaign_closure_reports ADD COLUMN IF NOT EXISTS organic_raised DECIMAL(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE campaign_closure_reports ADD COLUMN IF NOT EXISTS matched_raised DECIMAL(12,2) NOT NULL DEFAULT 0;
+
+UPDATE campaign_closure_reports SET organic_raised = total_raised;
+
+-- +goose Down
+ALTER TABLE campaign_closure_reports DROP COLUMN IF EXISTS matched_raised;
+ALTER TABLE campaign_closure_reports DROP COLUMN IF EXISTS organic_raised;
+DROP INDEX IF EXISTS idx_donations_matching;
+ALTER TABLE donations DROP COLUMN IF EXISTS matched_donation_id;
+ALTER TABLE donations DROP COLUMN IF EXISTS matching_pledge_id;
+DROP TABLE IF EXISTS matching_pledges;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Donors are linked to the user account that verified their email, so users can see their donations
+ALTER TABLE donors ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id);
+
+CREATE INDEX IF NOT EXISTS idx_donors_user_id ON donors(user_id);
+
+-- Tokens sent to a donor email to prove the user requesting the link owns it
+CREATE TABLE IF NOT EXISTS donor_link_tokens (
+    token VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_donor_link_tokens_user_id ON donor_link_tokens(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS donor_link_tokens;
+DROP INDEX IF EXISTS idx_donors_user_id;
+ALTER TABLE donors DROP COLUMN IF EXISTS user_id;
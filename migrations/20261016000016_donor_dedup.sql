@@ -0,0 +1,39 @@
+-- +goose Up
+-- Emails are stored lowercased. Addresses that differ only in casing from another donor are left
+-- as they are until the donors are merged.
+UPDATE donors d
+SET email = LOWER(TRIM(d.email)), updated_at = CURRENT_TIMESTAMP
+WHERE d.email <> LOWER(TRIM(d.email))
+  AND NOT EXISTS (
+      SELECT 1 FROM donors o
+      WHERE o.id <> d.id AND LOWER(TRIM(o.email)) = LOWER(TRIM(d.email))
+  );
+
+CREATE INDEX IF NOT EXISTS idx_donors_lower_email ON donors(LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_donors_phone ON donors(phone);
+
+-- Audit trail of donor merges. The merged donor is deleted, so its data is kept here; donor ids are
+-- not foreign keys because either side may be merged again later.
+CREATE TABLE IF NOT EXISTS donor_merges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    target_donor_id UUID NOT NULL,
+    source_donor_id UUID NOT NULL,
+    source_first_name VARCHAR(255) NOT NULL,
+    source_last_name VARCHAR(255) NOT NULL,
+    source_email VARCHAR(255) NOT NULL,
+    source_phone VARCHAR(50),
+    donations_moved INTEGER NOT NULL DEFAULT 0,
+    subscriptions_moved INTEGER NOT NULL DEFAULT 0,
+    pledges_moved INTEGER NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL,
+    merged_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_donor_merges_target_donor_id ON donor_merges(target_donor_id);
+CREATE INDEX IF NOT EXISTS idx_donor_merges_source_donor_id ON donor_merges(source_donor_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS donor_merges;
+DROP INDEX IF EXISTS idx_donors_phone;
+DROP INDEX IF EXISTS idx_donors_lower_email;
@@ -0,0 +1,52 @@
+-- +goose Up
+-- Anonymous donations without donor information no longer create a placeholder donor: they have no
+-- donor at all. Known donors who give anonymously stay linked to their donations.
+ALTER TABLE donations ALTER COLUMN donor_id DROP NOT NULL;
+
+-- Detach the donations of the placeholder donors created for anonymous donations and delete them
+UPDATE donations d
+SET donor_id = NULL, is_anonymous = true, updated_at = CURRENT_TIMESTAMP
+FROM donors dn
+WHERE dn.id = d.donor_id
+  AND dn.first_name = 'Anonymous'
+  AND dn.last_name = 'Donor'
+  AND dn.email = ''
+  AND COALESCE(dn.phone, '') = ''
+  AND dn.user_id IS NULL
+  AND NOT EXISTS (SELECT 1 FROM donation_subscriptions s WHERE s.donor_id = dn.id)
+  AND NOT EXISTS (SELECT 1 FROM matching_pledges p WHERE p.sponsor_donor_id = dn.id);
+
+DELETE FROM donors dn
+WHERE dn.first_name = 'Anonymous'
+  AND dn.last_name = 'Donor'
+  AND dn.email = ''
+  AND COALESCE(dn.phone, '') = ''
+  AND dn.user_id IS NULL
+  AND NOT EXISTS (SELECT 1 FROM donations d WHERE d.donor_id = dn.id)
+  AND NOT EXISTS (SELECT 1 FROM donation_subscriptions s WHERE s.donor_id = dn.id)
+  AND NOT EXISTS (SELECT 1 FROM matching_pledges p WHERE p.sponsor_donor_id = dn.id);
+
+-- Donors known only by their phone have no email. An empty email collided with the unique
+-- constraint as soon as a second donor had none, so missing emails are stored as NULL.
+ALTER TABLE donors ALTER COLUMN email DROP NOT NULL;
+UPDATE donors SET email = NULL WHERE email = '';
+
+-- A donation without donor is always anonymous
+ALTER TABLE donations ADD CONSTRAINT donations_donor_or_anonymous
+    CHECK (donor_id IS NOT NULL OR is_anonymous);
+
+-- +goose Down
+ALTER TABLE donations DROP CONSTRAINT IF EXISTS donations_donor_or_anonymous;
+
+-- Emails were required: donors without one get an address derived from their id
+UPDATE donors SET email = 'donor-' || id || '@no-email.invalid' WHERE email IS NULL;
+ALTER TABLE donors ALTER COLUMN email SET NOT NULL;
+
+-- Donations without donor get back a placeholder donor each, keyed by the donation id
+INSERT INTO donors (id, first_name, last_name, email)
+SELECT d.id, 'Anonymous', 'Donor', 'anonymous-' || d.id || '@no-email.invalid'
+FROM donations d
+WHERE d.donor_id IS NULL;
+
+UPDATE donations SET donor_id = id WHERE donor_id IS NULL;
+ALTER TABLE donations ALTER COLUMN donor_id SET NOT NULL;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Erased donors keep their row so their donations still count in campaign totals and closure
+-- reports, but every personal field is cleared
+ALTER TABLE donors ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP WITH TIME ZONE;
+
+-- Log of the data exports and erasures done for donors. donor_id is not a foreign key because the
+-- donor may be merged into another one later.
+CREATE TABLE IF NOT EXISTS donor_privacy_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    donor_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('export', 'erasure')),
+    format VARCHAR(10) CHECK (format IN ('json', 'zip')),
+    reason TEXT,
+    performed_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_donor_privacy_requests_donor_id ON donor_privacy_requests(donor_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS donor_privacy_requests;
+ALTER TABLE donors DROP COLUMN IF EXISTS erased_at;
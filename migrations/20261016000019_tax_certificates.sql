@@ -0,0 +1,24 @@
+-- +goose Up
+-- Yearly certificates of the completed donations of a donor, for tax deduction. A certificate is
+-- superseded when it is issued again after its donations changed, so only one per donor and fiscal
+-- year is current. donor_id is not a foreign key because the donor may be merged into another one.
+CREATE TABLE IF NOT EXISTS tax_certificates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    donor_id UUID NOT NULL,
+    fiscal_year INTEGER NOT NULL,
+    donor_name VARCHAR(511) NOT NULL,
+    lines JSONB NOT NULL,
+    totals JSONB NOT NULL,
+    donation_count INTEGER NOT NULL,
+    verification_hash VARCHAR(64) NOT NULL UNIQUE,
+    pdf_url VARCHAR(500) NOT NULL,
+    issued_by UUID REFERENCES users(id),
+    superseded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_certificates_current
+    ON tax_certificates(donor_id, fiscal_year) WHERE superseded_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS tax_certificates;
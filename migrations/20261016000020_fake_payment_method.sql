@@ -0,0 +1,10 @@
+-- +goose Up
+-- Payment method of the fake gateway, used to simulate online payments outside production. It is
+-- inactive so it is not offered to donors unless a development setup turns it on.
+INSERT INTO payment_methods (code, name, is_active) VALUES
+    ('fake', 'Pago simulado', false)
+ON CONFLICT (code) DO NOTHING;
+
+-- +goose Down
+DELETE FROM payment_methods WHERE code = 'fake'
+    AND NOT EXISTS (SELECT 1 FROM donations d WHERE d.payment_method_id = payment_methods.id);
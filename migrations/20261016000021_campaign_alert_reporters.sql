@@ -0,0 +1,11 @@
+-- +goose Up
+-- IP address of public reports, so that each reporter has at most one open alert per campaign
+ALTER TABLE campaign_alerts ADD COLUMN IF NOT EXISTS reporter_ip VARCHAR(45);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_alerts_open_reports
+    ON campaign_alerts(campaign_id, reported_by, reporter_ip)
+    WHERE status IN ('pending', 'investigating');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_campaign_alerts_open_reports;
+ALTER TABLE campaign_alerts DROP COLUMN IF EXISTS reporter_ip;
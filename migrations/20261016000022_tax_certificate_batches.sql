@@ -0,0 +1,19 @@
+-- +goose Up
+-- Year-end tax certificate batches run in the background, so that admins can follow their progress
+CREATE TABLE IF NOT EXISTS tax_certificate_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    fiscal_year INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'completed', 'failed')),
+    donors INTEGER NOT NULL DEFAULT 0,
+    issued JSONB NOT NULL DEFAULT '[]',
+    unchanged INTEGER NOT NULL DEFAULT 0,
+    failed JSONB NOT NULL DEFAULT '{}',
+    error TEXT,
+    started_by UUID REFERENCES users(id),
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS tax_certificate_batches;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- Every input of the transparency score is kept in the closure report, so that a closed campaign
+-- can be rescored under other rules without looking at data that changed after its closure
+ALTER TABLE campaign_closure_reports ADD COLUMN IF NOT EXISTS campaign_start TIMESTAMP WITH TIME ZONE;
+ALTER TABLE campaign_closure_reports ADD COLUMN IF NOT EXISTS campaign_end TIMESTAMP WITH TIME ZONE;
+ALTER TABLE campaign_closure_reports ADD COLUMN IF NOT EXISTS has_contract BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE campaign_closure_reports ADD COLUMN IF NOT EXISTS activity_interval_days DECIMAL(8,2) NOT NULL DEFAULT 30;
+
+-- Existing reports take the inputs as they were at closure time: completed campaigns cannot be
+-- edited, and only activities up to the closure are counted
+UPDATE campaign_closure_reports r
+SET campaign_start = c.start_date,
+    campaign_end = c.end_date,
+    has_contract = EXISTS (SELECT 1 FROM campaign_contracts cc WHERE cc.campaign_id = r.campaign_id),
+    activity_interval_days = COALESCE((
+        SELECT AVG(EXTRACT(EPOCH FROM (a.date - a.prev_date)) / 86400)
+        FROM (
+            SELECT date, LAG(date) OVER (ORDER BY date) AS prev_date
+            FROM activities
+            WHERE campaign_id = r.campaign_id AND date <= r.closed_at
+        ) a
+        WHERE a.prev_date IS NOT NULL
+    ), 30)
+FROM campaigns c
+WHERE c.id = r.campaign_id;
+
+ALTER TABLE campaign_closure_reports ALTER COLUMN campaign_start SET NOT NULL;
+ALTER TABLE campaign_closure_reports ALTER COLUMN campaign_end SET NOT NULL;
+
+-- +goose Down
+ALTER TABLE campaign_closure_reports DROP COLUMN IF EXISTS activity_interval_days;
+ALTER TABLE campaign_closure_reports DROP COLUMN IF EXISTS has_contract;
+ALTER TABLE campaign_closure_reports DROP COLUMN IF EXISTS campaign_end;
+ALTER TABLE campaign_closure_reports DROP COLUMN IF EXISTS campaign_start;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- One open public report per reporter and campaign is enforced by the database, so concurrent
+-- reports cannot both pass the check in the API. Existing duplicates after the first report are dismissed.
+UPDATE campaign_alerts ca
+SET status = 'dismissed',
+    resolution_notes = 'Duplicate report from the same reporter',
+    resolved_at = NOW()
+WHERE ca.reporter_ip IS NOT NULL
+  AND ca.status IN ('pending', 'investigating')
+  AND EXISTS (
+      SELECT 1 FROM campaign_alerts newer
+      WHERE newer.campaign_id = ca.campaign_id
+        AND newer.reporter_ip IS NOT NULL
+        AND newer.status IN ('pending', 'investigating')
+        AND COALESCE(newer.reported_by::text, newer.reporter_ip) = COALESCE(ca.reported_by::text, ca.reporter_ip)
+        AND (newer.created_at, newer.id) < (ca.created_at, ca.id)
+  );
+
+DROP INDEX IF EXISTS idx_campaign_alerts_open_reports;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_campaign_alerts_open_reports
+    ON campaign_alerts(campaign_id, COALESCE(reported_by::text, reporter_ip))
+    WHERE status IN ('pending', 'investigating') AND reporter_ip IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_campaign_alerts_open_reports;
+
+CREATE INDEX IF NOT EXISTS idx_campaign_alerts_open_reports
+    ON campaign_alerts(campaign_id, reported_by, reporter_ip)
+    WHERE status IN ('pending', 'investigating');
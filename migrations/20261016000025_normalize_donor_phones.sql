@@ -0,0 +1,84 @@
+-- +goose Up
+-- Existing donor phones are written in E.164 format with the same rules as donor.NormalizePhone, so
+-- that local and international forms of an Argentine number match. Phones of other countries and
+-- phones that cannot be normalized are left as they are.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION normalize_donor_phone(phone TEXT) RETURNS TEXT AS $$
+DECLARE
+    digits TEXT;
+    national TEXT;
+    international BOOLEAN;
+    mobile BOOLEAN := FALSE;
+    area_code_length INTEGER;
+BEGIN
+    IF phone IS NULL OR phone !~ '^\s*\+?[0-9 ().-]+\s*$' THEN
+        RETURN phone;
+    END IF;
+
+    international := btrim(phone) LIKE '+%';
+    digits := regexp_replace(phone, '[^0-9]', '', 'g');
+    IF NOT international AND digits LIKE '00%' THEN
+        digits := substr(digits, 3);
+        international := TRUE;
+    END IF;
+    -- No Argentine area code starts with 5, so a local phone starting with 54 already has the country code
+    IF NOT international AND digits LIKE '54%' THEN
+        international := TRUE;
+    END IF;
+
+    IF international THEN
+        IF digits NOT LIKE '54%' THEN
+            RETURN phone;
+        END IF;
+        national := substr(digits, 3);
+    ELSE
+        national := digits;
+    END IF;
+
+    -- Mobiles are dialed internationally with 9 before the area code and locally with 15 after it
+    IF national LIKE '9%' THEN
+        national := substr(national, 2);
+        mobile := TRUE;
+    END IF;
+    national := ltrim(national, '0');
+
+    IF length(national) = 12 THEN
+        IF national LIKE '11%' THEN
+            area_code_length := 2;
+        ELSIF substr(national, 1, 3) IN ('220', '221', '223', '230', '236', '237', '249', '260', '261',
+            '263', '264', '266', '280', '291', '294', '297', '299', '336', '341', '342', '343', '345',
+            '348', '351', '353', '358', '362', '364', '370', '376', '379', '380', '381', '383', '385',
+            '387', '388') THEN
+            area_code_length := 3;
+        ELSE
+            area_code_length := 4;
+        END IF;
+
+        IF substr(national, area_code_length + 1, 2) = '15' THEN
+            national := substr(national, 1, area_code_length) || substr(national, area_code_length + 3);
+            mobile := TRUE;
+        END IF;
+    END IF;
+
+    IF length(national) <> 10 THEN
+        RETURN phone;
+    END IF;
+    IF mobile THEN
+        RETURN '+549' || national;
+    END IF;
+    RETURN '+54' || national;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+-- +goose StatementEnd
+
+UPDATE donors
+SET phone = normalize_donor_phone(phone), updated_at = CURRENT_TIMESTAMP
+WHERE phone IS NOT NULL
+  AND phone <> ''
+  AND normalize_donor_phone(phone) <> phone;
+
+DROP FUNCTION normalize_donor_phone(TEXT);
+
+-- +goose Down
+-- Phones are not restored to the way they were written
+SELECT 1;
@@ -0,0 +1,215 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Amount is an exact amount of money, stored as a whole number of cents.
+//
+// Rounding rules:
+//   - Sums and differences of amounts are exact.
+//   - Amounts in requests must have at most two decimals; more are rejected, never rounded.
+//   - Database values with more decimals (such as AVG results), amounts built from floats,
+//     converted with an exchange rate or divided are rounded to the cent, halves away from zero.
+//     This matches ROUND() on PostgreSQL numerics.
+type Amount int64
+
+// Zero is an amount of no money
+const Zero Amount = 0
+
+var (
+	ErrInvalidAmount   = errors.New("invalid amount")
+	ErrTooManyDecimals = errors.New("amount cannot have more than 2 decimal places")
+)
+
+// FromCents creates an amount from a number of cents
+func FromCents(cents int64) Amount {
+	return Amount(cents)
+}
+
+// FromFloat creates an amount from a float, rounding to the cent. The float is read in its
+// shortest decimal form, so 1.005 becomes 1.01.
+func FromFloat(value float64) Amount {
+	amount, err := ParseRounded(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return Zero
+	}
+	return amount
+}
+
+// Parse parses a decimal string such as "1234.5". It fails if the value has more than two decimals.
+func Parse(value string) (Amount, error) {
+	return parse(value, false)
+}
+
+// ParseRounded parses a decimal string, rounding it to the cent
+func ParseRounded(value string) (Amount, error) {
+	return parse(value, true)
+}
+
+func parse(value string, round bool) (Amount, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.Contains(value, "/") {
+		return Zero, ErrInvalidAmount
+	}
+
+	cents, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Zero, ErrInvalidAmount
+	}
+	cents.Mul(cents, big.NewRat(100, 1))
+
+	result := new(big.Int)
+	if cents.IsInt() {
+		result.Set(cents.Num())
+	} else {
+		if !round {
+			return Zero, ErrTooManyDecimals
+		}
+		remainder := new(big.Int)
+		result.QuoRem(cents.Num(), cents.Denom(), remainder)
+		// Halves round away from zero
+		if remainder.Abs(remainder).Mul(remainder, big.NewInt(2)).Cmp(cents.Denom()) >= 0 {
+			result.Add(result, big.NewInt(int64(cents.Sign())))
+		}
+	}
+
+	if !result.IsInt64() {
+		return Zero, ErrInvalidAmount
+	}
+	return Amount(result.Int64()), nil
+}
+
+// Cents returns the amount as a number of cents
+func (a Amount) Cents() int64 {
+	return int64(a)
+}
+
+// Float64 returns the amount as a float. Use it for ratios and percentages, never to add amounts.
+func (a Amount) Float64() float64 {
+	return float64(a) / 100
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (a Amount) IsPositive() bool {
+	return a > 0
+}
+
+// MulRate multiplies the amount by a rate, such as an exchange rate, rounding to the cent
+func (a Amount) MulRate(rate float64) Amount {
+	return Amount(math.Round(float64(a) * rate))
+}
+
+// Div divides the amount in n parts, rounding to the cent. Dividing by zero returns zero.
+func (a Amount) Div(n int64) Amount {
+	if n == 0 {
+		return Zero
+	}
+	quotient, remainder := int64(a)/n, int64(a)%n
+	if 2*abs(remainder) >= abs(n) {
+		if (remainder < 0) != (n < 0) {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return Amount(quotient)
+}
+
+// Ratio returns a / b, or 0 when b is zero
+func (a Amount) Ratio(b Amount) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
+
+// String formats the amount with two decimals, such as "1234.50"
+func (a Amount) String() string {
+	sign := ""
+	cents := int64(a)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Format formats the amount for documents, such as "ARS $1234.50"
+func (a Amount) Format(currency string) string {
+	if currency == "" {
+		return "$" + a.String()
+	}
+	return currency + " $" + a.String()
+}
+
+// MarshalJSON encodes the amount as a JSON number with two decimals
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or numeric string with at most two decimals
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	amount, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// Value implements the driver.Valuer interface, storing the amount as an exact decimal
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for DECIMAL columns and aggregates
+func (a *Amount) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*a = Zero
+		return nil
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	case float64:
+		*a = FromFloat(v)
+		return nil
+	case int64:
+		*a = Amount(v * 100)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into money.Amount", value)
+	}
+}
+
+func (a *Amount) scanString(value string) error {
+	amount, err := ParseRounded(value)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into money.Amount: %w", value, err)
+	}
+	*a = amount
+	return nil
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,216 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Amount
+		err   error
+	}{
+		{"0", 0, nil},
+		{"1", 100, nil},
+		{"1234.5", 123450, nil},
+		{"1234.56", 123456, nil},
+		{" 0.01 ", 1, nil},
+		{"-12.34", -1234, nil},
+		{"99999999.99", 9999999999, nil},
+		{"1.005", 0, ErrTooManyDecimals},
+		{"0.001", 0, ErrTooManyDecimals},
+		{"", 0, ErrInvalidAmount},
+		{"abc", 0, ErrInvalidAmount},
+		{"1/3", 0, ErrInvalidAmount},
+		{"1e30", 0, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.value)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.value, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseRounded(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Amount
+	}{
+		{"1.004", 100},
+		{"1.005", 101},
+		{"1.015", 102},
+		{"-1.005", -101},
+		{"-1.004", -100},
+		{"2.675", 268},
+		{"0.125", 13},
+		{"33.333333333333333333", 3333},
+		{"1234.56", 123456},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRounded(tt.value)
+		if err != nil {
+			t.Errorf("ParseRounded(%q) error = %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRounded(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDiv(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		n      int64
+		want   Amount
+	}{
+		{1000, 3, 333},
+		{1000, 4, 250},
+		{1001, 2, 501},
+		{1003, 2, 502},
+		{999, 2, 500},
+		{-1001, 2, -501},
+		{1001, -2, -501},
+		{-1001, -2, 501},
+		{200, 3, 67},
+		{100, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Div(tt.n); got != tt.want {
+			t.Errorf("Amount(%d).Div(%d) = %d, want %d", tt.amount, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestMulRate(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		rate   float64
+		want   Amount
+	}{
+		{10000, 1, 10000},
+		{10000, 1050.5, 10505000},
+		{100, 0.001, 0},
+		{100, 0.005, 1},
+		{12345, 0.5, 6173},
+		{-12345, 0.5, -6173},
+		{1999, 1.1, 2199},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.MulRate(tt.rate); got != tt.want {
+			t.Errorf("Amount(%d).MulRate(%v) = %d, want %d", tt.amount, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  Amount
+	}{
+		{"nil", nil, 0},
+		{"bytes", []byte("1234.56"), 123456},
+		{"string", "0.10", 10},
+		{"average", "33.3333333333333333", 3333},
+		{"float", float64(19.99), 1999},
+		{"integer", int64(42), 4200},
+	}
+
+	for _, tt := range tests {
+		var got Amount = 1
+		if err := got.Scan(tt.value); err != nil {
+			t.Errorf("Scan(%s) error = %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Scan(%s) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	var amount Amount
+	if err := amount.Scan(true); err == nil {
+		t.Error("Scan(bool) error = nil, want error")
+	}
+	if err := amount.Scan("abc"); err == nil {
+		t.Error(`Scan("abc") error = nil, want error`)
+	}
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		want   string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{123450, "1234.50"},
+		{-1234, "-12.34"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.amount.Value()
+		if err != nil {
+			t.Errorf("Amount(%d).Value() error = %v", tt.amount, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Amount(%d).Value() = %v, want %s", tt.amount, got, tt.want)
+		}
+
+		var scanned Amount
+		if err := scanned.Scan(got); err != nil || scanned != tt.amount {
+			t.Errorf("Scan(Value()) = %d, %v, want %d", scanned, err, tt.amount)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type payload struct {
+		Amount Amount `json:"amount"`
+	}
+
+	encoded, err := json.Marshal(payload{Amount: 123450})
+	if err != nil {
+		t.Fatalf("Marshal error = %v", err)
+	}
+	if string(encoded) != `{"amount":1234.50}` {
+		t.Errorf("Marshal = %s, want {\"amount\":1234.50}", encoded)
+	}
+
+	tests := []struct {
+		data string
+		want Amount
+		err  bool
+	}{
+		{`{"amount":1234.5}`, 123450, false},
+		{`{"amount":"1234.56"}`, 123456, false},
+		{`{"amount":0.1}`, 10, false},
+		{`{"amount":null}`, 0, false},
+		{`{"amount":1.005}`, 0, true},
+		{`{"amount":"abc"}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		var got payload
+		err := json.Unmarshal([]byte(tt.data), &got)
+		if (err != nil) != tt.err {
+			t.Errorf("Unmarshal(%s) error = %v, want error %v", tt.data, err, tt.err)
+			continue
+		}
+		if !tt.err && got.Amount != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.data, got.Amount, tt.want)
+		}
+	}
+}
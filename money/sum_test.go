@@ -0,0 +1,114 @@
+package money
+
+import (
+	"math/big"
+	"math/rand"
+	"os"
+	"testing"
+
+	"dona_tutti_api/database"
+
+	"gorm.io/gorm"
+)
+
+// testAmounts returns amounts like the ones donations carry: many small amounts with cents that
+// floats cannot represent exactly, and a few large ones, with partial refunds
+func testAmounts() (amounts, refunded []Amount) {
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < 1000; i++ {
+		var amount Amount
+		switch i % 4 {
+		case 0:
+			amount = 10 // 0.10
+		case 1:
+			amount = Amount(random.Int63n(10000) + 1)
+		case 2:
+			amount = Amount(random.Int63n(100000000) + 1)
+		default:
+			amount = 1999 // 19.99
+		}
+		refund := Zero
+		if i%7 == 0 {
+			refund = Amount(random.Int63n(int64(amount)) + 1)
+		}
+		amounts = append(amounts, amount)
+		refunded = append(refunded, refund)
+	}
+	return amounts, refunded
+}
+
+func sumAmounts(amounts, refunded []Amount) (sum, net Amount) {
+	for i := range amounts {
+		sum += amounts[i]
+		net += amounts[i] - refunded[i]
+	}
+	return sum, net
+}
+
+// TestSumMatchesDecimalArithmetic checks the sums against exact decimal arithmetic, which is what
+// SUM() does on PostgreSQL numerics
+func TestSumMatchesDecimalArithmetic(t *testing.T) {
+	amounts, refunded := testAmounts()
+	sum, net := sumAmounts(amounts, refunded)
+
+	decimalSum, decimalNet := new(big.Rat), new(big.Rat)
+	for i := range amounts {
+		amount, _ := new(big.Rat).SetString(amounts[i].String())
+		refund, _ := new(big.Rat).SetString(refunded[i].String())
+		decimalSum.Add(decimalSum, amount)
+		decimalNet.Add(decimalNet, new(big.Rat).Sub(amount, refund))
+	}
+
+	if got := decimalSum.FloatString(2); got != sum.String() {
+		t.Errorf("sum = %s, want %s", sum, got)
+	}
+	if got := decimalNet.FloatString(2); got != net.String() {
+		t.Errorf("net sum = %s, want %s", net, got)
+	}
+}
+
+// TestSumMatchesSQL stores the amounts in a DECIMAL(10,2) column like donations.amount and checks that
+// SUM(amount) and SUM(amount - refunded_amount) equal the sums of the amounts. It needs a PostgreSQL
+// database configured with the DB_* variables and is skipped when DB_HOST is not set.
+func TestSumMatchesSQL(t *testing.T) {
+	if testing.Short() || os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST not set: skipping SQL sum test")
+	}
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+
+	amounts, refunded := testAmounts()
+	sum, net := sumAmounts(amounts, refunded)
+
+	var sqlSum, sqlNet Amount
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`CREATE TEMP TABLE money_sum_test (
+			amount DECIMAL(10,2) NOT NULL,
+			refunded_amount DECIMAL(10,2) NOT NULL
+		) ON COMMIT DROP`).Error
+		if err != nil {
+			return err
+		}
+		for i := range amounts {
+			err := tx.Exec("INSERT INTO money_sum_test (amount, refunded_amount) VALUES (?, ?)", amounts[i], refunded[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Raw("SELECT SUM(amount), SUM(amount - refunded_amount) FROM money_sum_test").
+			Row().Scan(&sqlSum, &sqlNet)
+	})
+	if err != nil {
+		t.Fatalf("failed to sum amounts in SQL: %v", err)
+	}
+
+	if sqlSum != sum {
+		t.Errorf("SUM(amount) = %s, want %s", sqlSum, sum)
+	}
+	if sqlNet != net {
+		t.Errorf("SUM(amount - refunded_amount) = %s, want %s", sqlNet, net)
+	}
+}
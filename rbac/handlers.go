@@ -1,9 +1,12 @@
 package rbac
 
 import (
-	"dona_tutti_api/middleware"
+	"errors"
 	"net/http"
 
+	apierrors "dona_tutti_api/errors"
+	"dona_tutti_api/middleware"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -32,6 +35,10 @@ func RegisterRoutes(g *echo.Group, service Service) {
 	roleGroup.GET("", handler.ListRoles)
 	roleGroup.GET("/:name", handler.GetRoleByName)
 
+	// User role assignment (admin only)
+	userRoleGroup := g.Group("/users/:id/role", middleware.RequireAuth(), rbacMiddleware.RequireRole("admin"))
+	userRoleGroup.PUT("", handler.AssignUserRole)
+
 	// Permission management routes (admin only)
 	permissionGroup := g.Group("/permissions", middleware.RequireAuth(), rbacMiddleware.RequireRole("admin"))
 	permissionGroup.GET("", handler.ListPermissions)
@@ -89,6 +96,48 @@ func (h *Handler) GetRoleByName(c echo.Context) error {
 	return c.JSON(http.StatusOK, role)
 }
 
+// AssignUserRoleRequest represents a request to change the role of a user
+type AssignUserRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+// @Summary Assign a role to a user
+// @Description Change the role of a user, such as admin, donor, guest or collector
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param role body AssignUserRoleRequest true "Role name"
+// @Success 200 {object} Role
+// @Failure 400 {object} errors.APIError
+// @Failure 401 {object} errors.APIError
+// @Failure 403 {object} errors.APIError
+// @Failure 404 {object} errors.APIError
+// @Router /users/{id}/role [put]
+func (h *Handler) AssignUserRole(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req AssignUserRoleRequest
+	if err := c.Bind(&req); err != nil || req.Role == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Role is required")
+	}
+
+	role, err := h.service.AssignUserRole(c.Request().Context(), userID, req.Role)
+	if err != nil {
+		var notFoundErr apierrors.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to assign role")
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
 // @Summary List all permissions
 // @Description Get a list of all permissions in the system
 // @Tags permissions
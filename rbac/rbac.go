@@ -51,16 +51,18 @@ type PermissionCheck struct {
 
 // Default role constants
 const (
-	RoleAdmin = "admin"
-	RoleDonor = "donor"
-	RoleGuest = "guest"
+	RoleAdmin     = "admin"
+	RoleDonor     = "donor"
+	RoleGuest     = "guest"
+	RoleCollector = "collector"
 )
 
 // Default role UUIDs (matching migration)
 var (
-	AdminRoleID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
-	DonorRoleID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
-	GuestRoleID = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	AdminRoleID     = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	DonorRoleID     = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	GuestRoleID     = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	CollectorRoleID = uuid.MustParse("44444444-4444-4444-4444-444444444444")
 )
 
 // Permission constants
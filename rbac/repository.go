@@ -2,12 +2,21 @@ package rbac
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrRoleNotFound is returned when no active role has the requested ID or name
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrUserNotFound is returned when the user does not exist
+	ErrUserNotFound = errors.New("user not found")
+)
+
 // LocalAuthContext represents the authentication context for a user (local to rbac package)
 type LocalAuthContext struct {
 	UserID      uuid.UUID `json:"user_id"`
@@ -37,6 +46,10 @@ type Repository interface {
 
 	// User context operations
 	GetUserAuthContext(ctx context.Context, userID uuid.UUID) (interface{}, error)
+	UpdateUserRole(ctx context.Context, userID, roleID uuid.UUID) error
+
+	// Ownership operations
+	IsCampaignOwner(ctx context.Context, userID, campaignID uuid.UUID) (bool, error)
 }
 
 type repository struct {
@@ -52,7 +65,7 @@ func (r *repository) GetRoleByID(ctx context.Context, id uuid.UUID) (*Role, erro
 	var model RoleModel
 	if err := r.db.WithContext(ctx).Where("id = ? AND is_active = ?", id, true).First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("role not found")
+			return nil, ErrRoleNotFound
 		}
 		return nil, fmt.Errorf("failed to get role: %w", err)
 	}
@@ -64,7 +77,7 @@ func (r *repository) GetRoleByName(ctx context.Context, name string) (*Role, err
 	var model RoleModel
 	if err := r.db.WithContext(ctx).Where("name = ? AND is_active = ?", name, true).First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("role not found")
+			return nil, ErrRoleNotFound
 		}
 		return nil, fmt.Errorf("failed to get role: %w", err)
 	}
@@ -207,7 +220,7 @@ func (r *repository) GetUserAuthContext(ctx context.Context, userID uuid.UUID) (
 	
 	if err := r.db.WithContext(ctx).Raw(query, userID).Scan(&result).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user auth context: %w", err)
 	}
@@ -229,4 +242,30 @@ func (r *repository) GetUserAuthContext(ctx context.Context, userID uuid.UUID) (
 		RoleName:    result.RoleName,
 		Permissions: permissionNames,
 	}, nil
-}
\ No newline at end of file
+}
+
+func (r *repository) UpdateUserRole(ctx context.Context, userID, roleID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Table("users").Where("id = ?", userID).
+		Updates(map[string]interface{}{"role_id": roleID, "updated_at": time.Now()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update user role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// IsCampaignOwner reports whether the user is the organizer of the campaign
+func (r *repository) IsCampaignOwner(ctx context.Context, userID, campaignID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("campaigns c").
+		Joins("JOIN organizers o ON o.id = c.organizer_id").
+		Where("c.id = ? AND o.user_id = ?", campaignID, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check campaign ownership: %w", err)
+	}
+	return count > 0, nil
+}
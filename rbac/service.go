@@ -2,9 +2,12 @@ package rbac
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	apierrors "dona_tutti_api/errors"
+
 	"github.com/google/uuid"
 )
 
@@ -23,6 +26,7 @@ type Service interface {
 	// Role management
 	ListRoles(ctx context.Context) ([]Role, error)
 	GetRoleByName(ctx context.Context, name string) (*Role, error)
+	AssignUserRole(ctx context.Context, userID uuid.UUID, roleName string) (*Role, error)
 
 	// Permission management
 	ListPermissions(ctx context.Context) ([]Permission, error)
@@ -108,6 +112,9 @@ func (s *service) ValidateResourceOwnership(ctx context.Context, userID uuid.UUI
 	case ResourceUsers:
 		// Users can only access their own profile
 		return userID == resourceID, nil
+	case ResourceCampaigns:
+		// Campaigns belong to the user of their organizer
+		return s.repo.IsCampaignOwner(ctx, userID, resourceID)
 	case ResourceDonations:
 		// TODO: Check if user owns the donation
 		// This would require a query to the donations table
@@ -144,6 +151,26 @@ func (s *service) GetRoleByName(ctx context.Context, name string) (*Role, error)
 	return s.repo.GetRoleByName(ctx, name)
 }
 
+// AssignUserRole changes the role of a user, such as making them a collector
+func (s *service) AssignUserRole(ctx context.Context, userID uuid.UUID, roleName string) (*Role, error) {
+	role, err := s.repo.GetRoleByName(ctx, strings.ToLower(strings.TrimSpace(roleName)))
+	if err != nil {
+		if errors.Is(err, ErrRoleNotFound) {
+			return nil, apierrors.NewNotFoundError(err.Error())
+		}
+		return nil, err
+	}
+
+	if err := s.repo.UpdateUserRole(ctx, userID, role.ID); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, apierrors.NewNotFoundError(err.Error())
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
 func (s *service) ListPermissions(ctx context.Context) ([]Permission, error) {
 	return s.repo.ListPermissions(ctx)
 }
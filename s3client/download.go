@@ -0,0 +1,36 @@
+package s3client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Download reads a file from S3 using the full URL or key
+func (c *Client) Download(ctx context.Context, urlOrKey string) ([]byte, error) {
+	key := extractKeyFromURL(urlOrKey, c.bucketName)
+	if key == "" {
+		return nil, fmt.Errorf("invalid URL or key provided")
+	}
+
+	getInput := &s3.GetObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(key),
+	}
+
+	output, err := c.s3Client.GetObject(ctx, getInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file from S3: %w", err)
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file from S3: %w", err)
+	}
+
+	return data, nil
+}
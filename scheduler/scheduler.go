@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"dona_tutti_api/database"
+
+	"gorm.io/gorm"
+)
+
+// Task is one pass of a scheduled job. It logs its own results.
+type Task func(ctx context.Context) error
+
+// Scheduler periodically runs a task on the API replica that holds its Postgres advisory lock,
+// so that only one replica runs it at a time
+type Scheduler struct {
+	name     string
+	db       *gorm.DB
+	lockKey  int64
+	interval time.Duration
+	task     Task
+}
+
+// New creates a scheduler that runs task every interval while holding the lockKey advisory lock
+func New(name string, db *gorm.DB, lockKey int64, interval time.Duration, task Task) *Scheduler {
+	return &Scheduler{
+		name:     name,
+		db:       db,
+		lockKey:  lockKey,
+		interval: interval,
+		task:     task,
+	}
+}
+
+// Start runs the scheduler until the context is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Printf("⏰ %s scheduler started (interval: %s)", s.name, s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("⏰ %s scheduler stopped", s.name)
+			return
+		case <-ticker.C:
+			s.run(ctx)
+		}
+	}
+}
+
+// run executes a single pass if this replica acquires the advisory lock
+func (s *Scheduler) run(ctx context.Context) {
+	acquired, err := database.WithAdvisoryLock(ctx, s.db, s.lockKey, s.task)
+	if err != nil {
+		log.Printf("❌ %s run failed: %v", s.name, err)
+		return
+	}
+	if !acquired {
+		log.Printf("⏭️  %s skipped: another instance holds the lock", s.name)
+	}
+}